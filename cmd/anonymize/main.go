@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/pkg/anonymize"
+	"cmd/main.go/internal/repo/export"
+	"cmd/main.go/internal/repo/memory"
+	"cmd/main.go/internal/repo/pii"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+const usage = `usage: anonymize [-config config.yml] [-local] [-format sql|ndjson] [-out FILE] [-seed SEED] [-column name=strategy]...
+
+Writes users of the database and its shards with personal data replaced by deterministic fakes,
+ids, relations and timestamps are kept. SQL dump inserts users into an empty users table of staging.
+
+strategies: keep, fake_name, fake_email, hash, empty, set by anonymize.columns of config and -column
+
+flags:
+`
+
+func main() {
+	configPath := flag.String("config", "config.yml", "path to config file")
+	local := flag.Bool("local", false, "use database1 (make run) settings")
+	format := flag.String("format", anonymize.FormatSQL, "dump format: sql or ndjson")
+	outPath := flag.String("out", "-", "output file, - for stdout")
+	seed := flag.String("seed", "", "secret of fakes, replaces anonymize.seed of config")
+	batchSize := flag.Uint64("batch", 1000, "users read at once")
+	columns := map[string]string{}
+	flag.Func("column", "strategy of column as name=strategy, replaces anonymize.columns of config, repeatable", func(v string) error {
+		column, strategy, ok := strings.Cut(v, "=")
+		if !ok {
+			return fmt.Errorf("want name=strategy, got %q", v)
+		}
+		columns[strings.TrimSpace(column)] = strategy
+
+		return nil
+	})
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := config.ReadConfigYML(*configPath); err != nil {
+		log.Fatalf("failed init configuration: %v", err)
+	}
+	cfg := config.GetConfigInstance()
+
+	dbCfg := cfg.Database
+	if *local {
+		dbCfg = config.Database(cfg.Database1)
+	}
+	if dbCfg.Driver == memory.Driver {
+		log.Fatal("in-memory storage has nothing to export")
+	}
+
+	if *seed == "" {
+		*seed = cfg.Anonymize.Seed
+	}
+	rules := anonymize.ParseRules(cfg.Anonymize.Columns)
+	for column, strategy := range anonymize.ParseRules(columns) {
+		rules[column] = strategy
+	}
+
+	anonymizer, err := anonymize.New(*seed, rules)
+	if err != nil {
+		log.Fatalf("invalid anonymization rules: %v", err)
+	}
+
+	fields, err := pii.Load(cfg.Encryption)
+	if err != nil {
+		log.Fatalf("failed loading encryption keys: %v", err)
+	}
+
+	var out io.Writer = os.Stdout
+	if *outPath != "-" {
+		file, err := os.Create(filepath.Clean(*outPath))
+		if err != nil {
+			log.Fatalf("failed creating output: %v", err)
+		}
+		//nolint
+		defer file.Close()
+		out = file
+	}
+
+	writer, err := anonymize.NewWriter(*format, out)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	// users of sharded deployments live on shards, primary database has none of them
+	dsns := map[string]string{"primary": dbCfg.DSN()}
+	names := []string{"primary"}
+	for _, s := range dbCfg.Shards {
+		dsns[s.Name] = dbCfg.ShardDSN(s)
+		names = append(names, s.Name)
+	}
+
+	var total int64
+	for _, name := range names {
+		db, closeDB, err := database.Open(ctx, "anonymize_"+name, dsns[name], dbCfg.Driver, dbCfg.Pool)
+		if err != nil {
+			log.Fatalf("failed opening database %s: %v", name, err)
+		}
+
+		n, err := export.Users(ctx, db, fields, *batchSize, func(u model.UserRequest) error {
+			return writer.Write(anonymizer.User(u))
+		})
+		closeDB()
+		if err != nil {
+			log.Fatalf("failed exporting users of %s: %v", name, err)
+		}
+		total += n
+	}
+
+	if err = writer.Close(); err != nil {
+		log.Fatalf("failed writing dump: %v", err)
+	}
+
+	log.Printf("exported %d users with %s", total, strings.Join(anonymizer.Rules(), ", "))
+}
@@ -6,17 +6,32 @@ import (
 	"log"
 	"time"
 
+	"cmd/main.go/internal/service/duplicate"
+	"cmd/main.go/internal/service/group"
+	"cmd/main.go/internal/service/keyrotation"
 	"cmd/main.go/internal/service/user_request"
 
 	"cmd/main.go/internal/repo"
+	"cmd/main.go/internal/repo/cache"
+	duplicaterepo "cmd/main.go/internal/repo/duplicate"
+	erasurerepo "cmd/main.go/internal/repo/erasure"
+	eventrepo "cmd/main.go/internal/repo/event"
+	grouprepo "cmd/main.go/internal/repo/group"
+	"cmd/main.go/internal/repo/memory"
+	"cmd/main.go/internal/repo/pii"
+	"cmd/main.go/internal/repo/sharded"
 
 	"cmd/main.go/internal/config"
 	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/database/migrate"
+	"cmd/main.go/internal/pkg/hashring"
+	"cmd/main.go/internal/pkg/health"
+	"cmd/main.go/internal/pkg/profile"
 	"cmd/main.go/internal/server"
+	"cmd/main.go/migrations"
 
-	_ "github.com/jackc/pgx/v4"
-	_ "github.com/jackc/pgx/v4/stdlib"
-	_ "github.com/lib/pq"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
 )
 
 var (
@@ -34,30 +49,224 @@ func main() {
 		)
 	}
 	cfg := config.GetConfigInstance()
+	dbCfg := config.Database(cfg.Database1)
+
+	if err := cfg.Validate(dbCfg); err != nil {
+		log.Fatal(ctx, fmt.Sprintf("%s: invalid configuration", grpsServerMainLogTag),
+			"err", err,
+		)
+	}
 
 	initCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	dsn := fmt.Sprintf("host=%v port=%v user=%v password=%v dbname=%v sslmode=%v",
-		cfg.Database1.Host,
-		cfg.Database1.Port,
-		cfg.Database1.User,
-		cfg.Database1.Password,
-		cfg.Database1.Name,
-		cfg.Database1.SslMode,
-	)
-
-	db, err := database.NewPostgres(initCtx, dsn, cfg.Database.Driver)
+	profileValidator, err := profile.NewValidatorFromFile(cfg.Profile.SchemaPath)
 	if err != nil {
+		log.Print(ctx, fmt.Sprintf("%s: failed loading profile schema", grpsServerMainLogTag), "err", err)
+
 		return
 	}
-	defer db.Close()
 
-	requestRepository := repo.NewUserRequestRepo(db, batchSize)
+	var (
+		transactor        database.Transactor
+		requestRepository repo.UserRequestRepo
+		groupRepository   grouprepo.Repo
+		eventRepository   eventrepo.Repo
+		erasureRepository erasurerepo.Repo
+		duplicateService  duplicate.ServiceInterface
+		// checks - dependencies of readiness, in-memory storage has none
+		checks []health.Check
+	)
+
+	if dbCfg.Driver == memory.Driver {
+		store := memory.NewStore()
+		transactor = store.Transactor()
+		requestRepository = memory.NewUserRequestRepo(store)
+		groupRepository = memory.NewGroupRepo(store)
+		eventRepository = memory.NewEventRepo(store)
+		erasureRepository = memory.NewErasureRepo(store)
+	} else {
+		fields, err := pii.Load(cfg.Encryption)
+		if err != nil {
+			log.Print(ctx, fmt.Sprintf("%s: failed loading encryption keys", grpsServerMainLogTag), "err", err)
+
+			return
+		}
+
+		db, closeDB, err := database.Open(initCtx, "primary", dbCfg.DSN(), dbCfg.Driver, dbCfg.Pool)
+		if err != nil {
+			log.Print(ctx, fmt.Sprintf("%s: failed opening database", grpsServerMainLogTag), "err", err)
+
+			return
+		}
+		defer closeDB()
+
+		// draining shards are emptied by offline rebalance and do not serve users
+		var (
+			shardNames    []string
+			shardDBs      []*sqlx.DB
+			shardReplicas [][]config.Replica
+		)
+		for _, s := range dbCfg.Shards {
+			if s.Draining {
+				continue
+			}
+
+			shardDB, closeShard, err := database.Open(initCtx, s.Name, dbCfg.ShardDSN(s), dbCfg.Driver, dbCfg.Pool)
+			if err != nil {
+				log.Print(ctx, fmt.Sprintf("%s: failed opening shard %s", grpsServerMainLogTag, s.Name), "err", err)
+
+				return
+			}
+			defer closeShard()
+
+			shardNames = append(shardNames, s.Name)
+			shardDBs = append(shardDBs, shardDB)
+			shardReplicas = append(shardReplicas, s.Replicas)
+		}
+
+		// service is ready while every database it serves is reachable and has all migrations applied
+		for i, migrateDB := range append([]*sqlx.DB{db}, shardDBs...) {
+			name := "primary"
+			if i > 0 {
+				name = shardNames[i-1]
+			}
+
+			migrator, err := migrate.New(migrateDB, migrations.For(database.DialectOf(migrateDB)))
+			if err != nil {
+				log.Print(ctx, fmt.Sprintf("%s: failed loading migrations", grpsServerMainLogTag), "err", err)
+
+				return
+			}
+
+			if dbCfg.AutoMigrate {
+				if _, err = migrator.Up(ctx); err != nil {
+					log.Print(ctx, fmt.Sprintf("%s: failed applying migrations", grpsServerMainLogTag), "err", err)
+
+					return
+				}
+			}
+
+			checks = append(checks,
+				health.Ping(fmt.Sprintf("database %s", name), migrateDB),
+				health.Check{Name: fmt.Sprintf("migrations %s", name), Check: migrator.Applied},
+			)
+		}
+
+		replicaDSNs := make(map[string]string, len(dbCfg.Replicas))
+		for _, r := range dbCfg.Replicas {
+			replicaDSNs[fmt.Sprintf("%s:%s", r.Host, r.Port)] = dbCfg.ReplicaDSN(r)
+		}
+
+		cluster, err := database.NewCluster(initCtx, db, dbCfg.Driver, replicaDSNs, time.Duration(dbCfg.ReplicaMaxLag)*time.Second)
+		if err != nil {
+			log.Print(ctx, fmt.Sprintf("%s: failed opening replicas", grpsServerMainLogTag), "err", err)
+
+			return
+		}
+		//nolint
+		defer cluster.Close()
+
+		healthCtx, stopHealthChecks := context.WithCancel(ctx)
+		defer stopHealthChecks()
+
+		go cluster.RunHealthChecks(healthCtx, time.Duration(dbCfg.ReplicaCheckInterval)*time.Second)
+
+		// shards join transactions of services when they are touched, their commit is not atomic with
+		// primary: a failed commit of primary keeps changes already committed on shards
+		transactor = database.NewTransactor(db, shardDBs...)
+
+		// emails are re-sealed on every database that stores users
+		var rotators []repo.EmailKeyRotator
+		if len(shardDBs) > 0 {
+			shardRepos := make(map[string]repo.UserRequestRepo, len(shardDBs))
+			for i, shardDB := range shardDBs {
+				shardReplicaDSNs := make(map[string]string, len(shardReplicas[i]))
+				for _, r := range shardReplicas[i] {
+					shardReplicaDSNs[fmt.Sprintf("%s:%s", r.Host, r.Port)] = dbCfg.ReplicaDSN(r)
+				}
+
+				shardCluster, err := database.NewCluster(initCtx, shardDB, dbCfg.Driver, shardReplicaDSNs, time.Duration(dbCfg.ReplicaMaxLag)*time.Second)
+				if err != nil {
+					log.Print(ctx, fmt.Sprintf("%s: failed opening replicas of shard %s", grpsServerMainLogTag, shardNames[i]), "err", err)
+
+					return
+				}
+				//nolint
+				defer shardCluster.Close()
+
+				go shardCluster.RunHealthChecks(healthCtx, time.Duration(dbCfg.ReplicaCheckInterval)*time.Second)
+
+				shardRepository := repo.NewUserRequestRepo(shardCluster, batchSize, fields)
+				shardRepos[shardNames[i]] = shardRepository
+				rotators = append(rotators, shardRepository)
+			}
+			requestRepository = sharded.NewUserRequestRepo(hashring.New(shardNames, dbCfg.ShardVirtualNodes), shardRepos)
+		} else {
+			primaryRepository := repo.NewUserRequestRepo(cluster, batchSize, fields)
+			requestRepository = primaryRepository
+			rotators = append(rotators, primaryRepository)
+		}
+		if cfg.UserCache.Enabled {
+			// NOTIFY is Postgres only, a SQLite file is served by one instance
+			notify := cfg.UserCache.Notify && database.DialectOf(db) == database.Postgres
+
+			var publisher cache.Publisher
+			if notify {
+				publisher = cache.NewPgPublisher(db)
+			}
+
+			cachedRepository := cache.NewUserRequestRepo(requestRepository, cfg.UserCache, publisher)
+			if notify {
+				listenCtx, stopListening := context.WithCancel(ctx)
+				defer stopListening()
+
+				listener := health.NewWorker("cache listener")
+				checks = append(checks, listener.Check())
+
+				go listener.Run(func() { cachedRepository.Listen(listenCtx, dbCfg.DSN()) })
+			}
+			requestRepository = cachedRepository
+		}
+		groupRepository = grouprepo.NewRepo(db)
+		eventRepository = eventrepo.NewRepo(db)
+		erasureRepository = erasurerepo.NewRepo(db)
+		// duplicate analysis relies on pg_trgm and is not available for in-memory storage and SQLite,
+		// it compares users of one database, so it is refused with shards by config validation
+		if len(shardDBs) == 0 && database.DialectOf(db) == database.Postgres {
+			duplicateService = duplicate.New(transactor, duplicaterepo.NewRepo(db, cfg.Encryption.Enabled), cfg.Duplicates)
+		}
+
+		if fields != nil {
+			rotationCtx, stopRotation := context.WithCancel(ctx)
+			defer stopRotation()
+
+			rotation := health.NewWorker("key rotation")
+			checks = append(checks, rotation.Check())
+
+			go rotation.Run(func() {
+				keyrotation.RunRotation(rotationCtx, keyrotation.New(cfg.Encryption, rotators...),
+					time.Duration(cfg.Encryption.RotationInterval)*time.Minute)
+			})
+		}
+	}
+
+	userRequestService := user_request.New(transactor, requestRepository, groupRepository, eventRepository, erasureRepository, profileValidator)
+	groupService := group.New(transactor, groupRepository, requestRepository)
+
+	if duplicateService != nil && cfg.Duplicates.Enabled {
+		analyzerCtx, stopAnalyzer := context.WithCancel(ctx)
+		defer stopAnalyzer()
 
-	userRequestService := user_request.New(db, requestRepository)
+		analyzer := health.NewWorker("duplicate analyzer")
+		checks = append(checks, analyzer.Check())
+
+		go analyzer.Run(func() {
+			duplicate.RunAnalyzer(analyzerCtx, duplicateService, time.Duration(cfg.Duplicates.Interval)*time.Minute)
+		})
+	}
 
-	if err := server.NewGrpcServer(userRequestService).Start(ctx, &cfg); err != nil {
+	if err := server.NewGrpcServer(userRequestService, groupService, duplicateService, checks).Start(ctx, &cfg); err != nil {
 		log.Print(ctx, fmt.Sprintf("%s: failed creating gRPC server", grpsServerMainLogTag), "err", err)
 
 		return
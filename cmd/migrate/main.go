@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/database/migrate"
+	"cmd/main.go/migrations"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+const usage = `usage: migrate [-config config.yml] [-local] <command>
+
+commands:
+  up           apply all pending migrations
+  down [N]     roll back N last applied migrations (default 1)
+  status       print state of every migration
+`
+
+func main() {
+	configPath := flag.String("config", "config.yml", "path to config file")
+	local := flag.Bool("local", false, "use database1 (make run) settings")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+	}
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := config.ReadConfigYML(*configPath); err != nil {
+		log.Fatalf("failed init configuration: %v", err)
+	}
+	cfg := config.GetConfigInstance()
+
+	dbCfg := cfg.Database
+	if *local {
+		dbCfg = config.Database(cfg.Database1)
+	}
+
+	ctx := context.Background()
+
+	db, err := database.NewPostgres(ctx, dbCfg.DSN(), dbCfg.Driver)
+	if err != nil {
+		log.Fatalf("failed connecting to database: %v", err)
+	}
+	defer db.Close()
+
+	migrator, err := migrate.New(db, migrations.For(database.DialectOf(db)))
+	if err != nil {
+		log.Fatalf("failed loading migrations: %v", err)
+	}
+
+	if err = run(ctx, migrator, flag.Arg(0), flag.Args()[1:]); err != nil {
+		log.Fatalf("migrate %s: %v", flag.Arg(0), err)
+	}
+}
+
+func run(ctx context.Context, migrator *migrate.Migrator, command string, args []string) error {
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Printf("applied %06d_%s\n", m.Version, m.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 0 {
+			var err error
+			if steps, err = strconv.Atoi(args[0]); err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[0])
+			}
+		}
+
+		rolledBack, err := migrator.Down(ctx, steps)
+		for _, m := range rolledBack {
+			fmt.Printf("rolled back %06d_%s\n", m.Version, m.Name)
+		}
+
+		return err
+	case "status":
+		statuses, err := migrator.Status(ctx)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.Applied {
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Fprintf(w, "%06d\t%s\t%s\n", s.Version, s.Name, appliedAt)
+		}
+		//nolint
+		w.Flush()
+
+		return err
+	default:
+		return fmt.Errorf("unknown command\n%s", usage)
+	}
+}
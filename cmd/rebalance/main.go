@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/pkg/hashring"
+	"cmd/main.go/internal/repo/sharded"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+)
+
+const usage = `usage: rebalance [-config config.yml] [-local] [-batch N] [-dry-run]
+
+Moves users to shards that own them on hash ring of database.shards, shards marked
+draining are emptied. Stop the service before running it, an interrupted run can be repeated.
+
+flags:
+`
+
+func main() {
+	configPath := flag.String("config", "config.yml", "path to config file")
+	local := flag.Bool("local", false, "use database1 (make run) settings")
+	batchSize := flag.Uint64("batch", 1000, "users read from a shard at once")
+	dryRun := flag.Bool("dry-run", false, "print moves without changing data")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if err := config.ReadConfigYML(*configPath); err != nil {
+		log.Fatalf("failed init configuration: %v", err)
+	}
+	cfg := config.GetConfigInstance()
+
+	dbCfg := cfg.Database
+	if *local {
+		dbCfg = config.Database(cfg.Database1)
+	}
+
+	if len(dbCfg.Shards) == 0 {
+		log.Fatal("no shards configured")
+	}
+
+	ctx := context.Background()
+
+	var nodes []string
+	dbs := make(map[string]*sqlx.DB, len(dbCfg.Shards))
+	for _, s := range dbCfg.Shards {
+		db, err := database.NewPostgres(ctx, dbCfg.ShardDSN(s), dbCfg.Driver)
+		if err != nil {
+			log.Fatalf("failed connecting to shard %s: %v", s.Name, err)
+		}
+		//nolint
+		defer db.Close()
+
+		dbs[s.Name] = db
+		if !s.Draining {
+			nodes = append(nodes, s.Name)
+		}
+	}
+
+	moves, err := sharded.Rebalance(ctx, hashring.New(nodes, dbCfg.ShardVirtualNodes), dbs, *batchSize, *dryRun)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FROM\tTO\tUSERS")
+	for _, m := range moves {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", m.From, m.To, m.Users)
+	}
+	//nolint
+	w.Flush()
+
+	if err != nil {
+		log.Fatalf("rebalance: %v", err)
+	}
+	if *dryRun {
+		fmt.Println("dry run, nothing was moved")
+	}
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/pkg/hashring"
+	"cmd/main.go/internal/pkg/labels"
+	"cmd/main.go/internal/pkg/profile"
+	"cmd/main.go/internal/pkg/seed"
+	"cmd/main.go/internal/repo"
+	erasurerepo "cmd/main.go/internal/repo/erasure"
+	eventrepo "cmd/main.go/internal/repo/event"
+	grouprepo "cmd/main.go/internal/repo/group"
+	"cmd/main.go/internal/repo/memory"
+	"cmd/main.go/internal/repo/pii"
+	"cmd/main.go/internal/repo/sharded"
+	"cmd/main.go/internal/service/user_request"
+	desc "cmd/main.go/pkg/my-api"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const usage = `usage: seed [-config config.yml] [-local] [-users N [-first-id ID] [-seed SEED] [-statuses active=W,done=W] [-deleted SHARE]] [FILE]...
+
+Creates users of fixture files (YAML, or JSON for .json files) and N synthetic users through the user service,
+so they pass the same validation as CreateUser requests. Users whose id is taken, deleted ones included,
+are left as they are, so reruns create only missing users.
+
+fixture file:
+  users:
+    - id: 1
+      name: Ann Example
+      email: ann@example.com
+      labels: {plan: pro}
+      profile: {locale: en-US}
+      status: done     # active (default) or done
+      deleted: true
+
+flags:
+`
+
+type result struct {
+	created int
+	skipped int
+	deleted int
+}
+
+func main() {
+	configPath := flag.String("config", "config.yml", "path to config file")
+	local := flag.Bool("local", false, "use database1 (make run) settings")
+	users := flag.Int("users", 0, "number of synthetic users")
+	firstID := flag.Uint64("first-id", 1_000_000, "id of the first synthetic user")
+	randSeed := flag.Int64("seed", 1, "seed of synthetic users, the same seed generates the same users")
+	statuses := flag.String("statuses", "active=80,done=20", "weights of statuses of synthetic users")
+	deleted := flag.Float64("deleted", 0.1, "share of deleted synthetic users, from 0 to 1")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	var fixtures []seed.User
+	for _, path := range flag.Args() {
+		fileUsers, err := seed.Load(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fixtures = append(fixtures, fileUsers...)
+	}
+
+	if *users > 0 {
+		weights, err := seed.ParseStatuses(*statuses)
+		if err != nil {
+			log.Fatalf("invalid -statuses: %v", err)
+		}
+		if *deleted < 0 || *deleted > 1 {
+			log.Fatalf("invalid -deleted %v, want share from 0 to 1", *deleted)
+		}
+		fixtures = append(fixtures, seed.Generate(*users, *firstID, *randSeed, seed.Distribution{Statuses: weights, Deleted: *deleted})...)
+	}
+
+	if len(fixtures) == 0 {
+		flag.Usage()
+		log.Fatal("nothing to seed, give fixture files or -users")
+	}
+
+	if err := config.ReadConfigYML(*configPath); err != nil {
+		log.Fatalf("failed init configuration: %v", err)
+	}
+	cfg := config.GetConfigInstance()
+
+	dbCfg := cfg.Database
+	if *local {
+		dbCfg = config.Database(cfg.Database1)
+	}
+	if dbCfg.Driver == memory.Driver {
+		log.Fatal("in-memory storage does not outlive seed, use it with a database")
+	}
+
+	profileValidator, err := profile.NewValidatorFromFile(cfg.Profile.SchemaPath)
+	if err != nil {
+		log.Fatalf("failed loading profile schema: %v", err)
+	}
+
+	fields, err := pii.Load(cfg.Encryption)
+	if err != nil {
+		log.Fatalf("failed loading encryption keys: %v", err)
+	}
+
+	ctx := context.Background()
+
+	transactor, service, closeDBs, err := newService(ctx, dbCfg, fields, profileValidator)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var res result
+	for _, u := range fixtures {
+		if err = seedUser(ctx, transactor, service, u, &res); err != nil {
+			closeDBs()
+			log.Fatalf("failed seeding user %d: %v", u.ID, err)
+		}
+	}
+	closeDBs()
+
+	log.Printf("created %d users, %d of them deleted, %d already existed", res.created, res.deleted, res.skipped)
+}
+
+// newService - user service over primary database and its serving shards, as grpc-server wires it
+// without replicas and cache
+func newService(ctx context.Context, dbCfg config.Database, fields *pii.Fields, profileValidator *profile.Validator) (database.Transactor, user_request.ServiceInterface, func(), error) {
+	var closers []func()
+	closeAll := func() {
+		for _, closeDB := range closers {
+			closeDB()
+		}
+	}
+
+	db, closeDB, err := database.Open(ctx, "primary", dbCfg.DSN(), dbCfg.Driver, dbCfg.Pool)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "open primary")
+	}
+	closers = append(closers, closeDB)
+
+	var shardDBs []*sqlx.DB
+	shardRepos := make(map[string]repo.UserRequestRepo)
+	var shardNames []string
+	for _, s := range dbCfg.Shards {
+		if s.Draining {
+			continue
+		}
+
+		shardDB, closeShard, err := database.Open(ctx, s.Name, dbCfg.ShardDSN(s), dbCfg.Driver, dbCfg.Pool)
+		if err != nil {
+			closeAll()
+			return nil, nil, nil, errors.Wrapf(err, "open shard %s", s.Name)
+		}
+		closers = append(closers, closeShard)
+
+		shardCluster, err := database.NewCluster(ctx, shardDB, dbCfg.Driver, nil, 0)
+		if err != nil {
+			closeAll()
+			return nil, nil, nil, errors.Wrapf(err, "shard %s", s.Name)
+		}
+
+		shardDBs = append(shardDBs, shardDB)
+		shardNames = append(shardNames, s.Name)
+		shardRepos[s.Name] = repo.NewUserRequestRepo(shardCluster, 0, fields)
+	}
+
+	var requestRepository repo.UserRequestRepo
+	if len(shardDBs) > 0 {
+		requestRepository = sharded.NewUserRequestRepo(hashring.New(shardNames, dbCfg.ShardVirtualNodes), shardRepos)
+	} else {
+		cluster, err := database.NewCluster(ctx, db, dbCfg.Driver, nil, 0)
+		if err != nil {
+			closeAll()
+			return nil, nil, nil, errors.Wrap(err, "primary")
+		}
+		requestRepository = repo.NewUserRequestRepo(cluster, 0, fields)
+	}
+
+	transactor := database.NewTransactor(db, shardDBs...)
+	service := user_request.New(transactor, requestRepository, grouprepo.NewRepo(db), eventrepo.NewRepo(db),
+		erasurerepo.NewRepo(db), profileValidator)
+
+	return transactor, service, closeAll, nil
+}
+
+// seedUser - create user unless its id is taken, the user is validated as CreateUser request
+// and is created and deleted in one transaction, so a failed run leaves no half seeded users
+func seedUser(ctx context.Context, transactor database.Transactor, service user_request.ServiceInterface, u seed.User, res *result) error {
+	req, err := createRequest(u)
+	if err != nil {
+		return err
+	}
+
+	created, err := database.WithTx(ctx, transactor, func(ctx context.Context) (bool, error) {
+		existing, err := service.GetUserByIdRequest(ctx, []uint64{u.ID})
+		if err != nil && !errors.Is(err, user_request.ErrNoListUserRequest) {
+			return false, err
+		}
+		if len(existing) > 0 {
+			return false, nil
+		}
+
+		userRequest, err := model.ConvertPbToUserRequest(req)
+		if err != nil {
+			return false, err
+		}
+		if _, err = service.CreateUserRequest(ctx, userRequest); err != nil {
+			return false, err
+		}
+
+		if u.Deleted {
+			if _, err = service.RemoveUserRequest(ctx, []uint64{u.ID}); err != nil {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}, database.RetryOnConflict())
+	if err != nil {
+		return err
+	}
+
+	if !created {
+		res.skipped++
+		return nil
+	}
+	res.created++
+	if u.Deleted {
+		res.deleted++
+	}
+
+	return nil
+}
+
+func createRequest(u seed.User) (*desc.CreateUserRequest, error) {
+	createdAt := time.Now()
+	if u.CreatedAt != nil {
+		createdAt = *u.CreatedAt
+	}
+
+	userProfile, err := structpb.NewStruct(u.Profile)
+	if err != nil {
+		return nil, errors.Wrap(err, "profile")
+	}
+
+	req := &desc.CreateUserRequest{
+		IdUser:    u.ID,
+		Name:      u.Name,
+		Email:     u.Email,
+		CreatedAt: timestamppb.New(createdAt),
+		Labels:    u.Labels,
+		Profile:   userProfile,
+	}
+	// done users without done_at are done when they were created
+	switch {
+	case u.DoneAt != nil:
+		req.DoneAt = timestamppb.New(*u.DoneAt)
+	case u.Status == seed.Done:
+		req.DoneAt = req.CreatedAt
+	}
+
+	if err = req.Validate(); err != nil {
+		return nil, err
+	}
+	if err = labels.Validate(req.GetLabels()); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/database/migrate"
+	"cmd/main.go/internal/pkg/backup"
+	backuprepo "cmd/main.go/internal/repo/backup"
+	"cmd/main.go/internal/repo/memory"
+	"cmd/main.go/migrations"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+)
+
+const usage = `usage: snapshot [-config config.yml] [-local] [-shard NAME] <command>
+
+Backs up users, groups, group members, user events and erasure receipts of one database
+to a gzip archive of protobuf records with a manifest of row counts and SHA-256 checksums.
+Archives do not depend on database version or dialect, a Postgres backup restores into SQLite.
+
+commands:
+  backup FILE                                       write archive of database to FILE, - for stdout
+  restore [-on-conflict fail|skip|overwrite] [-migrate] FILE
+                                                    insert rows of archive in one transaction
+  verify FILE                                       check checksums of archive and print its manifest
+
+flags:
+`
+
+func main() {
+	configPath := flag.String("config", "config.yml", "path to config file")
+	local := flag.Bool("local", false, "use database1 (make run) settings")
+	shard := flag.String("shard", "", "back up or restore shard of database.shards instead of primary database")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	command, args := flag.Arg(0), flag.Args()[1:]
+	if command == "verify" {
+		if err := verify(args); err != nil {
+			log.Fatalf("snapshot verify: %v", err)
+		}
+
+		return
+	}
+
+	if err := config.ReadConfigYML(*configPath); err != nil {
+		log.Fatalf("failed init configuration: %v", err)
+	}
+	cfg := config.GetConfigInstance()
+
+	dbCfg := cfg.Database
+	if *local {
+		dbCfg = config.Database(cfg.Database1)
+	}
+	if dbCfg.Driver == memory.Driver {
+		log.Fatal("in-memory storage can not be backed up")
+	}
+
+	dsn := dbCfg.DSN()
+	if *shard != "" {
+		found := false
+		for _, s := range dbCfg.Shards {
+			if s.Name == *shard {
+				dsn, found = dbCfg.ShardDSN(s), true
+			}
+		}
+		if !found {
+			log.Fatalf("unknown shard %q", *shard)
+		}
+	}
+
+	ctx := context.Background()
+
+	db, closeDB, err := database.Open(ctx, "snapshot", dsn, dbCfg.Driver, dbCfg.Pool)
+	if err != nil {
+		log.Fatalf("failed connecting to database: %v", err)
+	}
+	defer closeDB()
+
+	migrator, err := migrate.New(db, migrations.For(database.DialectOf(db)))
+	if err != nil {
+		log.Fatalf("failed loading migrations: %v", err)
+	}
+
+	switch command {
+	case "backup":
+		err = runBackup(ctx, db, migrator, args)
+	case "restore":
+		err = runRestore(ctx, db, migrator, args)
+	default:
+		err = fmt.Errorf("unknown command\n%s", usage)
+	}
+	if err != nil {
+		closeDB()
+		log.Fatalf("snapshot %s: %v", command, err)
+	}
+}
+
+func runBackup(ctx context.Context, db *sqlx.DB, migrator *migrate.Migrator, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("want archive path, got %d arguments", len(args))
+	}
+
+	version, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	if args[0] != "-" {
+		file, err := os.Create(filepath.Clean(args[0]))
+		if err != nil {
+			return err
+		}
+		//nolint
+		defer file.Close()
+		out = file
+	}
+
+	w, err := backup.NewWriter(out, version, database.DialectOf(db).Name())
+	if err != nil {
+		return err
+	}
+	if err = backuprepo.Dump(ctx, db, w); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+
+	for _, t := range w.Tables() {
+		log.Printf("backed up %d rows of %s", t.GetRows(), t.GetName())
+	}
+
+	return nil
+}
+
+func runRestore(ctx context.Context, db *sqlx.DB, migrator *migrate.Migrator, args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	onConflict := fs.String("on-conflict", string(backuprepo.ConflictFail), "rows with taken primary keys: fail, skip or overwrite")
+	migrateUp := fs.Bool("migrate", false, "apply pending migrations before restore")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("want archive path, got %d arguments", fs.NArg())
+	}
+
+	policy, err := backuprepo.ParseConflictPolicy(*onConflict)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(filepath.Clean(fs.Arg(0)))
+	if err != nil {
+		return err
+	}
+	//nolint
+	defer file.Close()
+
+	r, err := backup.NewReader(file)
+	if err != nil {
+		return err
+	}
+
+	if *migrateUp {
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		for _, m := range applied {
+			log.Printf("applied %06d_%s", m.Version, m.Name)
+		}
+	}
+
+	// columns of archive must exist in database, newer schemas only add columns with defaults
+	version, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if version < r.Header().GetSchemaVersion() {
+		return fmt.Errorf("database schema version %d is older than %d of archive, restore with -migrate",
+			version, r.Header().GetSchemaVersion())
+	}
+
+	written, err := backuprepo.Restore(ctx, db, r, policy)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range r.Manifest().GetTables() {
+		log.Printf("restored %d of %d rows of %s", written[t.GetName()], t.GetRows(), t.GetName())
+	}
+
+	return nil
+}
+
+func verify(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("want archive path, got %d arguments", len(args))
+	}
+
+	file, err := os.Open(filepath.Clean(args[0]))
+	if err != nil {
+		return err
+	}
+	//nolint
+	defer file.Close()
+
+	r, err := backup.NewReader(file)
+	if err != nil {
+		return err
+	}
+
+	for {
+		if _, err = r.Next(); err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	header := r.Header()
+	fmt.Printf("format %d, %s schema version %d, created at %s\n",
+		header.GetFormatVersion(), header.GetDialect(), header.GetSchemaVersion(),
+		header.GetCreatedAt().AsTime().Format("2006-01-02 15:04:05 MST"))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tROWS\tSHA256")
+	for _, t := range r.Manifest().GetTables() {
+		fmt.Fprintf(w, "%s\t%d\t%s\n", t.GetName(), t.GetRows(), t.GetSha256())
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,20 @@
+package admin
+
+import (
+	"cmd/main.go/internal/pkg/rbac"
+	desc "cmd/main.go/pkg/my-api"
+)
+
+const (
+	checkAccessLogTag = "CheckAccess"
+)
+
+type Implementation struct {
+	desc.UnimplementedAdminServiceServer
+	policy *rbac.Engine
+}
+
+// NewAdminService returns admin service, policy is nil when authorization is disabled
+func NewAdminService(policy *rbac.Engine) desc.AdminServiceServer {
+	return &Implementation{policy: policy}
+}
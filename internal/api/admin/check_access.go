@@ -0,0 +1,56 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"cmd/main.go/internal/logger"
+	"cmd/main.go/internal/pkg/rbac"
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *Implementation) CheckAccess(ctx context.Context, req *desc.CheckAccessRequest) (*desc.CheckAccessResponse, error) {
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid argument", checkAccessLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if i.policy == nil {
+		return nil, status.Error(codes.FailedPrecondition, "authorization is disabled")
+	}
+
+	identity := rbac.IdentityFromContext(ctx)
+	if req.GetIdentity() != nil {
+		identity = rbac.Identity{
+			Subject: req.GetIdentity().GetSubject(),
+			Roles:   req.GetIdentity().GetRoles(),
+			Scopes:  req.GetIdentity().GetScopes(),
+		}
+	}
+
+	decision := i.policy.Decide(identity, req.GetMethod())
+	resolved := i.policy.Resolve(identity)
+
+	logger.InfoKV(ctx, fmt.Sprintf("%s: success", checkAccessLogTag),
+		"method", req.GetMethod(),
+		"subject", resolved.Subject,
+		"allowed", decision.Allowed,
+	)
+
+	return &desc.CheckAccessResponse{
+		Allowed: decision.Allowed,
+		Reason:  decision.Reason,
+		Identity: &desc.Identity{
+			Subject: resolved.Subject,
+			Roles:   resolved.Roles,
+			Scopes:  resolved.Scopes,
+		},
+	}, nil
+}
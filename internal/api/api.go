@@ -1,23 +1,34 @@
 package api
 
 import (
+	"cmd/main.go/internal/service/duplicate"
 	"cmd/main.go/internal/service/user_request"
 	desc "cmd/main.go/pkg/my-api"
 )
 
 const (
-	createUserLogTag     = "CreateUser"
-	GetUserByIdLogTag    = "GetUserById"
-	listUserLogTag       = "ListUser"
-	removeUserLogTag     = "RemoveUser"
-	updateUserByIdLogTag = "UpdateUserById"
+	createUserLogTag        = "CreateUser"
+	GetUserByIdLogTag       = "GetUserById"
+	listUserLogTag          = "ListUser"
+	removeUserLogTag        = "RemoveUser"
+	restoreUsersLogTag      = "RestoreUsers"
+	updateUserByIdLogTag    = "UpdateUserById"
+	updateUserLabelsLogTag  = "UpdateUserLabels"
+	updateUserProfileLogTag = "UpdateUserProfile"
+	mergeUsersLogTag        = "MergeUsers"
+	listDuplicatesLogTag    = "ListDuplicateCandidates"
+	eraseUserLogTag         = "EraseUser"
 )
 
 type Implementation struct {
 	desc.UnimplementedApiServiceServer
 	userRequestService user_request.ServiceInterface
+	duplicateService   duplicate.ServiceInterface
 }
 
-func NewApiService(userRequestService user_request.ServiceInterface) desc.ApiServiceServer {
-	return &Implementation{userRequestService: userRequestService}
+func NewApiService(userRequestService user_request.ServiceInterface, duplicateService duplicate.ServiceInterface) desc.ApiServiceServer {
+	return &Implementation{
+		userRequestService: userRequestService,
+		duplicateService:   duplicateService,
+	}
 }
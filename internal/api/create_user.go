@@ -11,6 +11,7 @@ import (
 
 	"cmd/main.go/internal/logger"
 	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/pkg/labels"
 	desc "cmd/main.go/pkg/my-api"
 )
 
@@ -26,6 +27,14 @@ func (i *Implementation) CreateUser(ctx context.Context, req *desc.CreateUserReq
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	if err := labels.Validate(req.GetLabels()); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid labels", createUserLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	// ch, err := i.userRequestService.CheckExistsUserRequest(ctx, req.IdUser)
 	// if err != nil {
 	// 	log.Print(ctx, fmt.Sprintf("%s: User already exist", createUserLogTag),
@@ -41,6 +50,8 @@ func (i *Implementation) CreateUser(ctx context.Context, req *desc.CreateUserReq
 		UpdatedAt: req.GetUpdatedAt(),
 		DeletedAt: req.GetDeletedAt(),
 		DoneAt:    req.GetDoneAt(),
+		Labels:    req.GetLabels(),
+		Profile:   req.GetProfile(),
 	}
 
 	User, err := model.ConvertPbToUserRequest(&newItem)
@@ -55,6 +66,14 @@ func (i *Implementation) CreateUser(ctx context.Context, req *desc.CreateUserReq
 
 	id, err := i.userRequestService.CreateUserRequest(ctx, User)
 
+	if st, ok := profileViolationsStatus(err); ok {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid profile", createUserLogTag),
+			"err", err,
+		)
+
+		return nil, st.Err()
+	}
+
 	if err != nil {
 		logger.ErrorKV(ctx, fmt.Sprintf("%s: userRequestService.CreateUserRequest failed", createUserLogTag),
 			"err", err,
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"cmd/main.go/internal/logger"
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/service/user_request"
+	desc "cmd/main.go/pkg/my-api"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *Implementation) EraseUser(ctx context.Context, req *desc.EraseUserRequest) (*desc.EraseUserResponse, error) {
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid argument", eraseUserLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	receipt, err := i.userRequestService.EraseUserRequest(ctx, req.GetIdUser(), req.GetReason())
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: userRequestService.EraseUserRequest failed", eraseUserLogTag),
+			"err", err,
+			"idUser", req.GetIdUser(),
+		)
+
+		switch {
+		case errors.Is(err, user_request.ErrNoExistsUserRequest):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, user_request.ErrUserRequestAlreadyErased):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	logger.InfoKV(ctx, fmt.Sprintf("%s: success", eraseUserLogTag),
+		"idUser", req.GetIdUser(),
+		"receipt", receipt.ID,
+		"erased", len(receipt.Summary.Users),
+	)
+
+	return &desc.EraseUserResponse{
+		Receipt: model.ConvertErasureReceiptToPb(receipt),
+	}, nil
+}
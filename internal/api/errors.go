@@ -0,0 +1,40 @@
+package api
+
+import (
+	"cmd/main.go/internal/pkg/profile"
+
+	"github.com/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// profileViolationsStatus - convert profile validation error to InvalidArgument status with BadRequest details
+func profileViolationsStatus(err error) (*status.Status, bool) {
+	var violations []profile.FieldViolation
+
+	var validationErr *profile.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		violations = validationErr.Violations
+	case errors.Is(err, profile.ErrSchemaNotConfigured):
+		violations = []profile.FieldViolation{{Field: "profile", Description: err.Error()}}
+	default:
+		return nil, false
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, v := range violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+
+	st := status.New(codes.InvalidArgument, err.Error())
+	if withDetails, detailsErr := st.WithDetails(badRequest); detailsErr == nil {
+		st = withDetails
+	}
+
+	return st, true
+}
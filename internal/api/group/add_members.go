@@ -0,0 +1,43 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"cmd/main.go/internal/logger"
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *Implementation) AddMembers(ctx context.Context, req *desc.AddMembersRequest) (*desc.AddMembersResponse, error) {
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid argument", addMembersLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	added, err := i.groupService.AddMembers(ctx, req.GetIdGroup(), req.GetIdsUser())
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: groupService.AddMembers failed", addMembersLogTag),
+			"err", err,
+			"idGroup", req.GetIdGroup(),
+			"idsUser", req.GetIdsUser(),
+		)
+
+		return nil, serviceErrorToStatus(err)
+	}
+
+	logger.InfoKV(ctx, fmt.Sprintf("%s: success", addMembersLogTag),
+		"idGroup", req.GetIdGroup(),
+		"added", len(added),
+	)
+
+	return &desc.AddMembersResponse{
+		Added: added,
+	}, nil
+}
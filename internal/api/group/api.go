@@ -0,0 +1,43 @@
+package group
+
+import (
+	"cmd/main.go/internal/service/group"
+	desc "cmd/main.go/pkg/my-api"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	createGroupLogTag    = "CreateGroup"
+	getGroupByIdLogTag   = "GetGroupById"
+	listGroupsLogTag     = "ListGroups"
+	updateGroupLogTag    = "UpdateGroup"
+	removeGroupLogTag    = "RemoveGroup"
+	addMembersLogTag     = "AddMembers"
+	removeMembersLogTag  = "RemoveMembers"
+	listMembersLogTag    = "ListMembers"
+	listUserGroupsLogTag = "ListUserGroups"
+)
+
+type Implementation struct {
+	desc.UnimplementedGroupServiceServer
+	groupService group.ServiceInterface
+}
+
+func NewGroupService(groupService group.ServiceInterface) desc.GroupServiceServer {
+	return &Implementation{groupService: groupService}
+}
+
+// serviceErrorToStatus - convert group service error to gRPC status
+func serviceErrorToStatus(err error) error {
+	switch {
+	case errors.Is(err, group.ErrNoGroup),
+		errors.Is(err, group.ErrNoUpdatedGroup),
+		errors.Is(err, group.ErrNoRemovedGroup):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
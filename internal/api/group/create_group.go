@@ -0,0 +1,45 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"cmd/main.go/internal/logger"
+	"cmd/main.go/internal/model"
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *Implementation) CreateGroup(ctx context.Context, req *desc.CreateGroupRequest) (*desc.CreateGroupResponse, error) {
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid argument", createGroupLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	id, err := i.groupService.CreateGroup(ctx, &model.Group{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+	})
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: groupService.CreateGroup failed", createGroupLogTag),
+			"err", err,
+			"name", req.GetName(),
+		)
+
+		return nil, serviceErrorToStatus(err)
+	}
+
+	logger.InfoKV(ctx, fmt.Sprintf("%s: success", createGroupLogTag),
+		"idgroup", id,
+	)
+
+	return &desc.CreateGroupResponse{
+		IdGroup: id,
+	}, nil
+}
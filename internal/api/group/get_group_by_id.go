@@ -0,0 +1,40 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"cmd/main.go/internal/logger"
+	"cmd/main.go/internal/model"
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *Implementation) GetGroupById(ctx context.Context, req *desc.GetGroupByIdRequest) (*desc.GetGroupByIdResponse, error) {
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid argument", getGroupByIdLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	groups, err := i.groupService.GetGroupById(ctx, req.GetIdsGroup())
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: groupService.GetGroupById failed", getGroupByIdLogTag),
+			"err", err,
+			"idsGroup", req.GetIdsGroup(),
+		)
+
+		return nil, serviceErrorToStatus(err)
+	}
+
+	logger.Info(ctx, fmt.Sprintf("%s: success", getGroupByIdLogTag))
+
+	return &desc.GetGroupByIdResponse{
+		Groups: model.ConvertRepeatedGroupsToPb(groups),
+	}, nil
+}
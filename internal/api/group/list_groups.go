@@ -0,0 +1,41 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"cmd/main.go/internal/logger"
+	"cmd/main.go/internal/model"
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *Implementation) ListGroups(ctx context.Context, req *desc.ListGroupsRequest) (*desc.ListGroupsResponse, error) {
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid argument", listGroupsLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	groups, err := i.groupService.ListGroups(ctx, req.GetLimit(), req.GetOffset())
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: groupService.ListGroups failed", listGroupsLogTag),
+			"err", err,
+			"limit", req.GetLimit(),
+			"offset", req.GetOffset(),
+		)
+
+		return nil, serviceErrorToStatus(err)
+	}
+
+	logger.Info(ctx, fmt.Sprintf("%s: success", listGroupsLogTag))
+
+	return &desc.ListGroupsResponse{
+		Items: model.ConvertRepeatedGroupsToPb(groups),
+	}, nil
+}
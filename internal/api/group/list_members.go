@@ -0,0 +1,49 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"cmd/main.go/internal/logger"
+	"cmd/main.go/internal/model"
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *Implementation) ListMembers(ctx context.Context, req *desc.ListMembersRequest) (*desc.ListMembersResponse, error) {
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid argument", listMembersLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	users, err := i.groupService.ListMembers(ctx, req.GetIdGroup(), req.GetLimit(), req.GetOffset())
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: groupService.ListMembers failed", listMembersLogTag),
+			"err", err,
+			"idGroup", req.GetIdGroup(),
+		)
+
+		return nil, serviceErrorToStatus(err)
+	}
+
+	usersPb, err := model.ConvertRepeatedUserRequestsToPb(users)
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: unable to convert list of users to Pb message", listMembersLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	logger.Info(ctx, fmt.Sprintf("%s: success", listMembersLogTag))
+
+	return &desc.ListMembersResponse{
+		Items: usersPb,
+	}, nil
+}
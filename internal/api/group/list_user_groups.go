@@ -0,0 +1,40 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"cmd/main.go/internal/logger"
+	"cmd/main.go/internal/model"
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *Implementation) ListUserGroups(ctx context.Context, req *desc.ListUserGroupsRequest) (*desc.ListUserGroupsResponse, error) {
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid argument", listUserGroupsLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	groups, err := i.groupService.ListUserGroups(ctx, req.GetIdUser())
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: groupService.ListUserGroups failed", listUserGroupsLogTag),
+			"err", err,
+			"idUser", req.GetIdUser(),
+		)
+
+		return nil, serviceErrorToStatus(err)
+	}
+
+	logger.Info(ctx, fmt.Sprintf("%s: success", listUserGroupsLogTag))
+
+	return &desc.ListUserGroupsResponse{
+		Items: model.ConvertRepeatedGroupsToPb(groups),
+	}, nil
+}
@@ -0,0 +1,39 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"cmd/main.go/internal/logger"
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *Implementation) RemoveGroup(ctx context.Context, req *desc.RemoveGroupRequest) (*desc.RemoveGroupResponse, error) {
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid argument", removeGroupLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := i.groupService.RemoveGroups(ctx, req.GetIdsGroup())
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: groupService.RemoveGroups failed", removeGroupLogTag),
+			"err", err,
+			"idsGroup", req.GetIdsGroup(),
+		)
+
+		return nil, serviceErrorToStatus(err)
+	}
+
+	logger.Info(ctx, fmt.Sprintf("%s: success", removeGroupLogTag))
+
+	return &desc.RemoveGroupResponse{
+		Removed: result,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"cmd/main.go/internal/logger"
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *Implementation) RemoveMembers(ctx context.Context, req *desc.RemoveMembersRequest) (*desc.RemoveMembersResponse, error) {
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid argument", removeMembersLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := i.groupService.RemoveMembers(ctx, req.GetIdGroup(), req.GetIdsUser())
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: groupService.RemoveMembers failed", removeMembersLogTag),
+			"err", err,
+			"idGroup", req.GetIdGroup(),
+			"idsUser", req.GetIdsUser(),
+		)
+
+		return nil, serviceErrorToStatus(err)
+	}
+
+	logger.Info(ctx, fmt.Sprintf("%s: success", removeMembersLogTag))
+
+	return &desc.RemoveMembersResponse{
+		Removed: result,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package group
+
+import (
+	"context"
+	"fmt"
+
+	"cmd/main.go/internal/logger"
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *Implementation) UpdateGroup(ctx context.Context, req *desc.UpdateGroupRequest) (*desc.UpdateGroupResponse, error) {
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid argument", updateGroupLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := i.groupService.UpdateGroup(ctx, req.GetIdGroup(), req.GetName(), req.GetDescription())
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: groupService.UpdateGroup failed", updateGroupLogTag),
+			"err", err,
+			"idGroup", req.GetIdGroup(),
+			"name", req.GetName(),
+		)
+
+		return nil, serviceErrorToStatus(err)
+	}
+
+	logger.Info(ctx, fmt.Sprintf("%s: success", updateGroupLogTag))
+
+	return &desc.UpdateGroupResponse{
+		Updated: result,
+	}, nil
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"cmd/main.go/internal/logger"
+	"cmd/main.go/internal/model"
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *Implementation) ListDuplicateCandidates(ctx context.Context, req *desc.ListDuplicateCandidatesRequest) (*desc.ListDuplicateCandidatesResponse, error) {
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid argument", listDuplicatesLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if i.duplicateService == nil {
+		return nil, status.Error(codes.Unimplemented, "duplicate analysis is not available for configured storage")
+	}
+
+	candidates, err := i.duplicateService.ListDuplicateCandidates(ctx, req.GetMinScore(), req.GetLimit(), req.GetOffset())
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: duplicateService.ListDuplicateCandidates failed", listDuplicatesLogTag),
+			"err", err,
+			"minScore", req.GetMinScore(),
+			"limit", req.GetLimit(),
+			"offset", req.GetOffset(),
+		)
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	logger.Info(ctx, fmt.Sprintf("%s: success", listDuplicatesLogTag))
+
+	return &desc.ListDuplicateCandidatesResponse{
+		Items: model.ConvertRepeatedDuplicateCandidatesToPb(candidates),
+	}, nil
+}
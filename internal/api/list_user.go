@@ -6,6 +6,7 @@ import (
 
 	"cmd/main.go/internal/logger"
 	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/pkg/labels"
 	desc "cmd/main.go/pkg/my-api"
 
 	"google.golang.org/grpc/codes"
@@ -21,13 +22,25 @@ func (i *Implementation) ListUser(ctx context.Context, req *desc.ListUserRequest
 
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	userRequests, err := i.userRequestService.ListUserRequest(ctx, req.Limit, req.Offset)
+
+	selector, err := labels.Parse(req.GetLabelSelector())
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid label selector", listUserLogTag),
+			"err", err,
+			"labelSelector", req.GetLabelSelector(),
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	userRequests, err := i.userRequestService.ListUserRequest(ctx, req.Limit, req.Offset, selector)
 
 	if err != nil {
 		logger.ErrorKV(ctx, fmt.Sprintf("%s: userRequestService.ListUserRequest failed", listUserLogTag),
 			"err", err,
 			"limit", req.Limit,
 			"offset", req.Offset,
+			"labelSelector", selector.String(),
 		)
 
 		return nil, status.Error(codes.Internal, err.Error())
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"cmd/main.go/internal/logger"
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/service/user_request"
+	desc "cmd/main.go/pkg/my-api"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *Implementation) MergeUsers(ctx context.Context, req *desc.MergeUsersRequest) (*desc.MergeUsersResponse, error) {
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid argument", mergeUsersLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	survivor, err := i.userRequestService.MergeUsersRequest(ctx, req.GetIdSurvivor(), req.GetIdsLoser(), model.ConvertPbToMergePolicies(req.GetPolicies()))
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: userRequestService.MergeUsersRequest failed", mergeUsersLogTag),
+			"err", err,
+			"idSurvivor", req.GetIdSurvivor(),
+			"idsLoser", req.GetIdsLoser(),
+		)
+
+		switch {
+		case errors.Is(err, user_request.ErrMergeSurvivorIsLoser):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, user_request.ErrNoExistsUserRequest):
+			return nil, status.Error(codes.NotFound, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	survivorPb, err := model.ConvertUserToPb(survivor)
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: unable to convert User to Pb message", mergeUsersLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	logger.InfoKV(ctx, fmt.Sprintf("%s: success", mergeUsersLogTag),
+		"idSurvivor", req.GetIdSurvivor(),
+		"merged", len(req.GetIdsLoser()),
+	)
+
+	return &desc.MergeUsersResponse{
+		Survivor: survivorPb,
+		Merged:   req.GetIdsLoser(),
+	}, nil
+}
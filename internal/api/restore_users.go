@@ -0,0 +1,42 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"cmd/main.go/internal/logger"
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *Implementation) RestoreUsers(ctx context.Context, req *desc.RestoreUsersRequest) (*desc.RestoreUsersResponse, error) {
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid argument", restoreUsersLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	restored, err := i.userRequestService.RestoreUserRequest(ctx, req.GetIdsUser())
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: userRequestService.RestoreUserRequest failed", restoreUsersLogTag),
+			"err", err,
+			"idsUser", req.GetIdsUser(),
+		)
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	logger.InfoKV(ctx, fmt.Sprintf("%s: success", restoreUsersLogTag),
+		"idsUser", req.GetIdsUser(),
+		"restored", restored,
+	)
+
+	return &desc.RestoreUsersResponse{
+		Restored: uint64(restored),
+	}, nil
+}
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"cmd/main.go/internal/logger"
+	"cmd/main.go/internal/pkg/labels"
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *Implementation) UpdateUserLabels(ctx context.Context, req *desc.UpdateUserLabelsRequest) (*desc.UpdateUserLabelsResponse, error) {
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid argument", updateUserLabelsLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := labels.Validate(req.GetSetLabels()); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid labels", updateUserLabelsLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := i.userRequestService.UpdateUserLabelsRequest(ctx, req.GetIdUser(), req.GetSetLabels(), req.GetRemoveKeys())
+
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: userRequestService.UpdateUserLabelsRequest failed", updateUserLabelsLogTag),
+			"err", err,
+			"userRequestId", req.GetIdUser(),
+			"setLabels", req.GetSetLabels(),
+			"removeKeys", req.GetRemoveKeys(),
+		)
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	logger.Info(ctx, fmt.Sprintf("%s: success", updateUserLabelsLogTag))
+
+	return &desc.UpdateUserLabelsResponse{
+		Updated: result,
+	}, nil
+}
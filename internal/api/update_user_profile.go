@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"cmd/main.go/internal/logger"
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (i *Implementation) UpdateUserProfile(ctx context.Context, req *desc.UpdateUserProfileRequest) (*desc.UpdateUserProfileResponse, error) {
+
+	if err := req.Validate(); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid argument", updateUserProfileLogTag),
+			"err", err,
+		)
+
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	result, err := i.userRequestService.UpdateUserProfileRequest(ctx, req.GetIdUser(), req.GetProfile().AsMap())
+
+	if st, ok := profileViolationsStatus(err); ok {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: invalid profile", updateUserProfileLogTag),
+			"err", err,
+			"userRequestId", req.GetIdUser(),
+		)
+
+		return nil, st.Err()
+	}
+
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: userRequestService.UpdateUserProfileRequest failed", updateUserProfileLogTag),
+			"err", err,
+			"userRequestId", req.GetIdUser(),
+		)
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	logger.Info(ctx, fmt.Sprintf("%s: success", updateUserProfileLogTag))
+
+	return &desc.UpdateUserProfileResponse{
+		Updated: result,
+	}, nil
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -20,39 +22,171 @@ func GetConfigInstance() Config {
 
 // Database - contains all parameters database connection.
 type Database struct {
-	Host       string `yaml:"host"`
-	Port       string `yaml:"port"`
-	User       string `yaml:"user"`
-	Password   string `yaml:"password"`
-	Migrations string `yaml:"migrations"`
-	Name       string `yaml:"name"`
-	SslMode    string `yaml:"sslmode"`
-	Driver     string `yaml:"driver"`
+	Host        string `yaml:"host"`
+	Port        string `yaml:"port"`
+	User        string `yaml:"user"`
+	Password    string `yaml:"password"`
+	AutoMigrate bool   `yaml:"autoMigrate"`
+	Name        string `yaml:"name"`
+	SslMode     string `yaml:"sslmode"`
+	Driver      string `yaml:"driver"`
+	Path        string `yaml:"path"`
+
+	Replicas             []Replica `yaml:"replicas"`
+	ReplicaMaxLag        int64     `yaml:"replicaMaxLag"`
+	ReplicaCheckInterval int64     `yaml:"replicaCheckInterval"`
+
+	Pool Pool `yaml:"pool"`
+
+	Shards            []Shard `yaml:"shards"`
+	ShardVirtualNodes int     `yaml:"shardVirtualNodes"`
+}
+
+// sqliteDriver - driver that keeps database in file at Path, see database.SQLiteDriver
+const sqliteDriver = "sqlite3"
+
+// DSN - connection string of primary. SQLite transactions take the write lock when they begin
+// and wait for it instead of failing, as it has no row locks
+func (d Database) DSN() string {
+	if d.Driver == sqliteDriver {
+		return fmt.Sprintf("file:%s?_foreign_keys=on&_journal_mode=WAL&_busy_timeout=5000&_txlock=immediate", d.Path)
+	}
+
+	return d.ReplicaDSN(Replica{Host: d.Host, Port: d.Port})
+}
+
+// ReplicaDSN - connection string of replica, credentials and database name are shared with primary
+func (d Database) ReplicaDSN(r Replica) string {
+	return fmt.Sprintf("host=%v port=%v user=%v password=%v dbname=%v sslmode=%v",
+		r.Host,
+		r.Port,
+		d.User,
+		d.Password,
+		d.Name,
+		d.SslMode,
+	)
+}
+
+// ShardDSN - connection string of shard, credentials and database name are shared with primary
+func (d Database) ShardDSN(s Shard) string {
+	return d.ReplicaDSN(Replica{Host: s.Host, Port: s.Port})
+}
+
+// Replica - contains address of read replica.
+type Replica struct {
+	Host string `yaml:"host"`
+	Port string `yaml:"port"`
+}
+
+// Shard - contains address of users shard, its name places it on hash ring and must not change.
+// Reads of shard are served by its replicas like reads of primary.
+type Shard struct {
+	Name     string    `yaml:"name"`
+	Host     string    `yaml:"host"`
+	Port     string    `yaml:"port"`
+	Draining bool      `yaml:"draining"`
+	Replicas []Replica `yaml:"replicas"`
 }
+
+// Pool - contains settings of pgxpool, used with driver pgxpool, zero values keep pgxpool defaults.
+type Pool struct {
+	MaxConns           int32  `yaml:"maxConns"`
+	MinConns           int32  `yaml:"minConns"`
+	MaxConnLifetime    int64  `yaml:"maxConnLifetime"`
+	MaxConnIdleTime    int64  `yaml:"maxConnIdleTime"`
+	HealthCheckPeriod  int64  `yaml:"healthCheckPeriod"`
+	StatementCacheMode string `yaml:"statementCacheMode"`
+}
+
 type Database1 struct {
-	Host       string `yaml:"host"`
-	Port       string `yaml:"port"`
-	User       string `yaml:"user"`
-	Password   string `yaml:"password"`
-	Migrations string `yaml:"migrations"`
-	Name       string `yaml:"name"`
-	SslMode    string `yaml:"sslmode"`
-	Driver     string `yaml:"driver"`
+	Host        string `yaml:"host"`
+	Port        string `yaml:"port"`
+	User        string `yaml:"user"`
+	Password    string `yaml:"password"`
+	AutoMigrate bool   `yaml:"autoMigrate"`
+	Name        string `yaml:"name"`
+	SslMode     string `yaml:"sslmode"`
+	Driver      string `yaml:"driver"`
+	Path        string `yaml:"path"`
+
+	Replicas             []Replica `yaml:"replicas"`
+	ReplicaMaxLag        int64     `yaml:"replicaMaxLag"`
+	ReplicaCheckInterval int64     `yaml:"replicaCheckInterval"`
+
+	Pool Pool `yaml:"pool"`
+
+	Shards            []Shard `yaml:"shards"`
+	ShardVirtualNodes int     `yaml:"shardVirtualNodes"`
 }
 
-// Grpc - contains parameter address grpc.
+// Grpc - contains parameter address grpc, statuses of grpc.health.v1 are refreshed every HealthCheckInterval
+// seconds, graceful stop is forced after ShutdownTimeout seconds, so open Watch streams do not block it.
 type Grpc struct {
+	Port                int    `yaml:"port"`
+	MaxConnectionIdle   int64  `yaml:"maxConnectionIdle"`
+	Timeout             int64  `yaml:"timeout"`
+	MaxConnectionAge    int64  `yaml:"maxConnectionAge"`
+	Host                string `yaml:"host"`
+	HealthCheckInterval int64  `yaml:"healthCheckInterval"`
+	ShutdownTimeout     int64  `yaml:"shutdownTimeout"`
+}
+
+// Rest - contains parameter rest json connection, timeouts are in seconds, zero disables them.
+type Rest struct {
 	Port              int    `yaml:"port"`
-	MaxConnectionIdle int64  `yaml:"maxConnectionIdle"`
-	Timeout           int64  `yaml:"timeout"`
-	MaxConnectionAge  int64  `yaml:"maxConnectionAge"`
 	Host              string `yaml:"host"`
+	ReadTimeout       int64  `yaml:"readTimeout"`
+	ReadHeaderTimeout int64  `yaml:"readHeaderTimeout"`
+	WriteTimeout      int64  `yaml:"writeTimeout"`
+	IdleTimeout       int64  `yaml:"idleTimeout"`
+	MaxBodyBytes      int64  `yaml:"maxBodyBytes"`
+	AccessLog         bool   `yaml:"accessLog"`
+	Cors              Cors   `yaml:"cors"`
 }
 
-// Rest - contains parameter rest json connection.
-type Rest struct {
-	Port int    `yaml:"port"`
+// Cors - contains parameters of cross-origin requests to rest gateway, no origin is allowed when AllowedOrigins is empty.
+type Cors struct {
+	AllowedOrigins   []string `yaml:"allowedOrigins"`
+	AllowedMethods   []string `yaml:"allowedMethods"`
+	AllowedHeaders   []string `yaml:"allowedHeaders"`
+	AllowCredentials bool     `yaml:"allowCredentials"`
+	MaxAge           int64    `yaml:"maxAge"`
+}
+
+// Auth - contains parameters of authentication of requests by bearer JWTs of authorization metadata,
+// signatures are checked against keys of JWKS file reloaded every ReloadInterval seconds when it changes,
+// Leeway seconds are allowed for clock skew. ExemptMethods are full gRPC method names,
+// names ending with "/" exempt every method of the service.
+type Auth struct {
+	Enabled        bool     `yaml:"enabled"`
+	JWKSFile       string   `yaml:"jwksFile"`
+	ReloadInterval int64    `yaml:"reloadInterval"`
+	Issuer         string   `yaml:"issuer"`
+	Audience       string   `yaml:"audience"`
+	Leeway         int64    `yaml:"leeway"`
+	ExemptMethods  []string `yaml:"exemptMethods"`
+}
+
+// Authz - contains parameters of method level authorization, PolicyFile grants methods to roles, scopes
+// and subjects of callers, methods it does not grant are denied.
+type Authz struct {
+	Enabled    bool   `yaml:"enabled"`
+	PolicyFile string `yaml:"policyFile"`
+}
+
+// Metrics - contains parameters of metrics server scraped by prometheus.
+type Metrics struct {
 	Host string `yaml:"host"`
+	Port int    `yaml:"port"`
+	Path string `yaml:"path"`
+}
+
+// Status - contains parameters of status server of liveness, readiness and startup probes,
+// readiness fails when a dependency check takes longer than CheckTimeout seconds.
+type Status struct {
+	Host         string `yaml:"host"`
+	Port         int    `yaml:"port"`
+	CheckTimeout int64  `yaml:"checkTimeout"`
 }
 
 // Project - contains all parameters project information.
@@ -75,15 +209,79 @@ type Telemetry struct {
 	GraylogPath string `yaml:"graylogPath"`
 }
 
+// Profile - contains parameters of extended user profile.
+type Profile struct {
+	SchemaPath string `yaml:"schemaPath"`
+}
+
+// Duplicates - contains parameters of duplicate users analyzer.
+type Duplicates struct {
+	Enabled             bool    `yaml:"enabled"`
+	Interval            int64   `yaml:"interval"`
+	BatchSize           uint64  `yaml:"batchSize"`
+	NameThreshold       float64 `yaml:"nameThreshold"`
+	EmailLocalThreshold float64 `yaml:"emailLocalThreshold"`
+	MinScore            float64 `yaml:"minScore"`
+}
+
+// UserCache - contains parameters of read-through cache of user lookups.
+type UserCache struct {
+	Enabled bool  `yaml:"enabled"`
+	Size    int   `yaml:"size"`
+	TTL     int64 `yaml:"ttl"`
+	Notify  bool  `yaml:"notify"`
+}
+
+// Encryption - contains parameters of envelope encryption of user emails, keys are base64 encoded,
+// keys of KeyFile replace the ones given here.
+type Encryption struct {
+	Enabled           bool              `yaml:"enabled"`
+	KeyFile           string            `yaml:"keyFile"`
+	CurrentKey        uint32            `yaml:"currentKey"`
+	Keys              map[uint32]string `yaml:"keys"`
+	BlindIndexKey     string            `yaml:"blindIndexKey"`
+	RotationInterval  int64             `yaml:"rotationInterval"`
+	RotationBatchSize uint64            `yaml:"rotationBatchSize"`
+}
+
+// Anonymize - contains parameters of anonymized export of users, columns map column names to strategies
+// of internal/pkg/anonymize, columns that are not listed use its defaults.
+type Anonymize struct {
+	Seed    string            `yaml:"seed"`
+	Columns map[string]string `yaml:"columns"`
+}
+
 // Config - contains all configuration parameters in config package.
 type Config struct {
-	Project   Project   `yaml:"project"`
-	Grpc      Grpc      `yaml:"grpc"`
-	Rest      Rest      `yaml:"rest"`
-	Database  Database  `yaml:"database"`
-	Database1 Database1 `yaml:"database1"`
-	Jaeger    Jaeger    `yaml:"jaeger"`
-	Telemetry Telemetry `yaml:"telemetry"`
+	Project    Project    `yaml:"project"`
+	Grpc       Grpc       `yaml:"grpc"`
+	Rest       Rest       `yaml:"rest"`
+	Status     Status     `yaml:"status"`
+	Metrics    Metrics    `yaml:"metrics"`
+	Auth       Auth       `yaml:"auth"`
+	Authz      Authz      `yaml:"authz"`
+	Database   Database   `yaml:"database"`
+	Database1  Database1  `yaml:"database1"`
+	Jaeger     Jaeger     `yaml:"jaeger"`
+	Telemetry  Telemetry  `yaml:"telemetry"`
+	Profile    Profile    `yaml:"profile"`
+	Duplicates Duplicates `yaml:"duplicates"`
+	UserCache  UserCache  `yaml:"userCache"`
+	Encryption Encryption `yaml:"encryption"`
+	Anonymize  Anonymize  `yaml:"anonymize"`
+}
+
+// ErrDuplicatesWithShards is a "duplicate analyzer can not run on sharded users" error
+var ErrDuplicatesWithShards = errors.New("duplicates.enabled can not be used with database.shards: " +
+	"duplicate analysis compares users of one database, set duplicates.enabled to false to shard users")
+
+// Validate - check that enabled features can be served by database db
+func (c Config) Validate(db Database) error {
+	if c.Duplicates.Enabled && len(db.Shards) > 0 {
+		return ErrDuplicatesWithShards
+	}
+
+	return nil
 }
 
 // ReadConfigYML - read configurations from file and init instance Config.
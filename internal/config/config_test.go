@@ -0,0 +1,40 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	shards := []Shard{{Name: "users-0", Host: "postgres-users-0", Port: "5432"}}
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		db      Database
+		wantErr error
+	}{
+		{
+			name: "duplicates without shards",
+			cfg:  Config{Duplicates: Duplicates{Enabled: true}},
+		},
+		{
+			name: "shards without duplicates",
+			db:   Database{Shards: shards},
+		},
+		{
+			name:    "duplicates with shards",
+			cfg:     Config{Duplicates: Duplicates{Enabled: true}},
+			db:      Database{Shards: shards},
+			wantErr: ErrDuplicatesWithShards,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(tt.db); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"cmd/main.go/internal/logger"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+const clusterHealthCheckLogTag = "Cluster.RunHealthChecks()"
+
+const defaultReplicaCheckInterval = 10 * time.Second
+
+// replicaLagQuery - replay delay of replica in seconds, a replica that replayed everything it received
+// is not lagging even if primary had no writes for a long time, primary itself reports 0
+const replicaLagQuery = `SELECT CASE
+	WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END`
+
+type readYourWritesKey struct{}
+
+// WithReadYourWrites returns ctx in which reads go to primary, so they see preceding writes of the caller
+func WithReadYourWrites(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readYourWritesKey{}, true)
+}
+
+// IsReadYourWrites - check whether reads in ctx must go to primary
+func IsReadYourWrites(ctx context.Context) bool {
+	v, _ := ctx.Value(readYourWritesKey{}).(bool)
+
+	return v
+}
+
+type replica struct {
+	name    string
+	db      *sqlx.DB
+	healthy atomic.Bool
+	lag     atomic.Int64
+}
+
+// Cluster is a primary with read replicas, replicas are used for reads only while they are healthy
+// and their lag is in bounds, otherwise reads fall back to primary
+type Cluster struct {
+	primary  *sqlx.DB
+	replicas []*replica
+	maxLag   time.Duration
+	next     atomic.Uint64
+}
+
+// NewCluster returns Cluster, replicas are checked once before return,
+// unreachable ones are kept and start serving reads after they pass a health check
+func NewCluster(ctx context.Context, primary *sqlx.DB, driver string, replicaDSNs map[string]string, maxLag time.Duration) (*Cluster, error) {
+	c := &Cluster{
+		primary: primary,
+		maxLag:  maxLag,
+	}
+
+	for name, dsn := range replicaDSNs {
+		db, err := sqlx.Open(sqlDriver(driver), dsn)
+		if err != nil {
+			//nolint
+			c.Close()
+
+			return nil, errors.Wrapf(err, "sqlx.Open(%s)", name)
+		}
+		c.replicas = append(c.replicas, &replica{name: name, db: db})
+	}
+
+	c.checkReplicas(ctx)
+
+	return c, nil
+}
+
+// Primary returns connection to primary, it is used for writes and transactions
+func (c *Cluster) Primary() *sqlx.DB {
+	return c.primary
+}
+
+// Reader returns queryer for read statements: transaction from ctx, primary when read-your-writes
+// is requested or there is no usable replica, otherwise replicas in round robin
+func (c *Cluster) Reader(ctx context.Context) sqlx.ExtContext {
+	if tx := TxFor(ctx, c.primary); tx != nil {
+		return tx
+	}
+
+	if len(c.replicas) == 0 || IsReadYourWrites(ctx) {
+		return c.primary
+	}
+
+	start := c.next.Add(1)
+	for i := range c.replicas {
+		r := c.replicas[(start+uint64(i))%uint64(len(c.replicas))]
+		if r.healthy.Load() && time.Duration(r.lag.Load()) <= c.maxLag {
+			return r.db
+		}
+	}
+
+	return c.primary
+}
+
+// RunHealthChecks - ping replicas and measure their lag periodically until context is done
+func (c *Cluster) RunHealthChecks(ctx context.Context, interval time.Duration) {
+	if len(c.replicas) == 0 {
+		return
+	}
+
+	if interval <= 0 {
+		interval = defaultReplicaCheckInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.checkReplicas(ctx)
+		}
+	}
+}
+
+// Close closes replicas, primary is owned by caller
+func (c *Cluster) Close() error {
+	var result error
+	for _, r := range c.replicas {
+		if err := r.db.Close(); err != nil && result == nil {
+			result = errors.Wrapf(err, "replica %s", r.name)
+		}
+	}
+
+	return result
+}
+
+func (c *Cluster) checkReplicas(ctx context.Context) {
+	for _, r := range c.replicas {
+		checkCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		lag, err := replicaLag(checkCtx, r.db)
+		cancel()
+
+		wasHealthy := r.healthy.Load()
+		r.healthy.Store(err == nil)
+		if err == nil {
+			r.lag.Store(int64(lag))
+		}
+
+		switch {
+		case err != nil && wasHealthy:
+			logger.WarnKV(ctx, fmt.Sprintf("%s: replica is unhealthy", clusterHealthCheckLogTag),
+				"replica", r.name,
+				"err", err,
+			)
+		case err == nil && !wasHealthy:
+			logger.InfoKV(ctx, fmt.Sprintf("%s: replica is healthy", clusterHealthCheckLogTag),
+				"replica", r.name,
+				"lag", lag.String(),
+			)
+		case err == nil && lag > c.maxLag:
+			logger.WarnKV(ctx, fmt.Sprintf("%s: replica lag exceeds limit", clusterHealthCheckLogTag),
+				"replica", r.name,
+				"lag", lag.String(),
+				"maxLag", c.maxLag.String(),
+			)
+		}
+	}
+}
+
+func replicaLag(ctx context.Context, db *sqlx.DB) (time.Duration, error) {
+	var seconds float64
+	if err := db.QueryRowxContext(ctx, replicaLagQuery).Scan(&seconds); err != nil {
+		return 0, errors.Wrap(err, "db.QueryRowxContext()")
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
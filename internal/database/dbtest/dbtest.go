@@ -0,0 +1,111 @@
+// Package dbtest opens migrated databases for tests of repositories. Postgres tests run against
+// a disposable database from PostgresDSNEnv and are skipped when it is not set.
+package dbtest
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/database/migrate"
+	"cmd/main.go/migrations"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgresDSNEnv - environment variable with DSN of disposable Postgres database,
+// tests write to it and remove only rows they inserted
+const PostgresDSNEnv = "TEST_POSTGRES_DSN"
+
+// Postgres returns migrated DB on pgxpool of PostgresDSNEnv, the test is skipped when it is not set
+func Postgres(tb testing.TB) *sqlx.DB {
+	tb.Helper()
+
+	dsn := os.Getenv(PostgresDSNEnv)
+	if dsn == "" {
+		tb.Skipf("%s is not set", PostgresDSNEnv)
+	}
+
+	pool, err := database.NewPgxPool(context.Background(), dsn, config.Pool{})
+	if err != nil {
+		tb.Fatalf("database.NewPgxPool(): %v", err)
+	}
+	tb.Cleanup(pool.Close)
+
+	db := database.NewDBFromPool(pool)
+	tb.Cleanup(func() {
+		//nolint
+		db.Close()
+	})
+
+	migrateUp(tb, db)
+
+	return db
+}
+
+// SQLite returns migrated DB in temporary directory of the test, the test is skipped
+// when binary is built without sqlite3 driver
+func SQLite(tb testing.TB) *sqlx.DB {
+	tb.Helper()
+
+	if !registered(database.SQLiteDriver) {
+		tb.Skipf("%s driver is not compiled in", database.SQLiteDriver)
+	}
+
+	dbCfg := config.Database{Driver: database.SQLiteDriver, Path: filepath.Join(tb.TempDir(), "users.db")}
+	db, err := database.NewPostgres(context.Background(), dbCfg.DSN(), dbCfg.Driver)
+	if err != nil {
+		tb.Fatalf("database.NewPostgres(): %v", err)
+	}
+	tb.Cleanup(func() {
+		//nolint
+		db.Close()
+	})
+
+	migrateUp(tb, db)
+
+	return db
+}
+
+// Cluster returns cluster of db without replicas
+func Cluster(tb testing.TB, db *sqlx.DB) *database.Cluster {
+	tb.Helper()
+
+	cluster, err := database.NewCluster(context.Background(), db, db.DriverName(), nil, 0)
+	if err != nil {
+		tb.Fatalf("database.NewCluster(): %v", err)
+	}
+	tb.Cleanup(func() {
+		//nolint
+		cluster.Close()
+	})
+
+	return cluster
+}
+
+func migrateUp(tb testing.TB, db *sqlx.DB) {
+	tb.Helper()
+
+	migrator, err := migrate.New(db, migrations.For(database.DialectOf(db)))
+	if err != nil {
+		tb.Fatalf("migrate.New(): %v", err)
+	}
+
+	if _, err = migrator.Up(context.Background()); err != nil {
+		tb.Fatalf("migrator.Up(): %v", err)
+	}
+}
+
+func registered(driver string) bool {
+	for _, name := range sql.Drivers() {
+		if name == driver {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"encoding/json"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+// SQLiteDriver is a value of database.driver that stores data in SQLite file,
+// the driver is compiled in unless binary is built with no_sqlite3 tag
+const SQLiteDriver = "sqlite3"
+
+// Dialect - SQL differences of supported databases, repositories build statements with dialect
+// of their DB, so the same repository runs on Postgres and SQLite
+type Dialect struct {
+	name        string
+	placeholder sq.PlaceholderFormat
+	jsonParam   string
+	bigintParam string
+	lockRows    string
+}
+
+var (
+	// Postgres - labels and profiles are jsonb, rows are locked with FOR UPDATE
+	Postgres = &Dialect{
+		name:        "postgres",
+		placeholder: sq.Dollar,
+		jsonParam:   "?::jsonb",
+		bigintParam: "?::bigint",
+		lockRows:    "FOR UPDATE",
+	}
+	// SQLite - labels and profiles are JSON text handled by json1 functions, there are no row locks,
+	// transactions begin immediate (see config.Database.DSN) and serialize writers instead
+	SQLite = &Dialect{
+		name:        "sqlite",
+		placeholder: sq.Question,
+		jsonParam:   "json(?)",
+		bigintParam: "CAST(? AS INTEGER)",
+	}
+)
+
+// StatementBuilder is a placeholder for queries of Postgres only code, repositories that run
+// on every dialect use Dialect.Builder
+var StatementBuilder = Postgres.Builder()
+
+// DialectOf returns dialect of db by its driver
+func DialectOf(db *sqlx.DB) *Dialect {
+	if db.DriverName() == SQLiteDriver {
+		return SQLite
+	}
+
+	return Postgres
+}
+
+// Name returns name of dialect, it is also a name of directory with its migrations
+func (d *Dialect) Name() string {
+	return d.name
+}
+
+// Builder returns statement builder with placeholders of dialect
+func (d *Dialect) Builder() sq.StatementBuilderType {
+	return sq.StatementBuilder.PlaceholderFormat(d.placeholder)
+}
+
+// JSON - value bound as JSON document
+func (d *Dialect) JSON(value interface{}) sq.Sqlizer {
+	return sq.Expr(d.jsonParam, value)
+}
+
+// BigInt - value bound as 64-bit integer where type of parameter can not be inferred, e.g. in select list
+func (d *Dialect) BigInt(value interface{}) sq.Sqlizer {
+	return sq.Expr(d.bigintParam, value)
+}
+
+// LockForUpdate - lock selected rows until the end of transaction where dialect has row locks
+func (d *Dialect) LockForUpdate(sb sq.SelectBuilder) sq.SelectBuilder {
+	if d.lockRows == "" {
+		return sb
+	}
+
+	return sb.Suffix(d.lockRows)
+}
+
+// JSONKeyEquals - string value of key of JSON object in column equals value, false when key is missing
+func (d *Dialect) JSONKeyEquals(column, key, value string) sq.Sqlizer {
+	if d == SQLite {
+		return sq.Expr("json_extract("+column+", ?) IS ?", jsonPath(key), value)
+	}
+
+	// containment is served by GIN index of the column
+	object, _ := json.Marshal(map[string]string{key: value})
+
+	return sq.Expr(column+" @> ?::jsonb", string(object))
+}
+
+// JSONHasKey - JSON object in column has key
+func (d *Dialect) JSONHasKey(column, key string) sq.Sqlizer {
+	if d == SQLite {
+		return sq.Expr("json_type("+column+", ?) IS NOT NULL", jsonPath(key))
+	}
+
+	return sq.Expr(column+" ?? ?", key)
+}
+
+// JSONMergeKeys - JSON object in column without removeKeys and with keys of JSON object set,
+// set wins for keys present in both
+func (d *Dialect) JSONMergeKeys(column string, set interface{}, removeKeys []string) sq.Sqlizer {
+	if d == SQLite {
+		paths := make([]interface{}, 0, len(removeKeys))
+		for _, key := range removeKeys {
+			paths = append(paths, jsonPath(key))
+		}
+		removed := column
+		if len(paths) > 0 {
+			removed = "json_remove(" + column + strings.Repeat(", ?", len(paths)) + ")"
+		}
+
+		return sq.Expr("json_patch("+removed+", json(?))", append(paths, set)...)
+	}
+
+	if removeKeys == nil {
+		removeKeys = []string{}
+	}
+
+	return sq.Expr("("+column+" - ?::text[]) || ?::jsonb", removeKeys, set)
+}
+
+// jsonPath - SQLite path of top level key, keys are quoted as they may contain dots
+func jsonPath(key string) string {
+	return `$."` + key + `"`
+}
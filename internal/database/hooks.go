@@ -0,0 +1,44 @@
+package database
+
+import "context"
+
+type commitHooksKey struct{}
+
+// CommitHooks - functions registered with AfterCommit while transaction runs
+type CommitHooks struct {
+	fns []func()
+}
+
+// WithCommitHooks returns ctx of a new transaction, transactor runs returned hooks after commit
+// and drops them on rollback
+func WithCommitHooks(ctx context.Context) (context.Context, *CommitHooks) {
+	hooks := &CommitHooks{}
+
+	return context.WithValue(ctx, commitHooksKey{}, hooks), hooks
+}
+
+// Run - call hooks in order of registration
+func (h *CommitHooks) Run() {
+	for _, fn := range h.fns {
+		fn()
+	}
+}
+
+// AfterCommit - run fn after transaction from ctx is committed, or right away when ctx has no transaction
+func AfterCommit(ctx context.Context, fn func()) {
+	hooks, ok := ctx.Value(commitHooksKey{}).(*CommitHooks)
+	if !ok {
+		fn()
+
+		return
+	}
+
+	hooks.fns = append(hooks.fns, fn)
+}
+
+// InTx - check whether ctx carries transaction of any Transactor
+func InTx(ctx context.Context) bool {
+	_, ok := ctx.Value(commitHooksKey{}).(*CommitHooks)
+
+	return ok
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// outcomes of transactions
+const (
+	txCommitted   = "committed"
+	txRolledBack  = "rolled_back"
+	txBeginFailed = "begin_failed"
+	txCommitFail  = "commit_failed"
+)
+
+var (
+	queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Duration of repository methods that query database",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method"})
+	txDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_transaction_duration_seconds",
+		Help:    "Duration of transaction attempts by outcome",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"outcome"})
+	txRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "db_transaction_retries_total",
+		Help: "The total number of transactions rerun after serialization failure or deadlock",
+	})
+)
+
+// QueryTimer returns timer of repository method, repositories defer its ObserveDuration
+// next to their tracing span
+func QueryTimer(method string) *prometheus.Timer {
+	return prometheus.NewTimer(queryDuration.WithLabelValues(method))
+}
+
+func observeTx(outcome string, start time.Time) {
+	txDuration.WithLabelValues(outcome).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,420 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/logger"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+const (
+	migrateLogTag = "Migrator"
+
+	versionTable = "schema_migrations"
+
+	// lockKey is a key of advisory lock that serializes migrations between instances
+	lockKey = 7203000
+)
+
+// dialectStatements - bookkeeping statements of dialects, SQLite database is a file of one instance
+// and needs no lock between instances
+var dialectStatements = map[*database.Dialect]statements{
+	database.Postgres: {
+		lock:   "SELECT pg_advisory_lock($1)",
+		unlock: "SELECT pg_advisory_unlock($1)",
+		createVersionTable: "CREATE TABLE IF NOT EXISTS " + versionTable + ` (
+		version    BIGINT PRIMARY KEY,
+		name       TEXT        NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+		versionTableExists: "SELECT to_regclass($1) IS NOT NULL",
+	},
+	database.SQLite: {
+		createVersionTable: "CREATE TABLE IF NOT EXISTS " + versionTable + ` (
+		version    BIGINT PRIMARY KEY,
+		name       TEXT      NOT NULL,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`,
+		versionTableExists: "SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = ?)",
+	},
+}
+
+type statements struct {
+	lock               string
+	unlock             string
+	createVersionTable string
+	versionTableExists string
+}
+
+var fileNameRegexp = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// ErrNoMigrations is a "no migrations found" error
+var ErrNoMigrations = errors.New("no migrations found")
+
+// ErrPendingMigrations is a "database misses migrations of binary" error
+var ErrPendingMigrations = errors.New("migrations are not applied")
+
+// ErrUnknownVersion is a "database has version unknown to binary" error
+var ErrUnknownVersion = errors.New("database has applied migration unknown to this binary")
+
+// Migration is a versioned pair of up and down scripts
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status is a migration with time it was applied at
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator applies migrations from fs to database
+type Migrator struct {
+	db         *sqlx.DB
+	statements statements
+	migrations []Migration
+}
+
+// New returns Migrator with migrations loaded from fsys, each version must have both up and down files,
+// migrations must be written for dialect of db
+func New(db *sqlx.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		statements: dialectStatements[database.DialectOf(db)],
+		migrations: migrations,
+	}, nil
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, errors.Wrap(err, "fs.Glob()")
+	}
+
+	byVersion := make(map[uint64]*Migration)
+	for _, file := range files {
+		match := fileNameRegexp.FindStringSubmatch(path.Base(file))
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", file)
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "migration %q version", file)
+		}
+
+		body, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fs.ReadFile(%q)", file)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has different names %q and %q", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	if len(byVersion) == 0 {
+		return nil, ErrNoMigrations
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up - apply all pending migrations, returns migrations that were applied
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "migrate.Up")
+	defer span.Finish()
+
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		versions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if err = m.checkKnown(versions); err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := versions[migration.Version]; ok {
+				continue
+			}
+
+			if err = apply(ctx, conn, migration.Up,
+				m.db.Rebind("INSERT INTO "+versionTable+" (version, name) VALUES (?, ?)"), migration.Version, migration.Name); err != nil {
+				return errors.Wrapf(err, "migration %d_%s up", migration.Version, migration.Name)
+			}
+
+			logger.InfoKV(ctx, fmt.Sprintf("%s: migration applied", migrateLogTag),
+				"version", migration.Version,
+				"name", migration.Name,
+			)
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down - roll back last steps applied migrations, returns migrations that were rolled back
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "migrate.Down")
+	defer span.Finish()
+
+	var rolledBack []Migration
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		versions, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if err = m.checkKnown(versions); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := versions[migration.Version]; !ok {
+				continue
+			}
+
+			if err = apply(ctx, conn, migration.Down,
+				m.db.Rebind("DELETE FROM "+versionTable+" WHERE version = ?"), migration.Version); err != nil {
+				return errors.Wrapf(err, "migration %d_%s down", migration.Version, migration.Name)
+			}
+
+			logger.InfoKV(ctx, fmt.Sprintf("%s: migration rolled back", migrateLogTag),
+				"version", migration.Version,
+				"name", migration.Name,
+			)
+			rolledBack = append(rolledBack, migration)
+		}
+
+		return nil
+	})
+
+	return rolledBack, err
+}
+
+// Status - list all known migrations with their state in database, versions are read without the lock
+// and without creating the version table, so status neither waits for running migrations nor writes
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "migrate.Status")
+	defer span.Finish()
+
+	versions, err := m.readVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		appliedAt, ok := versions[migration.Version]
+		statuses = append(statuses, Status{
+			Migration: migration,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		})
+	}
+
+	return statuses, m.checkKnown(versions)
+}
+
+// Version - highest version applied to database, 0 when no migration was applied,
+// versions are read as Status reads them
+func (m *Migrator) Version(ctx context.Context) (uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "migrate.Version")
+	defer span.Finish()
+
+	versions, err := m.readVersions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var version uint64
+	for v := range versions {
+		if v > version {
+			version = v
+		}
+	}
+
+	return version, m.checkKnown(versions)
+}
+
+// Applied - fail unless every known migration is applied, versions are read as Status reads them
+func (m *Migrator) Applied(ctx context.Context) error {
+	versions, err := m.readVersions(ctx)
+	if err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, migration := range m.migrations {
+		if _, ok := versions[migration.Version]; !ok {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return errors.Wrapf(ErrPendingMigrations, "%d of %d", pending, len(m.migrations))
+	}
+
+	return m.checkKnown(versions)
+}
+
+// readVersions - applied versions read without the lock, missing version table means no versions
+func (m *Migrator) readVersions(ctx context.Context) (map[uint64]time.Time, error) {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.Connx()")
+	}
+	//nolint
+	defer conn.Close()
+
+	return m.appliedVersions(ctx, conn)
+}
+
+// withLock - run fn on dedicated connection holding session advisory lock,
+// concurrent instances wait until the lock is released
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return errors.Wrap(err, "db.Connx()")
+	}
+	//nolint
+	defer conn.Close()
+
+	if m.statements.lock != "" {
+		if _, err = conn.ExecContext(ctx, m.statements.lock, lockKey); err != nil {
+			return errors.Wrap(err, "lock migrations")
+		}
+		defer func() {
+			//nolint
+			conn.ExecContext(context.Background(), m.statements.unlock, lockKey)
+		}()
+	}
+
+	if _, err = conn.ExecContext(ctx, m.statements.createVersionTable); err != nil {
+		return errors.Wrap(err, "create "+versionTable)
+	}
+
+	return fn(conn)
+}
+
+// checkKnown - database must not be ahead of the binary, otherwise rollback can not be done
+func (m *Migrator) checkKnown(versions map[uint64]time.Time) error {
+	known := make(map[uint64]struct{}, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = struct{}{}
+	}
+
+	for version := range versions {
+		if _, ok := known[version]; !ok {
+			return errors.Wrapf(ErrUnknownVersion, "version %d", version)
+		}
+	}
+
+	return nil
+}
+
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sqlx.Conn) (map[uint64]time.Time, error) {
+	var exists bool
+	if err := conn.QueryRowxContext(ctx, m.statements.versionTableExists, versionTable).Scan(&exists); err != nil {
+		return nil, errors.Wrap(err, "conn.QueryRowxContext()")
+	}
+
+	versions := make(map[uint64]time.Time)
+	if !exists {
+		return versions, nil
+	}
+
+	rows, err := conn.QueryxContext(ctx, "SELECT version, applied_at FROM "+versionTable)
+	if err != nil {
+		return nil, errors.Wrap(err, "conn.QueryxContext()")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version uint64
+		var appliedAt time.Time
+		if err = rows.Scan(&version, &appliedAt); err != nil {
+			return nil, errors.Wrap(err, "rows.Scan()")
+		}
+		versions[version] = appliedAt
+	}
+
+	return versions, rows.Err()
+}
+
+// apply - run script and bookkeeping statement in one transaction
+func apply(ctx context.Context, conn *sqlx.Conn, script string, bookkeeping string, args ...interface{}) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "conn.BeginTxx()")
+	}
+
+	if _, err = tx.ExecContext(ctx, script); err != nil {
+		//nolint
+		tx.Rollback()
+
+		return errors.Wrap(err, "tx.ExecContext()")
+	}
+
+	if _, err = tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		//nolint
+		tx.Rollback()
+
+		return errors.Wrap(err, "tx.ExecContext()")
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "Tx.Commit")
+	}
+
+	return nil
+}
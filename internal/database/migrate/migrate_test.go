@@ -0,0 +1,95 @@
+package migrate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	file := func(body string) *fstest.MapFile {
+		return &fstest.MapFile{Data: []byte(body)}
+	}
+
+	tests := []struct {
+		name     string
+		fsys     fstest.MapFS
+		versions []uint64
+		err      string
+	}{
+		{
+			name: "sorted by version",
+			fsys: fstest.MapFS{
+				"000002_add_labels.up.sql":     file("ALTER"),
+				"000002_add_labels.down.sql":   file("ALTER"),
+				"000001_create_users.up.sql":   file("CREATE"),
+				"000001_create_users.down.sql": file("DROP"),
+				"README.md":                    file("not a migration"),
+			},
+			versions: []uint64{1, 2},
+		},
+		{
+			name: "missing down",
+			fsys: fstest.MapFS{
+				"000001_create_users.up.sql": file("CREATE"),
+			},
+			err: "must have both up and down files",
+		},
+		{
+			name: "missing up",
+			fsys: fstest.MapFS{
+				"000001_create_users.down.sql": file("DROP"),
+			},
+			err: "must have both up and down files",
+		},
+		{
+			name: "name mismatch",
+			fsys: fstest.MapFS{
+				"000001_create_users.up.sql":    file("CREATE"),
+				"000001_create_people.down.sql": file("DROP"),
+			},
+			err: "has different names",
+		},
+		{
+			name: "invalid file name",
+			fsys: fstest.MapFS{
+				"create_users.up.sql": file("CREATE"),
+			},
+			err: "invalid migration file name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := load(tt.fsys)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("load() error = %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("load() error = %v", err)
+			}
+
+			if len(migrations) != len(tt.versions) {
+				t.Fatalf("load() returned %d migrations, want %d", len(migrations), len(tt.versions))
+			}
+			for i, m := range migrations {
+				if m.Version != tt.versions[i] {
+					t.Errorf("migration %d version = %d, want %d", i, m.Version, tt.versions[i])
+				}
+				if m.Up == "" || m.Down == "" {
+					t.Errorf("migration %d has empty script", m.Version)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadEmpty(t *testing.T) {
+	if _, err := load(fstest.MapFS{}); !errors.Is(err, ErrNoMigrations) {
+		t.Fatalf("load() error = %v, want %v", err, ErrNoMigrations)
+	}
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns    *prometheus.Desc
+	idleConns        *prometheus.Desc
+	totalConns       *prometheus.Desc
+	maxConns         *prometheus.Desc
+	acquires         *prometheus.Desc
+	emptyAcquires    *prometheus.Desc
+	canceledAcquires *prometheus.Desc
+	acquireDuration  *prometheus.Desc
+	emptyAcquireWait *prometheus.Desc
+}
+
+// NewPoolCollector returns prometheus collector that exports statistics of pool on every scrape,
+// metrics are labeled with database name, so pools of several databases can be registered
+func NewPoolCollector(pool *pgxpool.Pool, database string) prometheus.Collector {
+	labels := prometheus.Labels{"database": database}
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(name, help, nil, labels)
+	}
+
+	return poolCollector{
+		pool: pool,
+
+		acquiredConns: desc("db_pool_acquired_conns",
+			"The number of currently acquired connections in the pool"),
+		idleConns: desc("db_pool_idle_conns",
+			"The number of currently idle connections in the pool"),
+		totalConns: desc("db_pool_total_conns",
+			"The total number of connections in the pool, including ones being constructed"),
+		maxConns: desc("db_pool_max_conns",
+			"The maximum size of the pool"),
+		acquires: desc("db_pool_acquires_total",
+			"The total number of successful acquires of connections from the pool"),
+		emptyAcquires: desc("db_pool_empty_acquires_total",
+			"The total number of successful acquires that waited for a connection because the pool was empty"),
+		canceledAcquires: desc("db_pool_canceled_acquires_total",
+			"The total number of acquires canceled by context"),
+		acquireDuration: desc("db_pool_acquire_duration_seconds_total",
+			"The total time spent in successful acquires of connections from the pool"),
+		emptyAcquireWait: desc("db_pool_empty_acquire_wait_seconds_total",
+			"The total time spent waiting for a connection because the pool was empty"),
+	}
+}
+
+func (c poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquiredConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+	ch <- c.acquires
+	ch <- c.emptyAcquires
+	ch <- c.canceledAcquires
+	ch <- c.acquireDuration
+	ch <- c.emptyAcquireWait
+}
+
+func (c poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquires, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireWait, prometheus.CounterValue, stat.EmptyAcquireWaitTime().Seconds())
+}
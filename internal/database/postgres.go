@@ -3,21 +3,43 @@ package database
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"cmd/main.go/internal/config"
 	"cmd/main.go/internal/logger"
 
-	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
-const newPostgresLogTag = "NewPostgres"
+const (
+	newPostgresLogTag = "NewPostgres"
+	newPgxPoolLogTag  = "NewPgxPool"
+)
+
+// PgxPoolDriver is a value of database.driver that opens primary as pgxpool
+const PgxPoolDriver = "pgxpool"
 
-// StatementBuilder is a placeholder for queries
-var StatementBuilder = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+// pgxDriver - database/sql driver registered by pgx stdlib
+const pgxDriver = "pgx"
+
+// postgresDriver - former lib/pq driver name, configs that still use it are served by pgx stdlib
+const postgresDriver = "postgres"
+
+// statement cache modes of config.Pool
+const (
+	statementCacheModePrepare  = "prepare"
+	statementCacheModeDescribe = "describe"
+	statementCacheModeNone     = "none"
+)
 
 // NewPostgres returns DB
 func NewPostgres(ctx context.Context, dsn, driver string) (*sqlx.DB, error) {
-	db, err := sqlx.Open(driver, dsn)
+	db, err := sqlx.Open(sqlDriver(driver), dsn)
 	if err != nil {
 		logger.ErrorKV(ctx, fmt.Sprintf("%s: sqlx.Open failed to create database connection", newPostgresLogTag),
 			"error", err,
@@ -36,3 +58,109 @@ func NewPostgres(ctx context.Context, dsn, driver string) (*sqlx.DB, error) {
 
 	return db, nil
 }
+
+// NewPgxPool returns pool of connections to dsn tuned with cfg
+func NewPgxPool(ctx context.Context, dsn string, cfg config.Pool) (*pgxpool.Pool, error) {
+	poolCfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, errors.Wrap(err, "pgxpool.ParseConfig()")
+	}
+
+	if cfg.MaxConns > 0 {
+		poolCfg.MaxConns = cfg.MaxConns
+	}
+	if cfg.MinConns > 0 {
+		poolCfg.MinConns = cfg.MinConns
+	}
+	if cfg.MaxConnLifetime > 0 {
+		poolCfg.MaxConnLifetime = time.Duration(cfg.MaxConnLifetime) * time.Minute
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		poolCfg.MaxConnIdleTime = time.Duration(cfg.MaxConnIdleTime) * time.Minute
+	}
+	if cfg.HealthCheckPeriod > 0 {
+		poolCfg.HealthCheckPeriod = time.Duration(cfg.HealthCheckPeriod) * time.Second
+	}
+
+	switch cfg.StatementCacheMode {
+	case "":
+	case statementCacheModePrepare:
+		poolCfg.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheStatement
+	case statementCacheModeDescribe:
+		poolCfg.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheDescribe
+	case statementCacheModeNone:
+		// statements are not cached between queries, so connections can be shared by pgbouncer in transaction mode
+		poolCfg.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeExec
+	default:
+		return nil, errors.Errorf("unknown statement cache mode %q", cfg.StatementCacheMode)
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: pgxpool.NewWithConfig failed to create pool", newPgxPoolLogTag),
+			"error", err,
+		)
+
+		return nil, err
+	}
+
+	if err = pool.Ping(ctx); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: pool.Ping failed ping the database", newPgxPoolLogTag),
+			"error", err,
+		)
+		pool.Close()
+
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+// Open returns DB of dsn and function that closes it, with PgxPoolDriver the DB runs on pgxpool
+// whose statistics are exported as metrics labeled with name, SQLiteDriver opens a database file
+func Open(ctx context.Context, name, dsn, driver string, poolCfg config.Pool) (*sqlx.DB, func(), error) {
+	if driver != PgxPoolDriver {
+		db, err := NewPostgres(ctx, dsn, driver)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		//nolint
+		return db, func() { db.Close() }, nil
+	}
+
+	pool, err := NewPgxPool(ctx, dsn, poolCfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = prometheus.Register(NewPoolCollector(pool, name)); err != nil {
+		pool.Close()
+
+		return nil, nil, errors.Wrap(err, "prometheus.Register()")
+	}
+
+	db := NewDBFromPool(pool)
+
+	return db, func() {
+		//nolint
+		db.Close()
+		pool.Close()
+	}, nil
+}
+
+// NewDBFromPool returns DB that runs statements on connections acquired from pool,
+// closing DB does not close pool
+func NewDBFromPool(pool *pgxpool.Pool) *sqlx.DB {
+	return sqlx.NewDb(stdlib.OpenDBFromPool(pool), pgxDriver)
+}
+
+// sqlDriver - database/sql driver for driver from config, pgxpool is served by pgx stdlib
+// where a standalone database/sql connection is needed, e.g. for replicas and migrations
+func sqlDriver(driver string) string {
+	if driver == PgxPoolDriver || driver == postgresDriver {
+		return pgxDriver
+	}
+
+	return driver
+}
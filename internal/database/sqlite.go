@@ -0,0 +1,19 @@
+//go:build !no_sqlite3
+
+package database
+
+// importing the driver registers SQLiteDriver, it needs cgo, binaries built without it fail to open SQLite databases
+import (
+	"github.com/mattn/go-sqlite3"
+	"github.com/pkg/errors"
+)
+
+// isSQLiteBusy - check whether SQLite could not take a lock held by concurrent transaction
+func isSQLiteBusy(err error) bool {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+
+	return false
+}
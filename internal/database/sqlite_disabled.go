@@ -0,0 +1,8 @@
+//go:build no_sqlite3
+
+package database
+
+// isSQLiteBusy - there are no SQLite errors without the driver
+func isSQLiteBusy(error) bool {
+	return false
+}
@@ -0,0 +1,29 @@
+//go:build !no_sqlite3
+
+package database
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+func TestIsRetryableSQLite(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: sqlite3.Error{Code: sqlite3.ErrBusy}, want: true},
+		{err: fmt.Errorf("exec: %w", sqlite3.Error{Code: sqlite3.ErrLocked}), want: true},
+		{err: sqlite3.Error{Code: sqlite3.ErrConstraint}},
+		{err: errors.New("database is locked")},
+	}
+
+	for _, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.want {
+			t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Transactor runs functions in transaction of the underlying storage,
+// services depend on it instead of concrete database handle
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error
+}
+
+type sqlxTransactor struct {
+	dbs []*sqlx.DB
+}
+
+// NewTransactor returns Transactor for sqlx database. Joined databases begin transactions on first statement
+// of their repositories, so only touched ones take part in it. They are committed before db without two-phase
+// commit: a failed commit leaves changes of joined databases that were committed before it
+func NewTransactor(db *sqlx.DB, joined ...*sqlx.DB) Transactor {
+	return sqlxTransactor{dbs: append([]*sqlx.DB{db}, joined...)}
+}
+
+func (t sqlxTransactor) InTx(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error {
+	o := NewTxOptions(opts...)
+	if set, ok := ctx.Value(txKey{}).(*txSet); ok {
+		if err := set.checkJoined(o); err != nil {
+			return err
+		}
+
+		return fn(ctx)
+	}
+
+	return retry(ctx, o, func() error {
+		return runTx(ctx, t.dbs, o, fn)
+	})
+}
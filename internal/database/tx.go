@@ -2,57 +2,335 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"math/rand"
+	"sync"
+	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 )
 
-// WithTxFuncReturnUint64 is a function that should be run in transaction and returns uint64
-type WithTxFuncReturnUint64 func(ctx context.Context, tx *sqlx.Tx) (uint64, error)
+const (
+	// sqlStateSerializationFailure - transaction can not be serialized with concurrent ones
+	sqlStateSerializationFailure = "40001"
+	// sqlStateDeadlockDetected - transaction was chosen as a deadlock victim
+	sqlStateDeadlockDetected = "40P01"
 
-// WithTxFuncReturnBool is a function that should be run in transaction and returns bool
-type WithTxFuncReturnBool func(ctx context.Context, tx *sqlx.Tx) (bool, error)
+	defaultRetries         = 3
+	defaultRetryBackoff    = 10 * time.Millisecond
+	defaultRetryMaxBackoff = time.Second
+)
 
-// WithTxReturnUint64 transaction for WithTxFuncReturnUint64
-func WithTxReturnUint64(ctx context.Context, db *sqlx.DB, fn WithTxFuncReturnUint64) (uint64, error) {
-	t, err := db.BeginTxx(ctx, nil)
-	if err != nil {
-		return 0, errors.Wrap(err, "db.BeginTxx()")
+// ErrTxOptionsConflict is a "nested transaction needs stronger options than the outer one" error
+var ErrTxOptionsConflict = errors.New("options of nested transaction conflict with outer transaction")
+
+// TxFunc is a function that should be run in transaction, the transaction is available from ctx
+type TxFunc[T any] func(ctx context.Context) (T, error)
+
+// TxOptions - options of transaction, build them with TxOption functions
+type TxOptions struct {
+	Isolation  sql.IsolationLevel
+	ReadOnly   bool
+	Retries    int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// TxOption configures transaction
+type TxOption func(o *TxOptions)
+
+// WithIsolation - run transaction with isolation level, default level of the database is used otherwise
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(o *TxOptions) {
+		o.Isolation = level
 	}
+}
 
-	var result uint64
-	if result, err = fn(ctx, t); err != nil {
-		if errRollback := t.Rollback(); errRollback != nil {
-			return 0, errors.Wrap(err, "Tx.Rollback")
-		}
-		return 0, errors.Wrap(err, "Tx.WithTxFunc")
+// ReadOnly - run read only transaction
+func ReadOnly() TxOption {
+	return func(o *TxOptions) {
+		o.ReadOnly = true
+	}
+}
+
+// WithRetries - rerun the whole function up to retries times when transaction fails
+// with serialization failure or deadlock, backoff doubles after every attempt and has jitter
+func WithRetries(retries int, backoff time.Duration) TxOption {
+	return func(o *TxOptions) {
+		o.Retries = retries
+		o.Backoff = backoff
 	}
+}
+
+// RetryOnConflict - rerun the whole function with default number of retries and backoff,
+// functions that only change the database use it
+func RetryOnConflict() TxOption {
+	return WithRetries(defaultRetries, defaultRetryBackoff)
+}
+
+// NoRetries - run function once, functions with effects outside of the database
+// (written streams, consumed readers) use it, so they are not repeated
+func NoRetries() TxOption {
+	return WithRetries(0, defaultRetryBackoff)
+}
+
+// NewTxOptions returns options with defaults applied, transactions are not retried by default,
+// so call sites state their retry policy with RetryOnConflict, WithRetries or NoRetries
+func NewTxOptions(opts ...TxOption) TxOptions {
+	o := TxOptions{
+		Backoff:    defaultRetryBackoff,
+		MaxBackoff: defaultRetryMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithTx - run fn in transaction of transactor and return its result.
+// When ctx already carries a transaction fn joins it: retries are left to the outer transaction
+// and isolation or read-write access it can not provide fail with ErrTxOptionsConflict
+func WithTx[T any](ctx context.Context, transactor Transactor, fn TxFunc[T], opts ...TxOption) (T, error) {
+	var result T
+	err := transactor.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = fn(ctx)
 
-	if err = t.Commit(); err != nil {
-		return 0, errors.Wrap(err, "Tx.Commit")
+		return err
+	}, opts...)
+	if err != nil {
+		var zero T
+		return zero, err
 	}
 
 	return result, nil
 }
 
-// WithTxReturnBool transaction for WithTxFuncReturnBool
-func WithTxReturnBool(ctx context.Context, db *sqlx.DB, fn WithTxFuncReturnBool) (bool, error) {
-	t, err := db.BeginTxx(ctx, nil)
+type txKey struct{}
+
+// txSet - transaction of transactor database and transactions of databases joined to it,
+// joined databases begin their transactions on first statement, so only touched ones take part
+type txSet struct {
+	ctx    context.Context
+	opts   *sql.TxOptions
+	mainDB *sqlx.DB
+	main   *sqlx.Tx
+
+	// repositories of different shards run statements concurrently
+	mu sync.Mutex
+	// joined - transactions of joined databases, nil until the first statement
+	joined map[*sqlx.DB]*sqlx.Tx
+	// begun - transactions of joined databases in order of begin
+	begun []*sqlx.Tx
+	// err - failed begin of joined database, the whole transaction fails with it
+	err error
+}
+
+// txFor returns transaction of db, transaction of joined database begins on the first call,
+// databases that are not joined have no transaction
+func (s *txSet) txFor(db *sqlx.DB) (*sqlx.Tx, error) {
+	if db == s.mainDB {
+		return s.main, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tx, ok := s.joined[db]
+	if !ok || tx != nil {
+		return tx, nil
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	tx, err := db.BeginTxx(s.ctx, s.opts)
 	if err != nil {
-		return false, errors.Wrap(err, "db.BeginTxx()")
+		s.err = errors.Wrap(err, "db.BeginTxx()")
+
+		return nil, s.err
 	}
+	s.joined[db] = tx
+	s.begun = append(s.begun, tx)
+
+	return tx, nil
+}
+
+// txs returns main transaction followed by begun transactions of joined databases
+func (s *txSet) txs() []*sqlx.Tx {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]*sqlx.Tx{s.main}, s.begun...)
+}
+
+// TxFromContext returns transaction started by WithTx on database of transactor or nil
+func TxFromContext(ctx context.Context) *sqlx.Tx {
+	set, ok := ctx.Value(txKey{}).(*txSet)
+	if !ok {
+		return nil
+	}
+
+	return set.main
+}
+
+// TxFor returns transaction of db from ctx or nil, db is either database of transactor or one joined to it.
+// Transaction of joined database begins here on first use, when it fails nil is returned and the whole
+// transaction fails on commit, callers that must not run statements outside of it call JoinTx first
+func TxFor(ctx context.Context, db *sqlx.DB) *sqlx.Tx {
+	set, ok := ctx.Value(txKey{}).(*txSet)
+	if !ok {
+		return nil
+	}
+
+	tx, _ := set.txFor(db)
 
-	var result bool
-	if result, err = fn(ctx, t); err != nil {
-		if errRollback := t.Rollback(); errRollback != nil {
-			return false, errors.Wrap(err, "Tx.Rollback")
+	return tx
+}
+
+// JoinTx - begin transaction of db when it is joined to transaction from ctx and has not begun yet,
+// it does nothing without transaction or for databases that are not joined
+func JoinTx(ctx context.Context, db *sqlx.DB) error {
+	set, ok := ctx.Value(txKey{}).(*txSet)
+	if !ok {
+		return nil
+	}
+
+	_, err := set.txFor(db)
+
+	return err
+}
+
+// Queryer returns transaction of db from ctx or db when there is no transaction,
+// repositories run all statements through it
+func Queryer(ctx context.Context, db *sqlx.DB) sqlx.ExtContext {
+	if tx := TxFor(ctx, db); tx != nil {
+		return tx
+	}
+
+	return db
+}
+
+// IsRetryable - check whether error is a serialization failure or deadlock of Postgres
+// or a busy database of SQLite, so transaction can be rerun.
+// Transactions of memory store hold its lock, they never conflict
+func IsRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == sqlStateSerializationFailure || pgErr.Code == sqlStateDeadlockDetected
+	}
+
+	return isSQLiteBusy(err)
+}
+
+// checkJoined - check that transaction of the set satisfies options of nested one
+func (s *txSet) checkJoined(o TxOptions) error {
+	if s.opts.ReadOnly && !o.ReadOnly {
+		return errors.Wrap(ErrTxOptionsConflict, "outer transaction is read only")
+	}
+	if isolation := s.isolation(); o.Isolation != sql.LevelDefault && o.Isolation > isolation {
+		return errors.Wrapf(ErrTxOptionsConflict, "isolation %s is requested in %s transaction", o.Isolation, isolation)
+	}
+
+	return nil
+}
+
+// isolation - level of transaction of the set, default level is read committed in Postgres,
+// SQLite transactions are always serializable
+func (s *txSet) isolation() sql.IsolationLevel {
+	switch {
+	case DialectOf(s.mainDB) == SQLite:
+		return sql.LevelSerializable
+	case s.opts.Isolation == sql.LevelDefault:
+		return sql.LevelReadCommitted
+	default:
+		return s.opts.Isolation
+	}
+}
+
+// runTx - begin transaction on the first of dbs, run fn and commit one attempt. Other dbs are joined,
+// their transactions begin when fn touches them and are committed before the main one in reverse order,
+// there is no two-phase commit, so a failed commit leaves changes that were committed before it
+func runTx(ctx context.Context, dbs []*sqlx.DB, o TxOptions, fn func(ctx context.Context) error) error {
+	start := time.Now()
+
+	opts := &sql.TxOptions{Isolation: o.Isolation, ReadOnly: o.ReadOnly}
+	main, err := dbs[0].BeginTxx(ctx, opts)
+	if err != nil {
+		observeTx(txBeginFailed, start)
+		return errors.Wrap(err, "db.BeginTxx()")
+	}
+
+	set := &txSet{
+		ctx:    ctx,
+		opts:   opts,
+		mainDB: dbs[0],
+		main:   main,
+		joined: make(map[*sqlx.DB]*sqlx.Tx, len(dbs)-1),
+	}
+	for _, db := range dbs[1:] {
+		set.joined[db] = nil
+	}
+
+	ctx, hooks := WithCommitHooks(ctx)
+	err = fn(context.WithValue(ctx, txKey{}, set))
+	txs := set.txs()
+	if err == nil && set.err != nil {
+		// statements of fn ran outside of transaction that failed to begin
+		err = set.err
+	}
+	if err != nil {
+		observeTx(txRolledBack, start)
+		return rollback(txs, err)
+	}
+
+	for i := len(txs) - 1; i >= 0; i-- {
+		if err := txs[i].Commit(); err != nil {
+			observeTx(txCommitFail, start)
+			return rollback(txs[:i], errors.Wrap(err, "Tx.Commit"))
 		}
-		return false, errors.Wrap(err, "Tx.WithTxFunc")
 	}
+	observeTx(txCommitted, start)
+	hooks.Run()
 
-	if err = t.Commit(); err != nil {
-		return false, errors.Wrap(err, "Tx.Commit")
+	return nil
+}
+
+// rollback - roll back txs after err
+func rollback(txs []*sqlx.Tx, err error) error {
+	for _, tx := range txs {
+		if errRollback := tx.Rollback(); errRollback != nil && !errors.Is(errRollback, sql.ErrTxDone) {
+			err = errors.Wrapf(err, "Tx.Rollback failed: %v", errRollback)
+		}
 	}
 
-	return result, nil
+	return err
+}
+
+// retry - run attempt until it succeeds, fails with not retryable error or retries are exhausted
+func retry(ctx context.Context, o TxOptions, attempt func() error) error {
+	backoff := o.Backoff
+	for i := 0; ; i++ {
+		err := attempt()
+		if err == nil || i >= o.Retries || !IsRetryable(err) {
+			return err
+		}
+
+		// full jitter spreads concurrent retries of conflicting transactions
+		//nolint:gosec
+		sleep := time.Duration(rand.Int63n(int64(backoff) + 1))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(sleep):
+		}
+		txRetries.Inc()
+
+		if backoff *= 2; backoff > o.MaxBackoff {
+			backoff = o.MaxBackoff
+		}
+	}
 }
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestRetry(t *testing.T) {
+	serialization := &pgconn.PgError{Code: sqlStateSerializationFailure}
+	deadlock := &pgconn.PgError{Code: sqlStateDeadlockDetected}
+	unique := &pgconn.PgError{Code: "23505"}
+
+	tests := []struct {
+		name     string
+		opts     []TxOption
+		errs     []error
+		attempts int
+		wantErr  error
+	}{
+		{
+			name:     "not retried by default",
+			errs:     []error{serialization},
+			attempts: 1,
+			wantErr:  serialization,
+		},
+		{
+			name:     "retried until success",
+			opts:     []TxOption{RetryOnConflict()},
+			errs:     []error{serialization, deadlock},
+			attempts: 3,
+		},
+		{
+			name:     "retries exhausted",
+			opts:     []TxOption{WithRetries(1, time.Millisecond)},
+			errs:     []error{serialization, deadlock, serialization},
+			attempts: 2,
+			wantErr:  deadlock,
+		},
+		{
+			name:     "not retryable error",
+			opts:     []TxOption{RetryOnConflict()},
+			errs:     []error{unique},
+			attempts: 1,
+			wantErr:  unique,
+		},
+		{
+			name:     "no retries",
+			opts:     []TxOption{NoRetries()},
+			errs:     []error{serialization},
+			attempts: 1,
+			wantErr:  serialization,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewTxOptions(tt.opts...)
+			o.Backoff = time.Millisecond
+
+			var attempts int
+			err := retry(context.Background(), o, func() error {
+				attempts++
+				if attempts <= len(tt.errs) {
+					return tt.errs[attempts-1]
+				}
+
+				return nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("retry() error = %v, want %v", err, tt.wantErr)
+			}
+			if attempts != tt.attempts {
+				t.Errorf("retry() attempts = %d, want %d", attempts, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestCheckJoined(t *testing.T) {
+	postgres := sqlx.NewDb(nil, "pgx")
+	sqlite := sqlx.NewDb(nil, SQLiteDriver)
+
+	tests := []struct {
+		name     string
+		db       *sqlx.DB
+		outer    sql.TxOptions
+		opts     []TxOption
+		conflict bool
+	}{
+		{
+			name: "default options",
+			db:   postgres,
+		},
+		{
+			name: "read only in read write",
+			db:   postgres,
+			opts: []TxOption{ReadOnly()},
+		},
+		{
+			name:     "read write in read only",
+			db:       postgres,
+			outer:    sql.TxOptions{ReadOnly: true},
+			conflict: true,
+		},
+		{
+			name:  "weaker isolation",
+			db:    postgres,
+			outer: sql.TxOptions{Isolation: sql.LevelRepeatableRead},
+			opts:  []TxOption{WithIsolation(sql.LevelReadCommitted)},
+		},
+		{
+			name:     "stronger isolation",
+			db:       postgres,
+			outer:    sql.TxOptions{Isolation: sql.LevelRepeatableRead},
+			opts:     []TxOption{WithIsolation(sql.LevelSerializable)},
+			conflict: true,
+		},
+		{
+			name:     "stronger than default isolation",
+			db:       postgres,
+			opts:     []TxOption{WithIsolation(sql.LevelRepeatableRead)},
+			conflict: true,
+		},
+		{
+			name: "sqlite is serializable",
+			db:   sqlite,
+			opts: []TxOption{WithIsolation(sql.LevelSerializable)},
+		},
+		{
+			name: "retries are left to outer transaction",
+			db:   postgres,
+			opts: []TxOption{RetryOnConflict()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outer := tt.outer
+			set := &txSet{mainDB: tt.db, opts: &outer}
+
+			err := set.checkJoined(NewTxOptions(tt.opts...))
+			if tt.conflict != errors.Is(err, ErrTxOptionsConflict) {
+				t.Errorf("checkJoined() error = %v, want conflict %v", err, tt.conflict)
+			}
+		})
+	}
+}
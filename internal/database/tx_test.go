@@ -0,0 +1,117 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/database/dbtest"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func TestTransactorJoined(t *testing.T) {
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name string
+		fail bool
+		// touched - joined databases that get statements
+		touched []int
+		// open - connections in use by joined databases while fn runs
+		open []int
+		// rows - rows of joined databases after transaction
+		rows []int
+	}{
+		{
+			name:    "untouched databases do not begin",
+			touched: []int{1},
+			open:    []int{0, 1},
+			rows:    []int{0, 1},
+		},
+		{
+			name:    "all touched",
+			touched: []int{0, 1},
+			open:    []int{1, 1},
+			rows:    []int{1, 1},
+		},
+		{
+			name:    "rolled back",
+			fail:    true,
+			touched: []int{0},
+			open:    []int{1, 0},
+			rows:    []int{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			main := dbtest.SQLite(t)
+			joined := []*sqlx.DB{dbtest.SQLite(t), dbtest.SQLite(t)}
+			for _, db := range append([]*sqlx.DB{main}, joined...) {
+				if _, err := db.ExecContext(ctx, "CREATE TABLE items (id INTEGER)"); err != nil {
+					t.Fatalf("create table: %v", err)
+				}
+			}
+
+			transactor := database.NewTransactor(main, joined...)
+			err := transactor.InTx(ctx, func(ctx context.Context) error {
+				for _, i := range tt.touched {
+					if err := database.JoinTx(ctx, joined[i]); err != nil {
+						return err
+					}
+					if _, err := database.Queryer(ctx, joined[i]).ExecContext(ctx, "INSERT INTO items (id) VALUES (1)"); err != nil {
+						return err
+					}
+				}
+				for i, db := range joined {
+					if got := db.Stats().InUse; got != tt.open[i] {
+						t.Errorf("joined[%d] connections in use = %d, want %d", i, got, tt.open[i])
+					}
+				}
+				if tt.fail {
+					return errFailed
+				}
+
+				return nil
+			})
+			if tt.fail != errors.Is(err, errFailed) {
+				t.Fatalf("InTx() error = %v", err)
+			}
+
+			for i, db := range joined {
+				if got := db.Stats().InUse; got != 0 {
+					t.Errorf("joined[%d] connections in use after transaction = %d", i, got)
+				}
+
+				var rows int
+				if err := db.GetContext(ctx, &rows, "SELECT COUNT(*) FROM items"); err != nil {
+					t.Fatalf("count items: %v", err)
+				}
+				if rows != tt.rows[i] {
+					t.Errorf("joined[%d] rows = %d, want %d", i, rows, tt.rows[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTxForNotJoined(t *testing.T) {
+	main, other := dbtest.SQLite(t), dbtest.SQLite(t)
+
+	err := database.NewTransactor(main).InTx(context.Background(), func(ctx context.Context) error {
+		if database.TxFor(ctx, main) == nil {
+			t.Error("TxFor(main) = nil, want transaction")
+		}
+		if database.TxFor(ctx, other) != nil {
+			t.Error("TxFor(other) returned transaction of database that is not joined")
+		}
+
+		return database.JoinTx(ctx, other)
+	})
+	if err != nil {
+		t.Fatalf("InTx() error = %v", err)
+	}
+}
@@ -14,3 +14,12 @@ func ConvertPbTimeToNullableTime(pbTime *timestamppb.Timestamp) sql.NullTime {
 
 	return nullableTime
 }
+
+// ConvertNullableTimeToPb - convert sql.NullTime to timestamppb.Timestamp, null time is returned as nil
+func ConvertNullableTimeToPb(nullableTime sql.NullTime) *timestamppb.Timestamp {
+	if !nullableTime.Valid {
+		return nil
+	}
+
+	return timestamppb.New(nullableTime.Time)
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"database/sql/driver"
+	"time"
+
+	desc "cmd/main.go/pkg/my-api"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// SignalEmailEqual - normalized emails are equal
+	SignalEmailEqual = "email_equal"
+	// SignalEmailLocalSimilar - local parts of emails are similar
+	SignalEmailLocalSimilar = "email_local_similar"
+	// SignalNameSimilar - names are similar
+	SignalNameSimilar = "name_similar"
+)
+
+// DuplicatePair is a pair of users found by analyzer with raw similarity signals
+type DuplicatePair struct {
+	UserA                uint64  `db:"id_user_a"`
+	UserB                uint64  `db:"id_user_b"`
+	EmailEqual           bool    `db:"email_equal"`
+	EmailLocalSimilarity float64 `db:"email_local_similarity"`
+	NameSimilarity       float64 `db:"name_similarity"`
+}
+
+// DuplicateCandidate is a scored pair of likely duplicate users waiting for review
+type DuplicateCandidate struct {
+	UserA      uint64    `db:"id_user_a"`
+	UserB      uint64    `db:"id_user_b"`
+	Score      float64   `db:"score"`
+	Signals    Signals   `db:"signals"`
+	DetectedAt time.Time `db:"detected_at"`
+}
+
+// Signals is a list of signal names stored as text[], SQLite keeps the same array literal in text column
+type Signals []string
+
+// Value - convert Signals to text[] literal
+func (s Signals) Value() (driver.Value, error) {
+	if s == nil {
+		s = Signals{}
+	}
+
+	data, err := pgtype.NewMap().Encode(pgtype.TextArrayOID, pgtype.TextFormatCode, []string(s), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan - convert text[] value to Signals
+func (s *Signals) Scan(src interface{}) error {
+	var result []string
+	if err := pgtype.NewMap().SQLScanner(&result).Scan(src); err != nil {
+		return err
+	}
+	*s = result
+
+	return nil
+}
+
+// ConvertRepeatedDuplicateCandidatesToPb - convert slice of DuplicateCandidate to slice of protobuf messages
+func ConvertRepeatedDuplicateCandidatesToPb(candidates []DuplicateCandidate) []*desc.DuplicateCandidate {
+	candidatesPb := make([]*desc.DuplicateCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		candidatesPb = append(candidatesPb, &desc.DuplicateCandidate{
+			IdUserA:    c.UserA,
+			IdUserB:    c.UserB,
+			Score:      c.Score,
+			Signals:    c.Signals,
+			DetectedAt: timestamppb.New(c.DetectedAt),
+		})
+	}
+
+	return candidatesPb
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// UserErased - personal data of users was replaced with tombstones, consumers must scrub their copies
+	UserErased EventType = "erased"
+)
+
+// PersonalPayloadKeys - keys of event payloads that may hold personal data, they are removed by erasure
+var PersonalPayloadKeys = []string{"name", "email", "labels", "profile"}
+
+// ErasureSummary is what erasure did, it holds ids and counts only
+type ErasureSummary struct {
+	// Users - ids of erased users: the requested one and users merged into it
+	Users []uint64 `json:"users"`
+	// Rows - number of scrubbed rows by table
+	Rows map[string]int64 `json:"rows"`
+}
+
+// ErasureReceipt is a record of erasure of user kept as a proof after personal data is gone
+type ErasureReceipt struct {
+	ID       uint64         `db:"id"`
+	UserID   uint64         `db:"id_user"`
+	Reason   string         `db:"reason"`
+	Summary  ErasureSummary `db:"summary"`
+	ErasedAt time.Time      `db:"erased_at"`
+}
+
+// Value - convert ErasureSummary to jsonb value
+func (s ErasureSummary) Value() (driver.Value, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan - convert jsonb value to ErasureSummary
+func (s *ErasureSummary) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*s = ErasureSummary{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unable to scan %T into ErasureSummary", src)
+	}
+
+	result := ErasureSummary{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	*s = result
+
+	return nil
+}
+
+// ConvertErasureReceiptToPb - convert ErasureReceipt to protobuf ErasureReceipt message
+func ConvertErasureReceiptToPb(receipt *ErasureReceipt) *desc.ErasureReceipt {
+	return &desc.ErasureReceipt{
+		Id:       receipt.ID,
+		IdUser:   receipt.UserID,
+		Erased:   receipt.Summary.Users,
+		Rows:     receipt.Summary.Rows,
+		Reason:   receipt.Reason,
+		ErasedAt: timestamppb.New(receipt.ErasedAt),
+	}
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EventType is a type of user event
+type EventType string
+
+const (
+	// UserMerged - losers were merged into survivor, the event is stored under survivor and its payload
+	// holds ids only, so erasure of a loser leaves no personal data of it in events of other users
+	UserMerged EventType = "merged"
+)
+
+// EventPayload is a payload of user event stored as jsonb
+type EventPayload map[string]interface{}
+
+// UserEvent is an event about user written to events table in the same transaction as the change
+type UserEvent struct {
+	ID        uint64       `db:"id"`
+	UserID    uint64       `db:"id_user"`
+	Type      EventType    `db:"type"`
+	Payload   EventPayload `db:"payload"`
+	CreatedAt time.Time    `db:"created_at"`
+}
+
+// Value - convert EventPayload to jsonb value
+func (p EventPayload) Value() (driver.Value, error) {
+	if p == nil {
+		return "{}", nil
+	}
+
+	data, err := json.Marshal(map[string]interface{}(p))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan - convert jsonb value to EventPayload
+func (p *EventPayload) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*p = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unable to scan %T into EventPayload", src)
+	}
+
+	result := EventPayload{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	*p = result
+
+	return nil
+}
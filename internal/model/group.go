@@ -0,0 +1,16 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Group is a named set of users
+type Group struct {
+	ID_group    uint64       `db:"id_group"`
+	Name        string       `db:"name"`
+	Description string       `db:"description"`
+	CreatedAt   time.Time    `db:"created_at"`
+	UpdatedAt   sql.NullTime `db:"updated_at"`
+	DeletedAt   sql.NullTime `db:"deleted_at"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ConvertGroupToPb - convert Group to protobuf Group message
+func ConvertGroupToPb(group *Group) *desc.Group {
+	return &desc.Group{
+		Id:          group.ID_group,
+		Name:        group.Name,
+		Description: group.Description,
+		CreatedAt:   timestamppb.New(group.CreatedAt),
+		UpdatedAt:   timestamppb.New(group.UpdatedAt.Time),
+		DeletedAt:   timestamppb.New(group.DeletedAt.Time),
+	}
+}
+
+// ConvertRepeatedGroupsToPb - convert slice of Group to slice of protobuf Group messages
+func ConvertRepeatedGroupsToPb(groups []Group) []*desc.Group {
+	groupsPb := make([]*desc.Group, 0, len(groups))
+	for i := range groups {
+		groupsPb = append(groupsPb, ConvertGroupToPb(&groups[i]))
+	}
+
+	return groupsPb
+}
@@ -0,0 +1,62 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Labels is a set of user labels stored as jsonb
+type Labels map[string]string
+
+// Value - convert Labels to jsonb value
+func (l Labels) Value() (driver.Value, error) {
+	if l == nil {
+		return "{}", nil
+	}
+
+	data, err := json.Marshal(map[string]string(l))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan - convert jsonb value to Labels
+func (l *Labels) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unable to scan %T into Labels", src)
+	}
+
+	result := Labels{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	*l = result
+
+	return nil
+}
+
+// Clone returns copy of labels, nil stays nil
+func (l Labels) Clone() Labels {
+	if l == nil {
+		return nil
+	}
+
+	result := make(Labels, len(l))
+	for k, v := range l {
+		result[k] = v
+	}
+
+	return result
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	desc "cmd/main.go/pkg/my-api"
+)
+
+// MergePolicy is a rule of choosing field value when users are merged
+type MergePolicy int
+
+const (
+	// MergeKeepSurvivor - keep value of survivor
+	MergeKeepSurvivor MergePolicy = iota
+	// MergeKeepNewest - take value of most recently updated user
+	MergeKeepNewest
+	// MergeKeepNonEmpty - keep value of survivor if it is not empty, otherwise take newest non-empty value
+	MergeKeepNonEmpty
+)
+
+// MergePolicies contains merge policy for every mergeable field
+type MergePolicies struct {
+	Name    MergePolicy
+	Email   MergePolicy
+	Labels  MergePolicy
+	Profile MergePolicy
+}
+
+// ConvertPbToMergePolicies - convert protobuf MergePolicies message to MergePolicies
+func ConvertPbToMergePolicies(policies *desc.MergePolicies) MergePolicies {
+	return MergePolicies{
+		Name:    convertPbToMergePolicy(policies.GetName()),
+		Email:   convertPbToMergePolicy(policies.GetEmail()),
+		Labels:  convertPbToMergePolicy(policies.GetLabels()),
+		Profile: convertPbToMergePolicy(policies.GetProfile()),
+	}
+}
+
+func convertPbToMergePolicy(policy desc.MergePolicy) MergePolicy {
+	switch policy {
+	case desc.MergePolicy_MERGE_POLICY_KEEP_NEWEST:
+		return MergeKeepNewest
+	case desc.MergePolicy_MERGE_POLICY_KEEP_NON_EMPTY:
+		return MergeKeepNonEmpty
+	default:
+		return MergeKeepSurvivor
+	}
+}
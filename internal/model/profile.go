@@ -0,0 +1,79 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Profile is a set of extended user fields stored as jsonb, its shape is defined by JSON Schema from config
+type Profile map[string]interface{}
+
+// Value - convert Profile to jsonb value
+func (p Profile) Value() (driver.Value, error) {
+	if p == nil {
+		return "{}", nil
+	}
+
+	data, err := json.Marshal(map[string]interface{}(p))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// Scan - convert jsonb value to Profile
+func (p *Profile) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*p = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unable to scan %T into Profile", src)
+	}
+
+	result := Profile{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+	*p = result
+
+	return nil
+}
+
+// Clone returns deep copy of profile, nil stays nil
+func (p Profile) Clone() Profile {
+	if p == nil {
+		return nil
+	}
+
+	return cloneJSONValue(map[string]interface{}(p)).(map[string]interface{})
+}
+
+// cloneJSONValue - deep copy of json-like value
+func cloneJSONValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			result[k] = cloneJSONValue(item)
+		}
+
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = cloneJSONValue(item)
+		}
+
+		return result
+	default:
+		return v
+	}
+}
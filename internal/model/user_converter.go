@@ -5,6 +5,7 @@ import (
 
 	desc "cmd/main.go/pkg/my-api"
 
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -15,6 +16,10 @@ var (
 
 // ConvertUserRequestToPb - convert UserRequest to protobuf UserRequest message
 func ConvertUserRequestToPb(userRequest *UserRequest) (*desc.CreateUserRequest, error) {
+	profile, err := ConvertProfileToPb(userRequest.Profile)
+	if err != nil {
+		return nil, err
+	}
 
 	return &desc.CreateUserRequest{
 		IdUser:    userRequest.ID_user,
@@ -24,6 +29,8 @@ func ConvertUserRequestToPb(userRequest *UserRequest) (*desc.CreateUserRequest,
 		UpdatedAt: timestamppb.New(userRequest.UpdatedAt.Time),
 		DoneAt:    timestamppb.New(userRequest.DoneAt.Time),
 		DeletedAt: timestamppb.New(userRequest.DeletedAt.Time),
+		Labels:    userRequest.Labels,
+		Profile:   profile,
 	}, nil
 }
 
@@ -53,18 +60,37 @@ func ConvertPbToUserRequest(userRequest *desc.CreateUserRequest) (*UserRequest,
 		UpdatedAt: ConvertPbTimeToNullableTime(userRequest.UpdatedAt),
 		DoneAt:    ConvertPbTimeToNullableTime(userRequest.DoneAt),
 		DeletedAt: ConvertPbTimeToNullableTime(userRequest.DeletedAt),
+		Labels:    userRequest.Labels,
+		Profile:   userRequest.Profile.AsMap(),
 	}, nil
 }
 
 func ConvertUserToPb(userRequest *UserRequest) (*desc.User, error) {
+	profile, err := ConvertProfileToPb(userRequest.Profile)
+	if err != nil {
+		return nil, err
+	}
 
 	return &desc.User{
-		Id:        userRequest.ID_user,
-		Name:      userRequest.Name,
-		Email:     userRequest.Email,
-		CreatedAt: timestamppb.New(userRequest.CreatedAt),
-		UpdatedAt: timestamppb.New(userRequest.UpdatedAt.Time),
-		DoneAt:    timestamppb.New(userRequest.DoneAt.Time),
-		DeletedAt: timestamppb.New(userRequest.DeletedAt.Time),
+		Id:         userRequest.ID_user,
+		Name:       userRequest.Name,
+		Email:      userRequest.Email,
+		CreatedAt:  timestamppb.New(userRequest.CreatedAt),
+		UpdatedAt:  timestamppb.New(userRequest.UpdatedAt.Time),
+		DoneAt:     timestamppb.New(userRequest.DoneAt.Time),
+		DeletedAt:  timestamppb.New(userRequest.DeletedAt.Time),
+		Labels:     userRequest.Labels,
+		Profile:    profile,
+		MergedInto: uint64(userRequest.MergedInto.Int64),
+		ErasedAt:   ConvertNullableTimeToPb(userRequest.ErasedAt),
 	}, nil
 }
+
+// ConvertProfileToPb - convert Profile to protobuf Struct, empty profile is returned as nil
+func ConvertProfileToPb(profile Profile) (*structpb.Struct, error) {
+	if len(profile) == 0 {
+		return nil, nil
+	}
+
+	return structpb.NewStruct(profile)
+}
@@ -7,11 +7,24 @@ import (
 
 // UserRequest is a request for equipment
 type UserRequest struct {
-	ID_user   uint64       `db:"id_user"`
-	Name      string       `db:"name"`
-	Email     string       `db:"email"`
-	CreatedAt time.Time    `db:"created_at"`
-	UpdatedAt sql.NullTime `db:"updated_at"`
-	DeletedAt sql.NullTime `db:"deleted_at"`
-	DoneAt    sql.NullTime `db:"done_at"`
+	ID_user         uint64         `db:"id_user"`
+	Name            string         `db:"name"`
+	Email           string         `db:"email"`
+	CreatedAt       time.Time      `db:"created_at"`
+	UpdatedAt       sql.NullTime   `db:"updated_at"`
+	DeletedAt       sql.NullTime   `db:"deleted_at"`
+	DoneAt          sql.NullTime   `db:"done_at"`
+	Labels          Labels         `db:"labels"`
+	Profile         Profile        `db:"profile"`
+	MergedInto      sql.NullInt64  `db:"merged_into"`
+	EmailBlindIndex sql.NullString `db:"email_bidx"`
+	ErasedAt        sql.NullTime   `db:"erased_at"`
+}
+
+// Clone returns copy of user that does not share labels and profile with the original
+func (u UserRequest) Clone() UserRequest {
+	u.Labels = u.Labels.Clone()
+	u.Profile = u.Profile.Clone()
+
+	return u
 }
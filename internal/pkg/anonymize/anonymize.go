@@ -0,0 +1,210 @@
+// Package anonymize replaces personal data of users with deterministic fakes, so dumps of production
+// can be loaded into staging. Fakes are derived from keyed hashes of original values: within one seed
+// equal values get equal fakes, so duplicates, shares of empty values and relations between rows survive,
+// while original values can not be recovered without the seed.
+package anonymize
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"sort"
+	"strings"
+
+	"cmd/main.go/internal/model"
+
+	"github.com/pkg/errors"
+)
+
+// Strategy is a way of anonymizing values of one column
+type Strategy string
+
+const (
+	// Keep - value is exported as is
+	Keep Strategy = "keep"
+	// FakeName - value is replaced with fake name with the same number of words
+	FakeName Strategy = "fake_name"
+	// FakeEmail - local part and domain are replaced with fake ones, "+tag" is kept as fake tag
+	FakeEmail Strategy = "fake_email"
+	// Hash - value is replaced with hex of its keyed hash
+	Hash Strategy = "hash"
+	// Empty - value is replaced with empty string, empty JSON object or null
+	Empty Strategy = "empty"
+)
+
+// Columns - columns of users in order they are exported
+var Columns = []string{
+	"id_user",
+	"name",
+	"email",
+	"created_at",
+	"updated_at",
+	"deleted_at",
+	"done_at",
+	"labels",
+	"profile",
+	"merged_into",
+	"email_bidx",
+	"erased_at",
+}
+
+// allowed - strategies supported by column, ids and created_at are always kept
+// so relations between rows and their distribution in time are preserved
+var allowed = map[string][]Strategy{
+	"id_user":     {Keep},
+	"name":        {Keep, FakeName, Hash, Empty},
+	"email":       {Keep, FakeEmail, Hash, Empty},
+	"created_at":  {Keep},
+	"updated_at":  {Keep, Empty},
+	"deleted_at":  {Keep, Empty},
+	"done_at":     {Keep, Empty},
+	"labels":      {Keep, Empty},
+	"profile":     {Keep, Empty},
+	"merged_into": {Keep},
+	"email_bidx":  {Keep, Empty},
+	"erased_at":   {Keep, Empty},
+}
+
+// Rules - strategy by column name
+type Rules map[string]Strategy
+
+// DefaultRules - strategies of columns missing from rules passed to New, other columns are kept.
+// Profile is free-form and blind index is computed with production key, so both are dropped
+var DefaultRules = Rules{
+	"name":       FakeName,
+	"email":      FakeEmail,
+	"profile":    Empty,
+	"email_bidx": Empty,
+}
+
+// ErrNoSeed is a "fakes would not depend on a secret" error
+var ErrNoSeed = errors.New("seed of anonymization is required")
+
+// Anonymizer replaces values of user columns by rules
+type Anonymizer struct {
+	seed  []byte
+	rules Rules
+}
+
+// New returns Anonymizer with rules on top of DefaultRules, seed keys hashes of values
+func New(seed string, rules Rules) (*Anonymizer, error) {
+	if seed == "" {
+		return nil, ErrNoSeed
+	}
+
+	merged := make(Rules, len(Columns))
+	for _, column := range Columns {
+		merged[column] = Keep
+	}
+	for column, strategy := range DefaultRules {
+		merged[column] = strategy
+	}
+
+	for column, strategy := range rules {
+		supported, ok := allowed[column]
+		if !ok {
+			return nil, errors.Errorf("unknown column %q", column)
+		}
+		if !hasStrategy(supported, strategy) {
+			return nil, errors.Errorf("column %q does not support strategy %q", column, strategy)
+		}
+		merged[column] = strategy
+	}
+
+	return &Anonymizer{
+		seed:  []byte(seed),
+		rules: merged,
+	}, nil
+}
+
+// ParseRules - convert strategy names by column, e.g. of config.Anonymize, to Rules
+func ParseRules(columns map[string]string) Rules {
+	rules := make(Rules, len(columns))
+	for column, strategy := range columns {
+		rules[column] = Strategy(strings.TrimSpace(strategy))
+	}
+
+	return rules
+}
+
+// Rules returns strategies of every column sorted by column name
+func (a *Anonymizer) Rules() []string {
+	result := make([]string, 0, len(a.rules))
+	for column, strategy := range a.rules {
+		result = append(result, column+"="+string(strategy))
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// User returns anonymized copy of user
+func (a *Anonymizer) User(u model.UserRequest) model.UserRequest {
+	u = u.Clone()
+
+	u.Name = a.text("name", u.Name)
+	u.Email = a.text("email", u.Email)
+	u.UpdatedAt = a.time("updated_at", u.UpdatedAt)
+	u.DeletedAt = a.time("deleted_at", u.DeletedAt)
+	u.DoneAt = a.time("done_at", u.DoneAt)
+	u.ErasedAt = a.time("erased_at", u.ErasedAt)
+
+	if a.rules["labels"] == Empty {
+		u.Labels = model.Labels{}
+	}
+	if a.rules["profile"] == Empty {
+		u.Profile = model.Profile{}
+	}
+	if a.rules["email_bidx"] == Empty {
+		u.EmailBlindIndex = sql.NullString{}
+	}
+
+	return u
+}
+
+// text - empty values stay empty, so their share is preserved
+func (a *Anonymizer) text(column, value string) string {
+	if value == "" {
+		return value
+	}
+
+	switch a.rules[column] {
+	case FakeName:
+		return a.fakeName(value)
+	case FakeEmail:
+		return a.fakeEmail(value)
+	case Hash:
+		return a.hash(column, value)
+	case Empty:
+		return ""
+	default:
+		return value
+	}
+}
+
+func (a *Anonymizer) time(column string, value sql.NullTime) sql.NullTime {
+	if a.rules[column] == Empty {
+		return sql.NullTime{}
+	}
+
+	return value
+}
+
+// digest - keyed hash of value in scope, the same value has different digests in different scopes
+func (a *Anonymizer) digest(scope, value string) []byte {
+	mac := hmac.New(sha256.New, a.seed)
+	//nolint
+	mac.Write([]byte(scope + "\x00" + value))
+
+	return mac.Sum(nil)
+}
+
+func hasStrategy(strategies []Strategy, strategy Strategy) bool {
+	for _, s := range strategies {
+		if s == strategy {
+			return true
+		}
+	}
+
+	return false
+}
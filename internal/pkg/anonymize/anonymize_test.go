@@ -0,0 +1,172 @@
+package anonymize
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cmd/main.go/internal/model"
+)
+
+func testAnonymizer(t *testing.T, seed string, rules Rules) *Anonymizer {
+	t.Helper()
+
+	a, err := New(seed, rules)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	return a
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		seed    string
+		rules   Rules
+		wantErr bool
+	}{
+		{name: "defaults", seed: "seed"},
+		{name: "custom rules", seed: "seed", rules: Rules{"name": Hash, "labels": Empty}},
+		{name: "no seed", wantErr: true},
+		{name: "unknown column", seed: "seed", rules: Rules{"password": Empty}, wantErr: true},
+		{name: "unsupported strategy", seed: "seed", rules: Rules{"id_user": Hash}, wantErr: true},
+		{name: "unknown strategy", seed: "seed", rules: ParseRules(map[string]string{"name": "scramble"}), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.seed, tt.rules); (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := New("", nil); !errors.Is(err, ErrNoSeed) {
+		t.Errorf("New() error = %v, want %v", err, ErrNoSeed)
+	}
+}
+
+func TestRules(t *testing.T) {
+	a := testAnonymizer(t, "seed", ParseRules(map[string]string{"labels": " empty "}))
+
+	rules := a.Rules()
+	if len(rules) != len(Columns) {
+		t.Fatalf("Rules() = %v, want rule of every column", rules)
+	}
+	for _, want := range []string{"email=fake_email", "email_bidx=empty", "id_user=keep", "labels=empty", "name=fake_name", "profile=empty"} {
+		if !hasRule(rules, want) {
+			t.Errorf("Rules() = %v, want %s", rules, want)
+		}
+	}
+}
+
+func hasRule(rules []string, rule string) bool {
+	for _, r := range rules {
+		if r == rule {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestUserDefaults(t *testing.T) {
+	a := testAnonymizer(t, "seed", nil)
+	now := time.Now()
+
+	u := model.UserRequest{
+		ID_user:         7,
+		Name:            "Bob Smith",
+		Email:           "bob@example.com",
+		CreatedAt:       now,
+		UpdatedAt:       sql.NullTime{Time: now, Valid: true},
+		Labels:          model.Labels{"team": "a"},
+		Profile:         model.Profile{"phone": "+4790000000"},
+		MergedInto:      sql.NullInt64{Int64: 3, Valid: true},
+		EmailBlindIndex: sql.NullString{String: "index", Valid: true},
+	}
+
+	got := a.User(u)
+	if got.Name == u.Name || got.Email == u.Email {
+		t.Errorf("User() name = %q, email = %q, want fakes", got.Name, got.Email)
+	}
+	if len(got.Profile) != 0 || got.EmailBlindIndex.Valid {
+		t.Errorf("User() profile = %v, email_bidx = %v, want dropped", got.Profile, got.EmailBlindIndex)
+	}
+	if got.ID_user != u.ID_user || !got.CreatedAt.Equal(u.CreatedAt) || got.UpdatedAt != u.UpdatedAt ||
+		got.MergedInto != u.MergedInto || !reflect.DeepEqual(got.Labels, u.Labels) {
+		t.Errorf("User() = %+v, want kept columns of %+v", got, u)
+	}
+	if u.Profile["phone"] != "+4790000000" {
+		t.Error("User() changed profile of original user")
+	}
+}
+
+func TestUserStrategies(t *testing.T) {
+	a := testAnonymizer(t, "seed", Rules{"name": Hash, "email": Empty, "updated_at": Empty, "labels": Empty})
+
+	got := a.User(model.UserRequest{
+		Name:      "Bob",
+		Email:     "bob@example.com",
+		UpdatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		Labels:    model.Labels{"team": "a"},
+	})
+	if len(got.Name) != hashSize || got.Name == "Bob" {
+		t.Errorf("User() name = %q, want hash of %d characters", got.Name, hashSize)
+	}
+	if got.Email != "" || got.UpdatedAt.Valid || len(got.Labels) != 0 {
+		t.Errorf("User() email = %q, updated_at = %v, labels = %v, want empty", got.Email, got.UpdatedAt, got.Labels)
+	}
+}
+
+func TestFakeName(t *testing.T) {
+	a := testAnonymizer(t, "seed", nil)
+
+	if got := a.User(model.UserRequest{}).Name; got != "" {
+		t.Errorf("fake of empty name = %q, want empty", got)
+	}
+	if got := a.fakeName("Bob"); len(strings.Fields(got)) != 1 {
+		t.Errorf("fakeName(one word) = %q, want one word", got)
+	}
+	if got := a.fakeName("Bob Smith"); len(strings.Fields(got)) != 2 {
+		t.Errorf("fakeName(two words) = %q, want two words", got)
+	}
+	if a.fakeName("Bob  Smith") != a.fakeName(" bob smith") {
+		t.Error("fakeName() differs for names that differ in case and spaces")
+	}
+	if a.fakeName("Bob Smith") != testAnonymizer(t, "seed", nil).fakeName("Bob Smith") {
+		t.Error("fakeName() differs for the same seed")
+	}
+}
+
+func TestFakeEmail(t *testing.T) {
+	a := testAnonymizer(t, "seed", nil)
+
+	plain := a.fakeEmail("Bob@Example.com")
+	if !strings.HasSuffix(plain, ".example") || strings.Count(plain, "@") != 1 {
+		t.Errorf("fakeEmail() = %q, want address in .example domain", plain)
+	}
+	if strings.Contains(plain, "bob") {
+		t.Errorf("fakeEmail() = %q contains original local part", plain)
+	}
+
+	tagged := a.fakeEmail("bob+news@example.com")
+	local, domain, _ := strings.Cut(plain, "@")
+	taggedLocal, taggedDomain, _ := strings.Cut(tagged, "@")
+	if !strings.HasPrefix(taggedLocal, local+"+") || taggedDomain != domain {
+		t.Errorf("fakeEmail(tagged) = %q, want tagged fake of %q", tagged, plain)
+	}
+
+	_, otherDomain, _ := strings.Cut(a.fakeEmail("alice@example.com"), "@")
+	if otherDomain != domain {
+		t.Errorf("fakeEmail() domain = %q, want %q for users of one domain", otherDomain, domain)
+	}
+
+	if other := testAnonymizer(t, "other seed", nil).fakeEmail("bob@example.com"); other == plain {
+		t.Errorf("fakeEmail() = %q for different seeds", other)
+	}
+}
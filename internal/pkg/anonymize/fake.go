@@ -0,0 +1,77 @@
+package anonymize
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// hashSize - hex characters of hashed values
+const hashSize = 16
+
+var firstNames = []string{
+	"Alice", "Bruno", "Carmen", "Dmitri", "Elena", "Felix", "Greta", "Hugo", "Ines", "Jonas",
+	"Kira", "Lars", "Maya", "Nikolai", "Olga", "Pavel", "Quinn", "Rosa", "Stefan", "Tara",
+	"Umar", "Vera", "Walter", "Xenia", "Yusuf", "Zoe", "Anton", "Bianca", "Cyril", "Daria",
+	"Emil", "Fiona", "Gleb", "Hanna", "Igor", "Julia", "Karl", "Lena", "Mark", "Nina",
+	"Oscar", "Polina", "Roman", "Sofia", "Timur", "Ulla", "Viktor", "Wanda", "Yana", "Zakhar",
+}
+
+var lastNames = []string{
+	"Abbott", "Baker", "Carver", "Dalton", "Ellis", "Fischer", "Garner", "Hale", "Ivanov", "Jensen",
+	"Keller", "Lowe", "Moreau", "Novak", "Olsen", "Petrov", "Quist", "Russo", "Sokolov", "Turner",
+	"Ueda", "Volkov", "Walsh", "Xu", "Young", "Zimmer", "Arden", "Brandt", "Costa", "Dorn",
+	"Eriksen", "Ford", "Gross", "Horvat", "Isaksen", "Jovanovic", "Kowalski", "Lindqvist", "Marsh", "Nygaard",
+	"Orlov", "Pike", "Reyes", "Stone", "Tanaka", "Unger", "Vance", "Weber", "Yilmaz", "Zorin",
+}
+
+var domainWords = []string{
+	"acme", "globex", "initech", "umbrella", "stark", "wayne", "hooli", "vandelay", "wonka", "tyrell",
+}
+
+// fakeName - name with the same number of words as value, names that differ in case and spaces get the same fake
+func (a *Anonymizer) fakeName(value string) string {
+	words := strings.Fields(strings.ToLower(value))
+	d := a.digest("name", strings.Join(words, " "))
+
+	first := pick(firstNames, d[0:4])
+	if len(words) < 2 {
+		return first
+	}
+
+	return first + " " + pick(lastNames, d[4:8])
+}
+
+// fakeEmail - local part is faked from local part without "+tag", so emails that duplicate analyzer
+// treats as equal stay equal, domain is faked from domain, so users of one domain stay together
+func (a *Anonymizer) fakeEmail(value string) string {
+	email := strings.ToLower(strings.TrimSpace(value))
+
+	local, domain := email, ""
+	if at := strings.LastIndex(email, "@"); at >= 0 {
+		local, domain = email[:at], email[at+1:]
+	}
+
+	local, tag, tagged := strings.Cut(local, "+")
+
+	d := a.digest("email", local)
+	fake := strings.ToLower(pick(firstNames, d[0:4])+"."+pick(lastNames, d[4:8])) +
+		strconv.FormatUint(uint64(binary.BigEndian.Uint16(d[8:10]))%1000, 10)
+	if tagged {
+		fake += "+" + hex.EncodeToString(a.digest("email_tag", tag))[:6]
+	}
+
+	dd := a.digest("email_domain", domain)
+
+	return fake + "@" + pick(domainWords, dd[0:4]) + "-" + hex.EncodeToString(dd[4:6]) + ".example"
+}
+
+func (a *Anonymizer) hash(column, value string) string {
+	return hex.EncodeToString(a.digest(column, value))[:hashSize]
+}
+
+// pick - element of list chosen by 4 bytes of digest
+func pick(list []string, b []byte) string {
+	return list[binary.BigEndian.Uint32(b)%uint32(len(list))]
+}
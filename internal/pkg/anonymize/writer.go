@@ -0,0 +1,192 @@
+package anonymize
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"cmd/main.go/internal/model"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// FormatSQL - INSERT statements in one transaction, literals are accepted by Postgres and SQLite
+	FormatSQL = "sql"
+	// FormatNDJSON - one JSON object per user and line
+	FormatNDJSON = "ndjson"
+)
+
+// sqlTimeLayout - timestamp literal Postgres parses as timestamptz and SQLite driver reads back as time
+const sqlTimeLayout = "2006-01-02 15:04:05.999999-07:00"
+
+// Writer writes users of dump
+type Writer interface {
+	Write(u model.UserRequest) error
+	Close() error
+}
+
+// NewWriter returns Writer of format to w, Close flushes buffered output but does not close w
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	out := bufio.NewWriter(w)
+
+	switch format {
+	case FormatSQL:
+		if _, err := out.WriteString("BEGIN;\n"); err != nil {
+			return nil, err
+		}
+
+		return &sqlWriter{out: out}, nil
+	case FormatNDJSON:
+		return &ndjsonWriter{out: out, enc: json.NewEncoder(out)}, nil
+	default:
+		return nil, errors.Errorf("unknown format %q, use %s or %s", format, FormatSQL, FormatNDJSON)
+	}
+}
+
+type sqlWriter struct {
+	out *bufio.Writer
+}
+
+func (w *sqlWriter) Write(u model.UserRequest) error {
+	labels, err := json.Marshal(u.Labels)
+	if err != nil {
+		return errors.Wrap(err, "labels")
+	}
+	profile, err := json.Marshal(u.Profile)
+	if err != nil {
+		return errors.Wrap(err, "profile")
+	}
+
+	values := []string{
+		strconv.FormatUint(u.ID_user, 10),
+		quote(u.Name),
+		quote(u.Email),
+		quote(u.CreatedAt.Format(sqlTimeLayout)),
+		nullTime(u.UpdatedAt),
+		nullTime(u.DeletedAt),
+		nullTime(u.DoneAt),
+		quote(jsonObject(labels)),
+		quote(jsonObject(profile)),
+		nullInt(u.MergedInto),
+		nullString(u.EmailBlindIndex),
+		nullTime(u.ErasedAt),
+	}
+
+	_, err = fmt.Fprintf(w.out, "INSERT INTO users (%s) VALUES (%s);\n", strings.Join(Columns, ", "), strings.Join(values, ", "))
+
+	return err
+}
+
+func (w *sqlWriter) Close() error {
+	if _, err := w.out.WriteString("COMMIT;\n"); err != nil {
+		return err
+	}
+
+	return w.out.Flush()
+}
+
+type ndjsonWriter struct {
+	out *bufio.Writer
+	enc *json.Encoder
+}
+
+// ndjsonUser - user with keys named after columns
+type ndjsonUser struct {
+	ID         uint64        `json:"id_user"`
+	Name       string        `json:"name"`
+	Email      string        `json:"email"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  *time.Time    `json:"updated_at"`
+	DeletedAt  *time.Time    `json:"deleted_at"`
+	DoneAt     *time.Time    `json:"done_at"`
+	Labels     model.Labels  `json:"labels"`
+	Profile    model.Profile `json:"profile"`
+	MergedInto *int64        `json:"merged_into"`
+	EmailBidx  *string       `json:"email_bidx"`
+	ErasedAt   *time.Time    `json:"erased_at"`
+}
+
+func (w *ndjsonWriter) Write(u model.UserRequest) error {
+	row := ndjsonUser{
+		ID:        u.ID_user,
+		Name:      u.Name,
+		Email:     u.Email,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: timePtr(u.UpdatedAt),
+		DeletedAt: timePtr(u.DeletedAt),
+		DoneAt:    timePtr(u.DoneAt),
+		Labels:    u.Labels,
+		Profile:   u.Profile,
+		ErasedAt:  timePtr(u.ErasedAt),
+	}
+	if row.Labels == nil {
+		row.Labels = model.Labels{}
+	}
+	if row.Profile == nil {
+		row.Profile = model.Profile{}
+	}
+	if u.MergedInto.Valid {
+		row.MergedInto = &u.MergedInto.Int64
+	}
+	if u.EmailBlindIndex.Valid {
+		row.EmailBidx = &u.EmailBlindIndex.String
+	}
+
+	return w.enc.Encode(row)
+}
+
+func (w *ndjsonWriter) Close() error {
+	return w.out.Flush()
+}
+
+// quote - SQL string literal, standard_conforming_strings makes backslashes plain characters on Postgres
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func nullTime(t sql.NullTime) string {
+	if !t.Valid {
+		return "NULL"
+	}
+
+	return quote(t.Time.Format(sqlTimeLayout))
+}
+
+func nullInt(i sql.NullInt64) string {
+	if !i.Valid {
+		return "NULL"
+	}
+
+	return strconv.FormatInt(i.Int64, 10)
+}
+
+func nullString(s sql.NullString) string {
+	if !s.Valid {
+		return "NULL"
+	}
+
+	return quote(s.String)
+}
+
+// jsonObject - nil maps are written as empty objects, columns are not null
+func jsonObject(data []byte) string {
+	if string(data) == "null" {
+		return "{}"
+	}
+
+	return string(data)
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
@@ -0,0 +1,115 @@
+package anonymize
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cmd/main.go/internal/database/dbtest"
+	"cmd/main.go/internal/model"
+)
+
+func testUsers() []model.UserRequest {
+	created := time.Date(2024, 5, 1, 10, 30, 0, 123456000, time.UTC)
+
+	return []model.UserRequest{
+		{
+			ID_user:   1,
+			Name:      "O'Brien",
+			Email:     `back\slash@example.com`,
+			CreatedAt: created,
+			Labels:    model.Labels{"team": "a"},
+			Profile:   model.Profile{"city": "Oslo"},
+		},
+		{
+			ID_user:         2,
+			CreatedAt:       created,
+			DeletedAt:       sql.NullTime{Time: created.Add(time.Hour), Valid: true},
+			MergedInto:      sql.NullInt64{Int64: 1, Valid: true},
+			EmailBlindIndex: sql.NullString{String: "index", Valid: true},
+		},
+	}
+}
+
+func write(t *testing.T, format string, users []model.UserRequest) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf)
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	for _, u := range users {
+		if err = w.Write(u); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	return buf.String()
+}
+
+func TestNewWriterUnknownFormat(t *testing.T) {
+	if _, err := NewWriter("csv", &bytes.Buffer{}); err == nil {
+		t.Error("NewWriter(csv) succeeded")
+	}
+}
+
+func TestNDJSONWriter(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(write(t, FormatNDJSON, testUsers())), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("ndjson lines = %d, want 2", len(lines))
+	}
+
+	var second map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	for key, want := range map[string]interface{}{
+		"id_user":     float64(2),
+		"merged_into": float64(1),
+		"email_bidx":  "index",
+		"updated_at":  nil,
+		"labels":      map[string]interface{}{},
+		"profile":     map[string]interface{}{},
+	} {
+		if got := second[key]; !reflect.DeepEqual(got, want) {
+			t.Errorf("ndjson %s = %v, want %v", key, got, want)
+		}
+	}
+}
+
+// TestSQLWriterLoads - dump is loaded into migrated database and read back
+func TestSQLWriterLoads(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.SQLite(t)
+	users := testUsers()
+
+	if _, err := db.ExecContext(ctx, write(t, FormatSQL, users)); err != nil {
+		t.Fatalf("load dump: %v", err)
+	}
+
+	var loaded []model.UserRequest
+	if err := db.SelectContext(ctx, &loaded, "SELECT * FROM users ORDER BY id_user"); err != nil {
+		t.Fatalf("select users: %v", err)
+	}
+	if len(loaded) != len(users) {
+		t.Fatalf("loaded users = %d, want %d", len(loaded), len(users))
+	}
+	for i, got := range loaded {
+		want := users[i]
+		if got.Name != want.Name || got.Email != want.Email || !got.CreatedAt.Equal(want.CreatedAt) ||
+			got.DeletedAt.Valid != want.DeletedAt.Valid || !got.DeletedAt.Time.Equal(want.DeletedAt.Time) ||
+			got.MergedInto != want.MergedInto || got.EmailBlindIndex != want.EmailBlindIndex ||
+			!reflect.DeepEqual(got.Labels, want.Labels) && len(want.Labels) > 0 {
+			t.Errorf("loaded user = %+v, want %+v", got, want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Package auth verifies bearer JWTs against keys of a JWKS file and carries verified claims in context
+package auth
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+)
+
+// ErrMissingToken is a "request carries no bearer token" error
+var ErrMissingToken = errors.New("missing bearer token")
+
+// algorithms - asymmetric algorithms tokens may be signed with, keys of JWKS are public keys,
+// so HMAC tokens are never accepted
+var algorithms = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// Claims are verified claims of token, roles and scope are used by authorization
+type Claims struct {
+	jwt.RegisteredClaims
+	Scope string   `json:"scope,omitempty"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+// Scopes returns space separated scope as a list
+func (c *Claims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+type claimsKey struct{}
+
+// WithClaims returns ctx carrying verified claims
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns claims verified for request of ctx
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+
+	return claims, ok
+}
+
+// Verifier checks signature, issuer, audience and expiry of tokens
+type Verifier struct {
+	keys   *KeySet
+	parser *jwt.Parser
+}
+
+// NewVerifier returns Verifier of tokens signed by keys, issued by issuer for audience,
+// leeway is allowed for clock skew in checks of exp, nbf and iat
+func NewVerifier(keys *KeySet, issuer, audience string, leeway time.Duration) *Verifier {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(algorithms),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(leeway),
+	}
+	if issuer != "" {
+		opts = append(opts, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		opts = append(opts, jwt.WithAudience(audience))
+	}
+
+	return &Verifier{keys: keys, parser: jwt.NewParser(opts...)}
+}
+
+// Verify returns claims of valid token
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	claims := &Claims{}
+	if _, err := v.parser.ParseWithClaims(token, claims, v.keyFunc); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (v *Verifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	k, err := v.keys.lookup(kid)
+	if err != nil {
+		return nil, err
+	}
+	if k.alg != "" && k.alg != token.Method.Alg() {
+		return nil, errors.Errorf("key %q is for %s, token is signed with %s", kid, k.alg, token.Method.Alg())
+	}
+
+	return k.public, nil
+}
+
+// BearerToken returns token of "Bearer <token>" authorization value
+func BearerToken(authorization string) (string, error) {
+	scheme, token, ok := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", ErrMissingToken
+	}
+
+	return strings.TrimSpace(token), nil
+}
@@ -0,0 +1,268 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testIssuer   = "https://issuer.example.com"
+	testAudience = "users-api"
+)
+
+// testKeys - signing keys of JWKS written by writeJWKS
+type testKeys struct {
+	ec *ecdsa.PrivateKey
+	ed ed25519.PrivateKey
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+
+	ec, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey(): %v", err)
+	}
+	_, ed, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey(): %v", err)
+	}
+
+	return testKeys{ec: ec, ed: ed}
+}
+
+// writeJWKS - write public keys as JWKS: "ec" restricted to ES256 and "ed" without alg
+func writeJWKS(t *testing.T, keys testKeys) string {
+	t.Helper()
+
+	encode := base64.RawURLEncoding.EncodeToString
+	set := map[string][]jwk{"keys": {
+		{
+			Kid: "ec", Kty: "EC", Alg: "ES256", Use: "sig", Crv: "P-256",
+			X: encode(keys.ec.X.FillBytes(make([]byte, 32))),
+			Y: encode(keys.ec.Y.FillBytes(make([]byte, 32))),
+		},
+		{
+			Kid: "ed", Kty: "OKP", Crv: "Ed25519",
+			X: encode(keys.ed.Public().(ed25519.PublicKey)),
+		},
+	}}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatalf("json.Marshal(): %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err = os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("os.WriteFile(): %v", err)
+	}
+
+	return path
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, signingKey interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(signingKey)
+	if err != nil {
+		t.Fatalf("SignedString(): %v", err)
+	}
+
+	return signed
+}
+
+func TestVerifier(t *testing.T) {
+	keys := newTestKeys(t)
+	keySet, err := LoadKeySet(writeJWKS(t, keys))
+	if err != nil {
+		t.Fatalf("LoadKeySet() error = %v", err)
+	}
+	v := NewVerifier(keySet, testIssuer, testAudience, 0)
+
+	now := time.Now()
+	claims := func(changes jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"sub":   "user-1",
+			"iss":   testIssuer,
+			"aud":   testAudience,
+			"iat":   now.Unix(),
+			"exp":   now.Add(time.Hour).Unix(),
+			"scope": "users:read users:write",
+			"roles": []string{"editor"},
+		}
+		for k, v := range changes {
+			if v == nil {
+				delete(c, k)
+				continue
+			}
+			c[k] = v
+		}
+
+		return c
+	}
+	publicEC, err := json.Marshal(keys.ec.Public())
+	if err != nil {
+		t.Fatalf("json.Marshal(): %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		// wantErr - expected error, nil for valid token
+		wantErr error
+	}{
+		{
+			name:  "ES256",
+			token: sign(t, jwt.SigningMethodES256, "ec", keys.ec, claims(nil)),
+		},
+		{
+			name:  "EdDSA",
+			token: sign(t, jwt.SigningMethodEdDSA, "ed", keys.ed, claims(nil)),
+		},
+		{
+			// public key of JWKS used as HMAC secret must not verify
+			name:    "HMAC algorithm",
+			token:   sign(t, jwt.SigningMethodHS256, "ec", publicEC, claims(nil)),
+			wantErr: jwt.ErrTokenSignatureInvalid,
+		},
+		{
+			name:    "unknown kid",
+			token:   sign(t, jwt.SigningMethodES256, "other", keys.ec, claims(nil)),
+			wantErr: ErrUnknownKey,
+		},
+		{
+			name:    "no kid with several keys",
+			token:   sign(t, jwt.SigningMethodES256, "", keys.ec, claims(nil)),
+			wantErr: ErrUnknownKey,
+		},
+		{
+			name:    "key of other algorithm",
+			token:   sign(t, jwt.SigningMethodEdDSA, "ec", keys.ed, claims(nil)),
+			wantErr: jwt.ErrTokenUnverifiable,
+		},
+		{
+			name:    "signed by other key",
+			token:   sign(t, jwt.SigningMethodEdDSA, "ed", ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)), claims(nil)),
+			wantErr: jwt.ErrTokenSignatureInvalid,
+		},
+		{
+			name:    "expired",
+			token:   sign(t, jwt.SigningMethodES256, "ec", keys.ec, claims(jwt.MapClaims{"exp": now.Add(-time.Minute).Unix()})),
+			wantErr: jwt.ErrTokenExpired,
+		},
+		{
+			name:    "without expiry",
+			token:   sign(t, jwt.SigningMethodES256, "ec", keys.ec, claims(jwt.MapClaims{"exp": nil})),
+			wantErr: jwt.ErrTokenRequiredClaimMissing,
+		},
+		{
+			name:    "wrong audience",
+			token:   sign(t, jwt.SigningMethodES256, "ec", keys.ec, claims(jwt.MapClaims{"aud": "other-api"})),
+			wantErr: jwt.ErrTokenInvalidAudience,
+		},
+		{
+			name:    "wrong issuer",
+			token:   sign(t, jwt.SigningMethodES256, "ec", keys.ec, claims(jwt.MapClaims{"iss": "https://other.example.com"})),
+			wantErr: jwt.ErrTokenInvalidIssuer,
+		},
+		{
+			name:    "issued in the future",
+			token:   sign(t, jwt.SigningMethodES256, "ec", keys.ec, claims(jwt.MapClaims{"iat": now.Add(time.Hour).Unix()})),
+			wantErr: jwt.ErrTokenUsedBeforeIssued,
+		},
+		{
+			name:    "malformed",
+			token:   "not.a.token",
+			wantErr: jwt.ErrTokenMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Verify(tt.token)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if got.Subject != "user-1" || len(got.Scopes()) != 2 || len(got.Roles) != 1 || got.Roles[0] != "editor" {
+				t.Errorf("Verify() = %+v, want claims of token", got)
+			}
+		})
+	}
+}
+
+func TestKeySetReload(t *testing.T) {
+	keys := newTestKeys(t)
+	path := writeJWKS(t, keys)
+	keySet, err := LoadKeySet(path)
+	if err != nil {
+		t.Fatalf("LoadKeySet() error = %v", err)
+	}
+
+	if reloaded, err := keySet.Reload(); err != nil || reloaded {
+		t.Errorf("Reload() of unchanged file = %v, %v, want no reload", reloaded, err)
+	}
+
+	// broken file keeps previous keys
+	if err = os.WriteFile(path, []byte(`{"keys": []}`), 0o600); err != nil {
+		t.Fatalf("os.WriteFile(): %v", err)
+	}
+	if _, err = keySet.Reload(); err == nil {
+		t.Error("Reload() of JWKS without keys succeeded")
+	}
+	if _, err = keySet.lookup("ec"); err != nil {
+		t.Errorf("lookup() after failed reload error = %v, want previous keys", err)
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		authorization string
+		want          string
+		wantErr       bool
+	}{
+		{authorization: "Bearer abc", want: "abc"},
+		{authorization: "  bearer   abc ", want: "abc"},
+		{authorization: "", wantErr: true},
+		{authorization: "Bearer", wantErr: true},
+		{authorization: "Bearer  ", wantErr: true},
+		{authorization: "Basic abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.authorization, func(t *testing.T) {
+			got, err := BearerToken(tt.authorization)
+			if tt.wantErr {
+				if !errors.Is(err, ErrMissingToken) {
+					t.Errorf("BearerToken() error = %v, want ErrMissingToken", err)
+				}
+
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("BearerToken() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"cmd/main.go/internal/logger"
+)
+
+const keySetLogTag = "KeySet"
+
+// ErrUnknownKey is a "token is signed by key missing from key set" error
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// key - public key of JWKS with algorithm it is restricted to, empty alg allows algorithms of its type
+type key struct {
+	public crypto.PublicKey
+	alg    string
+}
+
+// jwk - fields of JSON Web Key used by RSA, EC and OKP (Ed25519) public keys
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// parseJWKS returns public keys of JWKS by key id, keys for encryption (use "enc") are skipped
+func parseJWKS(data []byte) (map[string]key, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, errors.Wrap(err, "json.Unmarshal")
+	}
+
+	keys := make(map[string]key, len(set.Keys))
+	for i, k := range set.Keys {
+		if k.Use == "enc" {
+			continue
+		}
+		if _, ok := keys[k.Kid]; ok {
+			return nil, fmt.Errorf("key %d: duplicate kid %q", i, k.Kid)
+		}
+
+		public, err := k.publicKey()
+		if err != nil {
+			return nil, errors.Wrapf(err, "key %d (kid %q)", i, k.Kid)
+		}
+		keys[k.Kid] = key{public: public, alg: k.Alg}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no signing keys")
+	}
+
+	return keys, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, errors.Wrap(err, "n")
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, errors.Wrap(err, "e")
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid exponent")
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, errors.Wrap(err, "x")
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, errors.Wrap(err, "y")
+		}
+		//nolint:staticcheck
+		if !curve.IsOnCurve(x, y) {
+			return nil, errors.New("point is not on curve")
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, errors.Wrap(err, "x")
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key size")
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, errors.New("missing")
+	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+// KeySet holds keys of JWKS file, Run reloads them when the file changes,
+// a file that can not be loaded leaves keys as they are
+type KeySet struct {
+	path string
+	keys atomic.Pointer[map[string]key]
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+}
+
+// LoadKeySet returns keys of JWKS file at path
+func LoadKeySet(path string) (*KeySet, error) {
+	s := &KeySet{path: path}
+	if _, err := s.Reload(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Reload - load keys when modification time or size of the file changed since the last load,
+// returns whether keys were replaced
+func (s *KeySet) Reload() (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return false, errors.Wrap(err, "os.Stat")
+	}
+	if s.keys.Load() != nil && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return false, errors.Wrap(err, "os.ReadFile")
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return false, errors.Wrap(err, s.path)
+	}
+
+	s.keys.Store(&keys)
+	s.modTime, s.size = info.ModTime(), info.Size()
+
+	return true, nil
+}
+
+// Run - reload keys every interval until ctx is done
+func (s *KeySet) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		reloaded, err := s.Reload()
+		switch {
+		case err != nil:
+			logger.ErrorKV(ctx, fmt.Sprintf("%s: reload failed, previous keys are kept", keySetLogTag),
+				"path", s.path,
+				"err", err,
+			)
+		case reloaded:
+			logger.InfoKV(ctx, fmt.Sprintf("%s: keys reloaded", keySetLogTag),
+				"path", s.path,
+				"keys", len(*s.keys.Load()),
+			)
+		}
+	}
+}
+
+// lookup returns key by id, tokens without id are verified by the only key of set
+func (s *KeySet) lookup(kid string) (key, error) {
+	keys := *s.keys.Load()
+	if kid == "" && len(keys) == 1 {
+		for _, k := range keys {
+			return k, nil
+		}
+	}
+
+	k, ok := keys[kid]
+	if !ok {
+		return key{}, errors.Wrapf(ErrUnknownKey, "kid %q", kid)
+	}
+
+	return k, nil
+}
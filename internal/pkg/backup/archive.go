@@ -0,0 +1,275 @@
+// Package backup reads and writes backup archives: a gzip stream of length-delimited protobuf messages,
+// a header with format and schema versions, records grouped by table, an empty record and a manifest
+// with row counts and SHA-256 of records of every table, see api/aperg/my_api/v1/backup.proto
+package backup
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"io"
+
+	desc "cmd/main.go/pkg/my-api"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// FormatVersion - version of archive layout written by Writer
+const FormatVersion = 1
+
+// maxMessageSize - size of a message Reader accepts, rows are far smaller
+const maxMessageSize = 64 << 20
+
+var (
+	// ErrUnsupportedFormat is a "archive is written by newer binary" error
+	ErrUnsupportedFormat = errors.New("unsupported backup format version")
+	// ErrChecksumMismatch is a "records of table differ from manifest" error
+	ErrChecksumMismatch = errors.New("backup checksum mismatch")
+	// ErrTruncated is a "archive ends before manifest" error
+	ErrTruncated = errors.New("backup archive is truncated")
+)
+
+// Writer writes archive
+type Writer struct {
+	gz       *gzip.Writer
+	out      *bufio.Writer
+	buf      []byte
+	tables   []*desc.BackupTable
+	checksum hash.Hash
+}
+
+// NewWriter writes header to w and returns Writer, Close writes manifest and does not close w
+func NewWriter(w io.Writer, schemaVersion uint64, dialect string) (*Writer, error) {
+	out := bufio.NewWriter(w)
+	bw := &Writer{
+		gz:  gzip.NewWriter(out),
+		out: out,
+	}
+
+	header := &desc.BackupHeader{
+		FormatVersion: FormatVersion,
+		CreatedAt:     timestamppb.Now(),
+		SchemaVersion: schemaVersion,
+		Dialect:       dialect,
+	}
+	if _, err := bw.writeMessage(header); err != nil {
+		return nil, err
+	}
+
+	return bw, nil
+}
+
+// Write - append record, records of one table must be written one after another
+func (w *Writer) Write(record *desc.BackupRecord) error {
+	table := TableOf(record)
+	if table == "" {
+		return errors.New("record has no row")
+	}
+	if len(w.tables) == 0 || w.tables[len(w.tables)-1].Name != table {
+		w.finishTable()
+		w.tables = append(w.tables, &desc.BackupTable{Name: table})
+		w.checksum = sha256.New()
+	}
+
+	data, err := w.writeMessage(record)
+	if err != nil {
+		return err
+	}
+	//nolint
+	w.checksum.Write(data)
+	w.tables[len(w.tables)-1].Rows++
+
+	return nil
+}
+
+// Close - write manifest and flush archive
+func (w *Writer) Close() error {
+	w.finishTable()
+
+	if _, err := w.writeMessage(&desc.BackupRecord{}); err != nil {
+		return err
+	}
+	if _, err := w.writeMessage(&desc.BackupManifest{Tables: w.tables}); err != nil {
+		return err
+	}
+	if err := w.gz.Close(); err != nil {
+		return errors.Wrap(err, "gzip.Close()")
+	}
+
+	return w.out.Flush()
+}
+
+// Tables returns tables written so far with their row counts
+func (w *Writer) Tables() []*desc.BackupTable {
+	return w.tables
+}
+
+func (w *Writer) finishTable() {
+	if len(w.tables) > 0 {
+		w.tables[len(w.tables)-1].Sha256 = hex.EncodeToString(w.checksum.Sum(nil))
+	}
+}
+
+// writeMessage - write size and message, returns both as written
+func (w *Writer) writeMessage(m proto.Message) ([]byte, error) {
+	data, err := proto.Marshal(m)
+	if err != nil {
+		return nil, errors.Wrap(err, "proto.Marshal()")
+	}
+
+	w.buf = protowire.AppendVarint(w.buf[:0], uint64(len(data)))
+	w.buf = append(w.buf, data...)
+	if _, err = w.gz.Write(w.buf); err != nil {
+		return nil, errors.Wrap(err, "gzip.Write()")
+	}
+
+	return w.buf, nil
+}
+
+// Reader reads archive and verifies checksums of tables against manifest
+type Reader struct {
+	in       *bufio.Reader
+	header   *desc.BackupHeader
+	next     []byte
+	table    string
+	tables   []*desc.BackupTable
+	checksum hash.Hash
+	manifest *desc.BackupManifest
+}
+
+// NewReader reads header of archive from r
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "gzip.NewReader()")
+	}
+
+	br := &Reader{in: bufio.NewReader(gz)}
+
+	data, err := br.readMessage()
+	if err != nil {
+		return nil, err
+	}
+	br.header = &desc.BackupHeader{}
+	if err = proto.Unmarshal(data, br.header); err != nil {
+		return nil, errors.Wrap(err, "header")
+	}
+	if br.header.GetFormatVersion() == 0 || br.header.GetFormatVersion() > FormatVersion {
+		return nil, errors.Wrapf(ErrUnsupportedFormat, "version %d", br.header.GetFormatVersion())
+	}
+
+	return br, nil
+}
+
+// Header returns header of archive
+func (r *Reader) Header() *desc.BackupHeader {
+	return r.header
+}
+
+// Next returns next record, io.EOF after the last one once checksums of every table match manifest.
+// Records are not verified before the manifest is read, so callers that write them
+// must undo their writes when Next fails
+func (r *Reader) Next() (*desc.BackupRecord, error) {
+	data, err := r.readMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	record := &desc.BackupRecord{}
+	if err = proto.Unmarshal(data, record); err != nil {
+		return nil, errors.Wrap(err, "record")
+	}
+
+	// empty record ends records, manifest follows it
+	if record.GetRow() == nil {
+		return nil, r.finish()
+	}
+
+	table := TableOf(record)
+	if table != r.table {
+		r.finishTable()
+		r.table = table
+		r.tables = append(r.tables, &desc.BackupTable{Name: table})
+		r.checksum = sha256.New()
+	}
+	//nolint
+	r.checksum.Write(r.next)
+	r.tables[len(r.tables)-1].Rows++
+
+	return record, nil
+}
+
+// Manifest returns manifest after Next returned io.EOF
+func (r *Reader) Manifest() *desc.BackupManifest {
+	return r.manifest
+}
+
+func (r *Reader) finish() error {
+	r.finishTable()
+
+	data, err := r.readMessage()
+	if err != nil {
+		return err
+	}
+	r.manifest = &desc.BackupManifest{}
+	if err = proto.Unmarshal(data, r.manifest); err != nil {
+		return errors.Wrap(err, "manifest")
+	}
+
+	want := r.manifest.GetTables()
+	if len(want) != len(r.tables) {
+		return errors.Wrapf(ErrChecksumMismatch, "archive has %d tables, manifest lists %d", len(r.tables), len(want))
+	}
+	for i, got := range r.tables {
+		if got.GetName() != want[i].GetName() || got.GetRows() != want[i].GetRows() || got.GetSha256() != want[i].GetSha256() {
+			return errors.Wrapf(ErrChecksumMismatch, "table %s: %d rows %s, manifest has %s: %d rows %s",
+				got.GetName(), got.GetRows(), got.GetSha256(), want[i].GetName(), want[i].GetRows(), want[i].GetSha256())
+		}
+	}
+
+	if _, err := r.readMessage(); err != ErrTruncated {
+		return errors.New("data after manifest")
+	}
+
+	return io.EOF
+}
+
+func (r *Reader) finishTable() {
+	if r.checksum != nil {
+		r.tables[len(r.tables)-1].Sha256 = hex.EncodeToString(r.checksum.Sum(nil))
+	}
+}
+
+// readMessage - read size and message, r.next keeps both as read
+func (r *Reader) readMessage() ([]byte, error) {
+	size, err := binary.ReadUvarint(r.in)
+	if err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, ErrTruncated
+		}
+
+		return nil, errors.Wrap(err, "read size")
+	}
+	if size > maxMessageSize {
+		return nil, errors.Errorf("message of %d bytes is too large", size)
+	}
+
+	r.next = protowire.AppendVarint(r.next[:0], size)
+	start := len(r.next)
+	r.next = append(r.next, make([]byte, size)...)
+	if _, err = io.ReadFull(r.in, r.next[start:]); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, ErrTruncated
+		}
+
+		return nil, errors.Wrap(err, "read message")
+	}
+
+	return r.next[start:], nil
+}
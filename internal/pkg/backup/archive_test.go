@@ -0,0 +1,167 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"testing"
+
+	desc "cmd/main.go/pkg/my-api"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func testRecords() []*desc.BackupRecord {
+	return []*desc.BackupRecord{
+		{Row: &desc.BackupRecord_User{User: &desc.BackupUser{IdUser: 1, Name: "Bob"}}},
+		{Row: &desc.BackupRecord_User{User: &desc.BackupUser{IdUser: 2, Name: "Alice"}}},
+		{Row: &desc.BackupRecord_Group{Group: &desc.BackupGroup{IdGroup: 1, Name: "team"}}},
+		{Row: &desc.BackupRecord_GroupMember{GroupMember: &desc.BackupGroupMember{IdGroup: 1, IdUser: 2}}},
+	}
+}
+
+// writeArchive - archive of records, change runs before manifest is written
+func writeArchive(t *testing.T, records []*desc.BackupRecord, change func(w *Writer)) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, 8, "postgres")
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	for _, record := range records {
+		if err = w.Write(record); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if change != nil {
+		change(w)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+// readArchive - all records of archive and error that ended reading, io.EOF for valid archive
+func readArchive(data []byte) ([]*desc.BackupRecord, *Reader, error) {
+	r, err := NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var records []*desc.BackupRecord
+	for {
+		record, err := r.Next()
+		if err != nil {
+			return records, r, err
+		}
+		records = append(records, record)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	records := testRecords()
+
+	got, r, err := readArchive(writeArchive(t, records, nil))
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("Next() error = %v, want io.EOF", err)
+	}
+	if len(got) != len(records) {
+		t.Fatalf("read %d records, want %d", len(got), len(records))
+	}
+	for i := range records {
+		if !proto.Equal(got[i], records[i]) {
+			t.Errorf("record %d = %v, want %v", i, got[i], records[i])
+		}
+	}
+
+	if h := r.Header(); h.GetFormatVersion() != FormatVersion || h.GetSchemaVersion() != 8 || h.GetDialect() != "postgres" {
+		t.Errorf("Header() = %v", h)
+	}
+
+	wantRows := map[string]uint64{UsersTable: 2, GroupsTable: 1, GroupMembersTable: 1}
+	tables := r.Manifest().GetTables()
+	if len(tables) != len(wantRows) {
+		t.Fatalf("manifest tables = %v", tables)
+	}
+	for _, table := range tables {
+		if table.GetRows() != wantRows[table.GetName()] || len(table.GetSha256()) != 64 {
+			t.Errorf("manifest table %s = %d rows %q, want %d rows", table.GetName(), table.GetRows(), table.GetSha256(), wantRows[table.GetName()])
+		}
+	}
+}
+
+func TestEmptyArchive(t *testing.T) {
+	got, r, err := readArchive(writeArchive(t, nil, nil))
+	if !errors.Is(err, io.EOF) || len(got) != 0 {
+		t.Fatalf("read %d records, error = %v, want empty archive", len(got), err)
+	}
+	if len(r.Manifest().GetTables()) != 0 {
+		t.Errorf("manifest tables = %v, want none", r.Manifest().GetTables())
+	}
+}
+
+func TestChecksumMismatch(t *testing.T) {
+	data := writeArchive(t, testRecords(), func(w *Writer) {
+		w.tables[0].Rows++
+	})
+
+	if _, _, err := readArchive(data); !errors.Is(err, ErrChecksumMismatch) {
+		t.Errorf("Next() error = %v, want %v", err, ErrChecksumMismatch)
+	}
+}
+
+func TestTruncated(t *testing.T) {
+	var raw bytes.Buffer
+	gz, err := gzip.NewReader(bytes.NewReader(writeArchive(t, testRecords(), nil)))
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	if _, err = io.Copy(&raw, gz); err != nil {
+		t.Fatalf("decompress: %v", err)
+	}
+
+	// archive cut in the middle of records
+	var truncated bytes.Buffer
+	zw := gzip.NewWriter(&truncated)
+	if _, err = zw.Write(raw.Bytes()[:raw.Len()/2]); err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatalf("compress: %v", err)
+	}
+
+	if _, _, err = readArchive(truncated.Bytes()); !errors.Is(err, ErrTruncated) {
+		t.Errorf("Next() error = %v, want %v", err, ErrTruncated)
+	}
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	out := bufio.NewWriter(&buf)
+	w := &Writer{gz: gzip.NewWriter(out), out: out}
+	if _, err := w.writeMessage(&desc.BackupHeader{FormatVersion: FormatVersion + 1}); err != nil {
+		t.Fatalf("writeMessage() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	if _, err := NewReader(&buf); !errors.Is(err, ErrUnsupportedFormat) {
+		t.Errorf("NewReader() error = %v, want %v", err, ErrUnsupportedFormat)
+	}
+}
+
+func TestWriteRecordWithoutRow(t *testing.T) {
+	w, err := NewWriter(io.Discard, 1, "sqlite3")
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	if err = w.Write(&desc.BackupRecord{}); err == nil {
+		t.Error("Write() of record without row succeeded")
+	}
+}
@@ -0,0 +1,36 @@
+package backup
+
+import (
+	desc "cmd/main.go/pkg/my-api"
+)
+
+// Tables of archive in order they are written and restored, referenced tables go first.
+// Duplicate candidates are derived by the analyzer and are not backed up
+const (
+	UsersTable           = "users"
+	GroupsTable          = "groups"
+	GroupMembersTable    = "group_members"
+	UserEventsTable      = "users_events"
+	ErasureReceiptsTable = "users_erasure_receipts"
+)
+
+// Tables - names of backed up tables in order of archive
+var Tables = []string{UsersTable, GroupsTable, GroupMembersTable, UserEventsTable, ErasureReceiptsTable}
+
+// TableOf returns name of table record belongs to
+func TableOf(record *desc.BackupRecord) string {
+	switch record.GetRow().(type) {
+	case *desc.BackupRecord_User:
+		return UsersTable
+	case *desc.BackupRecord_Group:
+		return GroupsTable
+	case *desc.BackupRecord_GroupMember:
+		return GroupMembersTable
+	case *desc.BackupRecord_UserEvent:
+		return UserEventsTable
+	case *desc.BackupRecord_ErasureReceipt:
+		return ErasureReceiptsTable
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,58 @@
+// Package buildinfo describes the running binary, Version, Commit and BuildTime are set at build time with
+// -ldflags "-X cmd/main.go/internal/pkg/buildinfo.Version=...", revision stamped by go build
+// is used when Commit is not set
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+var (
+	// Version - release of binary
+	Version = "dev"
+	// Commit - VCS revision binary is built from
+	Commit = ""
+	// BuildTime - time binary is built at, RFC 3339
+	BuildTime = ""
+)
+
+// Info is a description of the running binary
+type Info struct {
+	Version    string `json:"version"`
+	Commit     string `json:"commit"`
+	BuildTime  string `json:"buildTime"`
+	CommitTime string `json:"commitTime"`
+	Modified   bool   `json:"modified"`
+	GoVersion  string `json:"goVersion"`
+}
+
+// Get returns description of the running binary
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = setting.Value
+			}
+		case "vcs.time":
+			info.CommitTime = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+
+	return info
+}
@@ -0,0 +1,219 @@
+// Package envelope encrypts values with envelope encryption: every value gets its own AES-256-GCM data key,
+// the data key is wrapped by a versioned key encryption key and stored next to the ciphertext,
+// so keys are rotated by re-wrapping values without touching other values.
+// Deterministic blind indexes allow equality lookups of encrypted values.
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Prefix starts every sealed value, values without it are plaintext written before encryption was enabled
+const Prefix = "enc:"
+
+const (
+	// KeySize - size of key encryption keys and data keys, AES-256
+	KeySize = 32
+	// MinIndexKeySize - minimal size of blind index key
+	MinIndexKeySize = 32
+)
+
+var (
+	// ErrUnknownKey is a "value is sealed with key version missing from keyring" error
+	ErrUnknownKey = errors.New("unknown key version")
+	// ErrMalformed is a "sealed value can not be parsed" error
+	ErrMalformed = errors.New("malformed sealed value")
+)
+
+var encoding = base64.RawURLEncoding
+
+// Keyring holds key encryption keys by version, new values are sealed with the current one
+type Keyring struct {
+	current  uint32
+	keks     map[uint32]cipher.AEAD
+	indexKey []byte
+}
+
+// New returns Keyring of keys by version, keys must be KeySize bytes and contain current
+func New(current uint32, keys map[uint32][]byte, indexKey []byte) (*Keyring, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, errors.Wrapf(ErrUnknownKey, "current key %d", current)
+	}
+	if len(indexKey) < MinIndexKeySize {
+		return nil, errors.Errorf("blind index key must have at least %d bytes", MinIndexKeySize)
+	}
+
+	k := &Keyring{
+		current:  current,
+		keks:     make(map[uint32]cipher.AEAD, len(keys)),
+		indexKey: append([]byte(nil), indexKey...),
+	}
+	for version, key := range keys {
+		if len(key) != KeySize {
+			return nil, errors.Errorf("key %d must have %d bytes", version, KeySize)
+		}
+
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "key %d", version)
+		}
+		k.keks[version] = aead
+	}
+
+	return k, nil
+}
+
+// Current returns version of key that seals new values
+func (k *Keyring) Current() uint32 {
+	return k.current
+}
+
+// Seal - encrypt plaintext under fresh data key wrapped by the current key, aad binds the value
+// to its place, e.g. column and row, and must be passed to Open unchanged
+func (k *Keyring) Seal(plaintext, aad []byte) (string, error) {
+	dataKey := make([]byte, KeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", errors.Wrap(err, "rand.Read()")
+	}
+
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	kek := k.keks[k.current]
+	wrapNonce, err := nonce(kek)
+	if err != nil {
+		return "", err
+	}
+	dataNonce, err := nonce(data)
+	if err != nil {
+		return "", err
+	}
+
+	// wrapNonce | wrapped data key | dataNonce | ciphertext
+	out := append([]byte(nil), wrapNonce...)
+	out = kek.Seal(out, wrapNonce, dataKey, aad)
+	out = append(out, dataNonce...)
+	out = data.Seal(out, dataNonce, plaintext, aad)
+
+	return Prefix + strconv.FormatUint(uint64(k.current), 10) + ":" + encoding.EncodeToString(out), nil
+}
+
+// Open - decrypt value sealed by Seal with the same aad
+func (k *Keyring) Open(sealed string, aad []byte) ([]byte, error) {
+	version, payload, err := parse(sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	kek, ok := k.keks[version]
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownKey, "key %d", version)
+	}
+
+	raw, err := encoding.DecodeString(payload)
+	if err != nil {
+		return nil, errors.Wrap(ErrMalformed, err.Error())
+	}
+
+	wrappedSize := kek.NonceSize() + KeySize + kek.Overhead()
+	if len(raw) < wrappedSize {
+		return nil, ErrMalformed
+	}
+
+	dataKey, err := kek.Open(nil, raw[:kek.NonceSize()], raw[kek.NonceSize():wrappedSize], aad)
+	if err != nil {
+		return nil, errors.Wrap(err, "unwrap data key")
+	}
+
+	data, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	raw = raw[wrappedSize:]
+	if len(raw) < data.NonceSize() {
+		return nil, ErrMalformed
+	}
+
+	plaintext, err := data.Open(nil, raw[:data.NonceSize()], raw[data.NonceSize():], aad)
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypt value")
+	}
+
+	return plaintext, nil
+}
+
+// BlindIndex returns deterministic keyed hash of value, equal values have equal indexes,
+// callers normalize values so that values they treat as equal are equal bytes
+func (k *Keyring) BlindIndex(value []byte) string {
+	mac := hmac.New(sha256.New, k.indexKey)
+	//nolint
+	mac.Write(value)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsSealed - check if value was produced by Seal
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// Version returns version of key that sealed value
+func Version(sealed string) (uint32, error) {
+	version, _, err := parse(sealed)
+
+	return version, err
+}
+
+func parse(sealed string) (uint32, string, error) {
+	if !IsSealed(sealed) {
+		return 0, "", ErrMalformed
+	}
+
+	version, payload, ok := strings.Cut(strings.TrimPrefix(sealed, Prefix), ":")
+	if !ok {
+		return 0, "", ErrMalformed
+	}
+
+	v, err := strconv.ParseUint(version, 10, 32)
+	if err != nil {
+		return 0, "", errors.Wrap(ErrMalformed, err.Error())
+	}
+
+	return uint32(v), payload, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "aes.NewCipher()")
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "cipher.NewGCM()")
+	}
+
+	return aead, nil
+}
+
+func nonce(aead cipher.AEAD) ([]byte, error) {
+	n := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(n); err != nil {
+		return nil, errors.Wrap(err, "rand.Read()")
+	}
+
+	return n, nil
+}
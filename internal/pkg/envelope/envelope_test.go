@@ -0,0 +1,186 @@
+package envelope
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, KeySize)
+}
+
+func testKeyring(t *testing.T, current uint32, keys map[uint32][]byte) *Keyring {
+	t.Helper()
+
+	k, err := New(current, keys, bytes.Repeat([]byte{9}, MinIndexKeySize))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	return k
+}
+
+func TestSealOpen(t *testing.T) {
+	k := testKeyring(t, 1, map[uint32][]byte{1: testKey(1)})
+	aad := []byte("users.email:1")
+
+	tests := []struct {
+		name      string
+		plaintext []byte
+	}{
+		{name: "email", plaintext: []byte("user@example.com")},
+		{name: "empty", plaintext: []byte{}},
+		{name: "binary", plaintext: []byte{0, 1, 2, 255}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sealed, err := k.Seal(tt.plaintext, aad)
+			if err != nil {
+				t.Fatalf("Seal() error = %v", err)
+			}
+			if !IsSealed(sealed) {
+				t.Errorf("IsSealed(%q) = false", sealed)
+			}
+			if version, err := Version(sealed); err != nil || version != 1 {
+				t.Errorf("Version() = %d, %v, want 1", version, err)
+			}
+
+			got, err := k.Open(sealed, aad)
+			if err != nil {
+				t.Fatalf("Open() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.plaintext) {
+				t.Errorf("Open() = %q, want %q", got, tt.plaintext)
+			}
+		})
+	}
+}
+
+func TestSealFreshDataKeys(t *testing.T) {
+	k := testKeyring(t, 1, map[uint32][]byte{1: testKey(1)})
+
+	a, err := k.Seal([]byte("user@example.com"), nil)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	b, err := k.Seal([]byte("user@example.com"), nil)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	if a == b {
+		t.Error("equal plaintexts are sealed to equal values")
+	}
+}
+
+func TestOpenAADMismatch(t *testing.T) {
+	k := testKeyring(t, 1, map[uint32][]byte{1: testKey(1)})
+
+	sealed, err := k.Seal([]byte("user@example.com"), []byte("users.email:1"))
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+
+	// value copied to another row does not open
+	if _, err = k.Open(sealed, []byte("users.email:2")); err == nil {
+		t.Error("Open() with other aad succeeded")
+	}
+}
+
+func TestOpenMalformed(t *testing.T) {
+	k := testKeyring(t, 1, map[uint32][]byte{1: testKey(1)})
+
+	tests := []struct {
+		name   string
+		sealed string
+	}{
+		{name: "plaintext", sealed: "user@example.com"},
+		{name: "no version", sealed: Prefix + "payload"},
+		{name: "bad version", sealed: Prefix + "x:payload"},
+		{name: "bad payload", sealed: Prefix + "1:!!!"},
+		{name: "short payload", sealed: Prefix + "1:AAAA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := k.Open(tt.sealed, nil); !errors.Is(err, ErrMalformed) {
+				t.Errorf("Open() error = %v, want ErrMalformed", err)
+			}
+		})
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	aad := []byte("users.email:1")
+	old := testKeyring(t, 1, map[uint32][]byte{1: testKey(1)})
+
+	sealed, err := old.Seal([]byte("user@example.com"), aad)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+
+	// keyring with a new current key still opens values of the old one
+	rotated := testKeyring(t, 2, map[uint32][]byte{1: testKey(1), 2: testKey(2)})
+	got, err := rotated.Open(sealed, aad)
+	if err != nil || string(got) != "user@example.com" {
+		t.Fatalf("Open() of old value = %q, %v", got, err)
+	}
+
+	resealed, err := rotated.Seal(got, aad)
+	if err != nil {
+		t.Fatalf("Seal() error = %v", err)
+	}
+	if version, _ := Version(resealed); version != 2 {
+		t.Errorf("Version() of resealed value = %d, want 2", version)
+	}
+
+	// once the old key is dropped, only resealed values open
+	current := testKeyring(t, 2, map[uint32][]byte{2: testKey(2)})
+	if _, err = current.Open(sealed, aad); !errors.Is(err, ErrUnknownKey) {
+		t.Errorf("Open() of old value error = %v, want ErrUnknownKey", err)
+	}
+	if got, err = current.Open(resealed, aad); err != nil || string(got) != "user@example.com" {
+		t.Errorf("Open() of resealed value = %q, %v", got, err)
+	}
+
+	// the same version under another key does not open
+	wrong := testKeyring(t, 2, map[uint32][]byte{2: testKey(3)})
+	if _, err = wrong.Open(resealed, aad); err == nil {
+		t.Error("Open() with wrong key succeeded")
+	}
+}
+
+func TestBlindIndex(t *testing.T) {
+	a := testKeyring(t, 1, map[uint32][]byte{1: testKey(1)})
+	b := testKeyring(t, 2, map[uint32][]byte{2: testKey(2)})
+
+	// index does not depend on key encryption keys, so rotation of them keeps it
+	if a.BlindIndex([]byte("user@example.com")) != b.BlindIndex([]byte("user@example.com")) {
+		t.Error("equal values have different indexes")
+	}
+	if a.BlindIndex([]byte("user@example.com")) == a.BlindIndex([]byte("other@example.com")) {
+		t.Error("different values have equal indexes")
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  uint32
+		keys     map[uint32][]byte
+		indexKey []byte
+	}{
+		{name: "missing current key", current: 2, keys: map[uint32][]byte{1: testKey(1)}, indexKey: testKey(9)},
+		{name: "short key", current: 1, keys: map[uint32][]byte{1: testKey(1)[:16]}, indexKey: testKey(9)},
+		{name: "short index key", current: 1, keys: map[uint32][]byte{1: testKey(1)}, indexKey: testKey(9)[:8]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.current, tt.keys, tt.indexKey); err == nil {
+				t.Error("New() succeeded")
+			}
+		})
+	}
+}
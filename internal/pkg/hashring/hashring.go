@@ -0,0 +1,120 @@
+// Package hashring maps keys to nodes with consistent hashing,
+// adding or removing a node moves only keys of neighbouring ring segments
+package hashring
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+	"strconv"
+)
+
+// DefaultVirtualNodes - points of one node on the ring, more points give more even distribution
+const DefaultVirtualNodes = 160
+
+// Ring is an immutable consistent hash ring
+type Ring struct {
+	nodes  []string
+	points []uint64
+	owners []string
+}
+
+// New returns Ring of nodes with virtualNodes points per node, DefaultVirtualNodes is used when it is not positive.
+// Placement depends only on node names, so rings of the same nodes agree in every process
+func New(nodes []string, virtualNodes int) *Ring {
+	if virtualNodes <= 0 {
+		virtualNodes = DefaultVirtualNodes
+	}
+
+	type point struct {
+		hash  uint64
+		owner string
+	}
+
+	points := make([]point, 0, len(nodes)*virtualNodes)
+	for _, node := range nodes {
+		for i := 0; i < virtualNodes; i++ {
+			points = append(points, point{hash: hashString(node + "#" + strconv.Itoa(i)), owner: node})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].hash != points[j].hash {
+			return points[i].hash < points[j].hash
+		}
+
+		return points[i].owner < points[j].owner
+	})
+
+	r := &Ring{
+		nodes:  append([]string(nil), nodes...),
+		points: make([]uint64, len(points)),
+		owners: make([]string, len(points)),
+	}
+	for i, p := range points {
+		r.points[i] = p.hash
+		r.owners[i] = p.owner
+	}
+
+	return r
+}
+
+// Nodes returns nodes of the ring
+func (r *Ring) Nodes() []string {
+	return append([]string(nil), r.nodes...)
+}
+
+// Locate returns node that owns key, empty string for ring without nodes
+func (r *Ring) Locate(key uint64) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+
+	h := hashUint64(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+
+	return r.owners[i]
+}
+
+// Group splits keys by owner nodes keeping their order
+func (r *Ring) Group(keys []uint64) map[string][]uint64 {
+	groups := make(map[string][]uint64)
+	for _, key := range keys {
+		node := r.Locate(key)
+		groups[node] = append(groups[node], key)
+	}
+
+	return groups
+}
+
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	//nolint
+	h.Write([]byte(s))
+
+	return mix(h.Sum64())
+}
+
+func hashUint64(key uint64) uint64 {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], key)
+
+	h := fnv.New64a()
+	//nolint
+	h.Write(b[:])
+
+	return mix(h.Sum64())
+}
+
+// mix - finalizer of splitmix64, fnv alone spreads short sequential keys poorly
+func mix(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+
+	return h
+}
@@ -0,0 +1,127 @@
+package hashring
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// keys - number of sequential keys placed by tests
+const keys = 100000
+
+func TestLocateStable(t *testing.T) {
+	// placement depends on node names only, not on their order or the ring instance
+	a := New([]string{"shard-a", "shard-b", "shard-c"}, 0)
+	b := New([]string{"shard-c", "shard-a", "shard-b"}, 0)
+
+	for key := uint64(0); key < keys; key++ {
+		if got, want := b.Locate(key), a.Locate(key); got != want {
+			t.Fatalf("Locate(%d) = %s, want %s", key, got, want)
+		}
+	}
+}
+
+func TestLocateEmpty(t *testing.T) {
+	if got := New(nil, 0).Locate(1); got != "" {
+		t.Errorf("Locate() = %q, want empty node of empty ring", got)
+	}
+}
+
+func TestDistribution(t *testing.T) {
+	nodes := []string{"shard-a", "shard-b", "shard-c", "shard-d"}
+	r := New(nodes, 0)
+
+	counts := make(map[string]int, len(nodes))
+	for key := uint64(0); key < keys; key++ {
+		counts[r.Locate(key)]++
+	}
+
+	want := float64(keys) / float64(len(nodes))
+	for _, node := range nodes {
+		if deviation := math.Abs(float64(counts[node])-want) / want; deviation > 0.2 {
+			t.Errorf("node %s owns %d keys, more than 20%% off even share %.0f", node, counts[node], want)
+		}
+	}
+}
+
+func TestRebalanceMovement(t *testing.T) {
+	tests := []struct {
+		name   string
+		before []string
+		after  []string
+		// changed - node that is added or removed, only its keys move
+		changed string
+	}{
+		{
+			name:    "node added",
+			before:  []string{"shard-a", "shard-b", "shard-c"},
+			after:   []string{"shard-a", "shard-b", "shard-c", "shard-d"},
+			changed: "shard-d",
+		},
+		{
+			name:    "node removed",
+			before:  []string{"shard-a", "shard-b", "shard-c", "shard-d"},
+			after:   []string{"shard-a", "shard-b", "shard-c"},
+			changed: "shard-d",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, after := New(tt.before, 0), New(tt.after, 0)
+
+			moved := 0
+			for key := uint64(0); key < keys; key++ {
+				from, to := before.Locate(key), after.Locate(key)
+				if from == to {
+					continue
+				}
+				if from != tt.changed && to != tt.changed {
+					t.Fatalf("key %d moved from %s to %s, keys of unchanged nodes must stay", key, from, to)
+				}
+				moved++
+			}
+
+			// about 1/4 of keys belong to the fourth node
+			if share := float64(moved) / keys; share < 0.15 || share > 0.35 {
+				t.Errorf("moved %.2f of keys, want about 0.25", share)
+			}
+		})
+	}
+}
+
+func TestGroup(t *testing.T) {
+	r := New([]string{"shard-a", "shard-b"}, 0)
+	input := []uint64{9, 3, 7, 1, 5}
+
+	groups := r.Group(input)
+
+	var total int
+	for node, part := range groups {
+		for i, key := range part {
+			if r.Locate(key) != node {
+				t.Errorf("key %d grouped to %s, located at %s", key, node, r.Locate(key))
+			}
+			if i > 0 && indexOf(input, part[i-1]) > indexOf(input, key) {
+				t.Errorf("group %s = %v, want order of input", node, part)
+			}
+		}
+		total += len(part)
+	}
+	if total != len(input) {
+		t.Errorf("groups = %v, want every key once", groups)
+	}
+	if !reflect.DeepEqual(r.Nodes(), []string{"shard-a", "shard-b"}) {
+		t.Errorf("Nodes() = %v", r.Nodes())
+	}
+}
+
+func indexOf(keys []uint64, key uint64) int {
+	for i, k := range keys {
+		if k == key {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,126 @@
+// Package health runs dependency checks of readiness probes and tracks background workers.
+//
+// The service has no outbox: events are written in the transaction of the change they describe,
+// so no relay has to run for the service to be ready. Readiness checks instead:
+//   - ping of every database and shard,
+//   - migrations of every database and shard applied,
+//   - cache listener running when user cache is invalidated by notifications,
+//   - key rotation running when emails are encrypted,
+//   - duplicate analyzer running when duplicate analysis is enabled.
+//
+// In-memory storage has no checks.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Check is a named dependency check, nil error means the dependency is healthy
+type Check struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// Result is an outcome of check
+type Result struct {
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty"`
+}
+
+// Run - run checks concurrently, each within timeout, and return their results in order of checks
+// and whether all of them passed
+func Run(ctx context.Context, timeout time.Duration, checks []Check) ([]Result, bool) {
+	results := make([]Result, len(checks))
+	healthy := atomic.Bool{}
+	healthy.Store(true)
+
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+
+			results[i] = Result{Name: check.Name}
+			if err := runCheck(ctx, timeout, check); err != nil {
+				results[i].Error = err.Error()
+				healthy.Store(false)
+			}
+		}(i, check)
+	}
+	wg.Wait()
+
+	return results, healthy.Load()
+}
+
+// runCheck - check that does not return in time fails, it keeps running until it returns
+func runCheck(ctx context.Context, timeout time.Duration, check Check) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- fmt.Errorf("check panicked: %v", p)
+			}
+		}()
+		done <- check.Check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Worker is a background loop that is running between start and return of Run
+type Worker struct {
+	name    string
+	running atomic.Bool
+}
+
+// NewWorker returns stopped Worker
+func NewWorker(name string) *Worker {
+	return &Worker{name: name}
+}
+
+// Run - run fn and mark worker running until fn returns
+func (w *Worker) Run(fn func()) {
+	w.running.Store(true)
+	defer w.running.Store(false)
+
+	fn()
+}
+
+// Check returns check that fails when worker is not running
+func (w *Worker) Check() Check {
+	return Check{
+		Name: w.name,
+		Check: func(ctx context.Context) error {
+			if !w.running.Load() {
+				return fmt.Errorf("%s is not running", w.name)
+			}
+
+			return nil
+		},
+	}
+}
+
+// Pinger is a database or connection pool
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// Ping returns check of connection to database
+func Ping(name string, db Pinger) Check {
+	return Check{Name: name, Check: db.PingContext}
+}
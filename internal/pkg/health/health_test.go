@@ -0,0 +1,111 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	errDown := errors.New("down")
+	block := make(chan struct{})
+	defer close(block)
+
+	tests := []struct {
+		name        string
+		checks      []Check
+		want        []Result
+		wantHealthy bool
+	}{
+		{name: "no checks", want: []Result{}, wantHealthy: true},
+		{
+			name: "healthy",
+			checks: []Check{
+				{Name: "a", Check: func(ctx context.Context) error { return nil }},
+				{Name: "b", Check: func(ctx context.Context) error { return nil }},
+			},
+			want:        []Result{{Name: "a"}, {Name: "b"}},
+			wantHealthy: true,
+		},
+		{
+			name: "failed",
+			checks: []Check{
+				{Name: "a", Check: func(ctx context.Context) error { return nil }},
+				{Name: "b", Check: func(ctx context.Context) error { return errDown }},
+			},
+			want: []Result{{Name: "a"}, {Name: "b", Error: "down"}},
+		},
+		{
+			name:   "panicked",
+			checks: []Check{{Name: "a", Check: func(ctx context.Context) error { panic("boom") }}},
+			want:   []Result{{Name: "a", Error: "check panicked: boom"}},
+		},
+		{
+			name: "timed out",
+			checks: []Check{{Name: "a", Check: func(ctx context.Context) error {
+				<-block
+				return nil
+			}}},
+			want: []Result{{Name: "a", Error: context.DeadlineExceeded.Error()}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, healthy := Run(context.Background(), 20*time.Millisecond, tt.checks)
+			if !reflect.DeepEqual(got, tt.want) || healthy != tt.wantHealthy {
+				t.Errorf("Run() = %v, %v, want %v, %v", got, healthy, tt.want, tt.wantHealthy)
+			}
+		})
+	}
+}
+
+func TestWorker(t *testing.T) {
+	w := NewWorker("analyzer")
+	check := w.Check()
+	if check.Name != "analyzer" {
+		t.Errorf("Check() name = %q, want analyzer", check.Name)
+	}
+	if err := check.Check(context.Background()); err == nil {
+		t.Error("check of stopped worker passed")
+	}
+
+	started := make(chan struct{})
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		w.Run(func() {
+			close(started)
+			<-stop
+		})
+	}()
+
+	<-started
+	if err := check.Check(context.Background()); err != nil {
+		t.Errorf("check of running worker error = %v", err)
+	}
+
+	close(stop)
+	<-done
+	if err := check.Check(context.Background()); err == nil {
+		t.Error("check of returned worker passed")
+	}
+}
+
+type pinger struct{ err error }
+
+func (p pinger) PingContext(ctx context.Context) error {
+	return p.err
+}
+
+func TestPing(t *testing.T) {
+	errDown := errors.New("down")
+
+	check := Ping("database primary", pinger{err: errDown})
+	if check.Name != "database primary" || !errors.Is(check.Check(context.Background()), errDown) {
+		t.Errorf("Ping() = %q check with error %v, want %v", check.Name, check.Check(context.Background()), errDown)
+	}
+}
@@ -0,0 +1,192 @@
+package labels
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MaxLength is a max length of label key and value
+const MaxLength = 63
+
+// Operator is a label selector operator
+type Operator string
+
+const (
+	// Equals - label exists and has the value
+	Equals Operator = "="
+	// NotEquals - label does not exist or has other value
+	NotEquals Operator = "!="
+	// Exists - label exists with any value
+	Exists Operator = "exists"
+	// DoesNotExist - label does not exist
+	DoesNotExist Operator = "!"
+)
+
+var (
+	// ErrInvalidSelector is a "unable to parse label selector" error
+	ErrInvalidSelector = errors.New("invalid label selector")
+
+	// ErrInvalidLabel is a "label key or value has wrong format" error
+	ErrInvalidLabel = errors.New("invalid label")
+
+	namePattern = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_./]*[A-Za-z0-9])?$`)
+)
+
+// Requirement is a single condition of selector, e.g. "plan=pro"
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Value    string
+}
+
+// Selector is a list of requirements joined by AND
+type Selector []Requirement
+
+// Empty - check if selector has no requirements
+func (s Selector) Empty() bool {
+	return len(s) == 0
+}
+
+// Matches - check if labels satisfy all requirements of selector
+func (s Selector) Matches(set map[string]string) bool {
+	for _, r := range s {
+		value, ok := set[r.Key]
+		switch r.Operator {
+		case Equals:
+			if !ok || value != r.Value {
+				return false
+			}
+		case NotEquals:
+			if ok && value == r.Value {
+				return false
+			}
+		case Exists:
+			if !ok {
+				return false
+			}
+		case DoesNotExist:
+			if ok {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// String - return selector in canonical form
+func (s Selector) String() string {
+	parts := make([]string, 0, len(s))
+	for _, r := range s {
+		switch r.Operator {
+		case Exists:
+			parts = append(parts, r.Key)
+		case DoesNotExist:
+			parts = append(parts, "!"+r.Key)
+		default:
+			parts = append(parts, r.Key+string(r.Operator)+r.Value)
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// Parse - parse selector like "plan=pro,region!=us,beta,!legacy"
+func Parse(selector string) (Selector, error) {
+	selector = strings.TrimSpace(selector)
+	if selector == "" {
+		return nil, nil
+	}
+
+	var result Selector
+	for _, part := range strings.Split(selector, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, errors.Wrap(ErrInvalidSelector, "empty requirement")
+		}
+
+		r, err := parseRequirement(part)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, r)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+
+	return result, nil
+}
+
+func parseRequirement(part string) (Requirement, error) {
+	var r Requirement
+
+	switch {
+	case strings.Contains(part, "!="):
+		key, value, _ := strings.Cut(part, "!=")
+		r = Requirement{Key: strings.TrimSpace(key), Operator: NotEquals, Value: strings.TrimSpace(value)}
+	case strings.Contains(part, "=="):
+		key, value, _ := strings.Cut(part, "==")
+		r = Requirement{Key: strings.TrimSpace(key), Operator: Equals, Value: strings.TrimSpace(value)}
+	case strings.Contains(part, "="):
+		key, value, _ := strings.Cut(part, "=")
+		r = Requirement{Key: strings.TrimSpace(key), Operator: Equals, Value: strings.TrimSpace(value)}
+	case strings.HasPrefix(part, "!"):
+		r = Requirement{Key: strings.TrimSpace(part[1:]), Operator: DoesNotExist}
+	default:
+		r = Requirement{Key: part, Operator: Exists}
+	}
+
+	if err := ValidateKey(r.Key); err != nil {
+		return Requirement{}, errors.Wrap(ErrInvalidSelector, fmt.Sprintf("requirement %q: %v", part, err))
+	}
+
+	if r.Operator == Equals || r.Operator == NotEquals {
+		if err := ValidateValue(r.Value); err != nil {
+			return Requirement{}, errors.Wrap(ErrInvalidSelector, fmt.Sprintf("requirement %q: %v", part, err))
+		}
+	}
+
+	return r, nil
+}
+
+// ValidateKey - check label key format
+func ValidateKey(key string) error {
+	if len(key) == 0 || len(key) > MaxLength || !namePattern.MatchString(key) {
+		return errors.Wrapf(ErrInvalidLabel, "key %q", key)
+	}
+
+	return nil
+}
+
+// ValidateValue - check label value format, empty value is allowed
+func ValidateValue(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	if len(value) > MaxLength || !namePattern.MatchString(value) {
+		return errors.Wrapf(ErrInvalidLabel, "value %q", value)
+	}
+
+	return nil
+}
+
+// Validate - check all keys and values of labels
+func Validate(set map[string]string) error {
+	for key, value := range set {
+		if err := ValidateKey(key); err != nil {
+			return err
+		}
+		if err := ValidateValue(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package labels
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     Selector
+		wantErr  bool
+	}{
+		{
+			name:     "empty",
+			selector: "  ",
+		},
+		{
+			name:     "all operators sorted by key",
+			selector: "region!=us, plan=pro,beta,!legacy,tier==gold",
+			want: Selector{
+				{Key: "beta", Operator: Exists},
+				{Key: "legacy", Operator: DoesNotExist},
+				{Key: "plan", Operator: Equals, Value: "pro"},
+				{Key: "region", Operator: NotEquals, Value: "us"},
+				{Key: "tier", Operator: Equals, Value: "gold"},
+			},
+		},
+		{
+			name:     "spaces around operator",
+			selector: "plan = pro",
+			want:     Selector{{Key: "plan", Operator: Equals, Value: "pro"}},
+		},
+		{
+			name:     "empty value",
+			selector: "plan=",
+			want:     Selector{{Key: "plan", Operator: Equals}},
+		},
+		{
+			name:     "prefixed key",
+			selector: "example.com/team=core",
+			want:     Selector{{Key: "example.com/team", Operator: Equals, Value: "core"}},
+		},
+		{
+			name:     "repeated keys keep their order",
+			selector: "plan!=free,plan",
+			want: Selector{
+				{Key: "plan", Operator: NotEquals, Value: "free"},
+				{Key: "plan", Operator: Exists},
+			},
+		},
+		{name: "empty requirement", selector: "plan=pro,,beta", wantErr: true},
+		{name: "trailing comma", selector: "plan=pro,", wantErr: true},
+		{name: "missing key", selector: "=pro", wantErr: true},
+		{name: "missing key of not exists", selector: "!", wantErr: true},
+		{name: "invalid key", selector: "-plan=pro", wantErr: true},
+		{name: "invalid value", selector: "plan=pro!", wantErr: true},
+		{name: "too long key", selector: strings.Repeat("k", MaxLength+1), wantErr: true},
+		{name: "too long value", selector: "plan=" + strings.Repeat("v", MaxLength+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.selector)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSelector) {
+					t.Fatalf("Parse(%q) error = %v, want ErrInvalidSelector", tt.selector, err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.selector, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %v, want %v", tt.selector, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectorString(t *testing.T) {
+	selector, err := Parse("region != us, plan==pro, !legacy, beta")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	const want = "beta,!legacy,plan=pro,region!=us"
+	if got := selector.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	// canonical form parses to the same selector
+	again, err := Parse(want)
+	if err != nil || !reflect.DeepEqual(again, selector) {
+		t.Errorf("Parse(String()) = %v, %v, want %v", again, err, selector)
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	set := map[string]string{"plan": "pro", "region": "eu", "beta": ""}
+
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{selector: "", want: true},
+		{selector: "plan=pro", want: true},
+		{selector: "plan=free", want: false},
+		{selector: "plan!=free", want: true},
+		{selector: "plan!=pro", want: false},
+		{selector: "missing!=x", want: true},
+		{selector: "beta", want: true},
+		{selector: "missing", want: false},
+		{selector: "!missing", want: true},
+		{selector: "!beta", want: false},
+		{selector: "beta=", want: true},
+		{selector: "plan=pro,region=eu,!legacy", want: true},
+		{selector: "plan=pro,region=us", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			selector, err := Parse(tt.selector)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := selector.Matches(set); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		set     map[string]string
+		wantErr bool
+	}{
+		{name: "valid", set: map[string]string{"plan": "pro", "example.com/team": "core_team", "beta": ""}},
+		{name: "empty key", set: map[string]string{"": "pro"}, wantErr: true},
+		{name: "key with space", set: map[string]string{"pl an": "pro"}, wantErr: true},
+		{name: "value ends with dash", set: map[string]string{"plan": "pro-"}, wantErr: true},
+		{name: "too long value", set: map[string]string{"plan": strings.Repeat("v", MaxLength+1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.set)
+			if tt.wantErr != errors.Is(err, ErrInvalidLabel) || (!tt.wantErr && err != nil) {
+				t.Errorf("Validate() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
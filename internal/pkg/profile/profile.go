@@ -0,0 +1,173 @@
+package profile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	// timezone format is checked against embedded IANA database, runtime image has no tzdata
+	_ "time/tzdata"
+
+	"github.com/pkg/errors"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+const schemaURL = "profile.schema.json"
+
+// ErrSchemaNotConfigured is a "profile schema is not configured" error
+var ErrSchemaNotConfigured = errors.New("profile schema is not configured")
+
+// FieldViolation describes a single invalid or unknown profile field
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// ValidationError is returned when profile does not match schema
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		parts = append(parts, fmt.Sprintf("%s: %s", v.Field, v.Description))
+	}
+
+	return "invalid profile: " + strings.Join(parts, "; ")
+}
+
+// Validator checks user profiles against JSON Schema
+type Validator struct {
+	schema *jsonschema.Schema
+}
+
+// NewValidatorFromFile - compile JSON Schema from file,
+// empty path returns validator that accepts only empty profiles
+func NewValidatorFromFile(path string) (*Validator, error) {
+	if path == "" {
+		return &Validator{}, nil
+	}
+
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Wrap(err, "os.Open")
+	}
+
+	//nolint
+	defer func() {
+		_ = file.Close()
+	}()
+
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat = true
+	compiler.Formats["timezone"] = isTimezone
+
+	if err := compiler.AddResource(schemaURL, file); err != nil {
+		return nil, errors.Wrap(err, "compiler.AddResource")
+	}
+
+	schema, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "compiler.Compile")
+	}
+
+	return &Validator{schema: schema}, nil
+}
+
+// Validate - check profile, unknown top-level fields are always reported
+// even if schema does not set additionalProperties to false
+func (v *Validator) Validate(profile map[string]interface{}) error {
+	if len(profile) == 0 {
+		return nil
+	}
+
+	if v == nil || v.schema == nil {
+		return ErrSchemaNotConfigured
+	}
+
+	var violations []FieldViolation
+	for key := range profile {
+		if _, ok := v.schema.Properties[key]; !ok {
+			violations = append(violations, FieldViolation{
+				Field:       fieldPath("/" + key),
+				Description: "unknown field",
+			})
+		}
+	}
+
+	if err := v.schema.Validate(profile); err != nil {
+		var validationErr *jsonschema.ValidationError
+		if !errors.As(err, &validationErr) {
+			return errors.Wrap(err, "schema.Validate")
+		}
+		violations = append(violations, collectViolations(validationErr)...)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Field < violations[j].Field
+	})
+
+	return &ValidationError{Violations: dedup(violations)}
+}
+
+func collectViolations(err *jsonschema.ValidationError) []FieldViolation {
+	// unknown top-level fields are already reported one by one in Validate
+	if err.InstanceLocation == "" && strings.HasSuffix(err.KeywordLocation, "/additionalProperties") {
+		return nil
+	}
+
+	if len(err.Causes) == 0 {
+		return []FieldViolation{{
+			Field:       fieldPath(err.InstanceLocation),
+			Description: err.Message,
+		}}
+	}
+
+	var result []FieldViolation
+	for _, cause := range err.Causes {
+		result = append(result, collectViolations(cause)...)
+	}
+
+	return result
+}
+
+func dedup(violations []FieldViolation) []FieldViolation {
+	result := violations[:0]
+	seen := make(map[FieldViolation]struct{}, len(violations))
+	for _, v := range violations {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		result = append(result, v)
+	}
+
+	return result
+}
+
+// fieldPath - convert JSON pointer like "/address/city" to "profile.address.city"
+func fieldPath(pointer string) string {
+	pointer = strings.Trim(pointer, "/")
+	if pointer == "" {
+		return "profile"
+	}
+
+	return "profile." + strings.ReplaceAll(pointer, "/", ".")
+}
+
+func isTimezone(v interface{}) bool {
+	s, ok := v.(string)
+	if !ok {
+		return true
+	}
+	_, err := time.LoadLocation(s)
+
+	return err == nil && s != "" && s != "Local"
+}
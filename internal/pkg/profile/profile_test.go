@@ -0,0 +1,165 @@
+package profile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "phone": {"type": "string", "pattern": "^\\+[1-9][0-9]{6,14}$"},
+    "timezone": {"type": "string", "format": "timezone"},
+    "date_of_birth": {"type": "string", "format": "date"},
+    "address": {
+      "type": "object",
+      "properties": {
+        "city": {"type": "string"},
+        "zip": {"type": "string", "maxLength": 5}
+      }
+    }
+  }
+}`
+
+func newTestValidator(t *testing.T) *Validator {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), schemaURL)
+	if err := os.WriteFile(path, []byte(testSchema), 0o600); err != nil {
+		t.Fatalf("write schema: %v", err)
+	}
+
+	v, err := NewValidatorFromFile(path)
+	if err != nil {
+		t.Fatalf("NewValidatorFromFile() error = %v", err)
+	}
+
+	return v
+}
+
+func TestValidate(t *testing.T) {
+	v := newTestValidator(t)
+
+	tests := []struct {
+		name    string
+		profile map[string]interface{}
+		want    []FieldViolation
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "valid",
+			profile: map[string]interface{}{
+				"phone":         "+4790000000",
+				"timezone":      "Europe/Oslo",
+				"date_of_birth": "1990-01-31",
+				"address":       map[string]interface{}{"city": "Oslo", "zip": "0150"},
+			},
+		},
+		{
+			name:    "unknown field without additionalProperties",
+			profile: map[string]interface{}{"nickname": "bob"},
+			want:    []FieldViolation{{Field: "profile.nickname", Description: "unknown field"}},
+		},
+		{
+			name:    "pattern",
+			profile: map[string]interface{}{"phone": "12345"},
+			want:    []FieldViolation{{Field: "profile.phone"}},
+		},
+		{
+			name:    "timezone format",
+			profile: map[string]interface{}{"timezone": "Mars/Olympus"},
+			want:    []FieldViolation{{Field: "profile.timezone"}},
+		},
+		{
+			name:    "local is not a timezone",
+			profile: map[string]interface{}{"timezone": "Local"},
+			want:    []FieldViolation{{Field: "profile.timezone"}},
+		},
+		{
+			name:    "date format",
+			profile: map[string]interface{}{"date_of_birth": "31.01.1990"},
+			want:    []FieldViolation{{Field: "profile.date_of_birth"}},
+		},
+		{
+			name:    "nested field",
+			profile: map[string]interface{}{"address": map[string]interface{}{"zip": "123456"}},
+			want:    []FieldViolation{{Field: "profile.address.zip"}},
+		},
+		{
+			name: "violations sorted by field",
+			profile: map[string]interface{}{
+				"timezone": "Nowhere",
+				"phone":    "1",
+				"age":      42,
+			},
+			want: []FieldViolation{
+				{Field: "profile.age", Description: "unknown field"},
+				{Field: "profile.phone"},
+				{Field: "profile.timezone"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(tt.profile)
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() error = %v, want ValidationError", err)
+			}
+
+			got := validationErr.Violations
+			if len(got) != len(tt.want) {
+				t.Fatalf("Validate() violations = %v, want %v", got, tt.want)
+			}
+			for i, want := range tt.want {
+				// descriptions of schema keywords come from jsonschema, only ours are compared
+				if got[i].Field != want.Field || got[i].Description == "" ||
+					want.Description != "" && got[i].Description != want.Description {
+					t.Errorf("violation %d = %v, want %v", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateWithoutSchema(t *testing.T) {
+	v, err := NewValidatorFromFile("")
+	if err != nil {
+		t.Fatalf("NewValidatorFromFile() error = %v", err)
+	}
+
+	if err = v.Validate(nil); err != nil {
+		t.Errorf("Validate(empty) error = %v", err)
+	}
+	if err = v.Validate(map[string]interface{}{"phone": "+4790000000"}); !errors.Is(err, ErrSchemaNotConfigured) {
+		t.Errorf("Validate() error = %v, want ErrSchemaNotConfigured", err)
+	}
+}
+
+func TestNewValidatorFromFileErrors(t *testing.T) {
+	if _, err := NewValidatorFromFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("NewValidatorFromFile(missing file) succeeded")
+	}
+
+	path := filepath.Join(t.TempDir(), "invalid.json")
+	if err := os.WriteFile(path, []byte(`{"type": 1}`), 0o600); err != nil {
+		t.Fatalf("write schema: %v", err)
+	}
+	if _, err := NewValidatorFromFile(path); err == nil {
+		t.Error("NewValidatorFromFile(invalid schema) succeeded")
+	}
+}
@@ -0,0 +1,43 @@
+package rbac
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"cmd/main.go/internal/pkg/auth"
+)
+
+// certSubjectPrefix - subjects of client certificates are "cert:<common name>",
+// so they do not collide with subjects of tokens
+const certSubjectPrefix = "cert:"
+
+// IdentityFromContext returns identity of caller: subject, roles and scopes of verified token,
+// or common name of verified client certificate when request carries no token.
+// Identity without subject is anonymous
+func IdentityFromContext(ctx context.Context) Identity {
+	if claims, ok := auth.ClaimsFromContext(ctx); ok {
+		return Identity{
+			Subject: claims.Subject,
+			Roles:   claims.Roles,
+			Scopes:  claims.Scopes(),
+		}
+	}
+
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return Identity{}
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return Identity{}
+	}
+
+	commonName := tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+	if commonName == "" {
+		return Identity{}
+	}
+
+	return Identity{Subject: certSubjectPrefix + commonName}
+}
@@ -0,0 +1,171 @@
+// Package rbac decides which gRPC methods a caller may call by roles, scopes and subject of its identity
+package rbac
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidPolicy is a "policy can not be loaded" error
+var ErrInvalidPolicy = errors.New("invalid policy")
+
+// Policy grants methods to roles and scopes, subjects get roles by their identity, e.g. "cert:reporting-job".
+// Methods are fully-qualified gRPC names "/package.Service/Method", "/package.Service/*" for every method
+// of service, or "*" for every method. Public methods are allowed to every caller, anonymous ones included
+type Policy struct {
+	Public   []string            `yaml:"public"`
+	Roles    map[string][]string `yaml:"roles"`
+	Scopes   map[string][]string `yaml:"scopes"`
+	Subjects map[string][]string `yaml:"subjects"`
+}
+
+// Identity is a caller of method
+type Identity struct {
+	Subject string
+	Roles   []string
+	Scopes  []string
+}
+
+// Decision is an outcome of access check, Reason names the rule that allowed method or why it was denied
+type Decision struct {
+	Allowed bool
+	Public  bool
+	Reason  string
+}
+
+// Engine evaluates policy
+type Engine struct {
+	public   []string
+	roles    map[string][]string
+	scopes   map[string][]string
+	subjects map[string][]string
+}
+
+// Load returns Engine of policy YAML file
+func Load(path string) (*Engine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "os.ReadFile")
+	}
+
+	var policy Policy
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err = dec.Decode(&policy); err != nil {
+		return nil, errors.Wrapf(ErrInvalidPolicy, "%s: %v", path, err)
+	}
+
+	return New(policy)
+}
+
+// New returns Engine of policy, methods of policy are validated
+func New(policy Policy) (*Engine, error) {
+	if err := validateMethods("public", policy.Public); err != nil {
+		return nil, err
+	}
+	for role, methods := range policy.Roles {
+		if err := validateMethods(fmt.Sprintf("role %s", role), methods); err != nil {
+			return nil, err
+		}
+	}
+	for scope, methods := range policy.Scopes {
+		if err := validateMethods(fmt.Sprintf("scope %s", scope), methods); err != nil {
+			return nil, err
+		}
+	}
+	for subject, roles := range policy.Subjects {
+		for _, role := range roles {
+			if _, ok := policy.Roles[role]; !ok {
+				return nil, errors.Wrapf(ErrInvalidPolicy, "subject %s: unknown role %q", subject, role)
+			}
+		}
+	}
+
+	return &Engine{
+		public:   policy.Public,
+		roles:    policy.Roles,
+		scopes:   policy.Scopes,
+		subjects: policy.Subjects,
+	}, nil
+}
+
+func validateMethods(owner string, methods []string) error {
+	for _, method := range methods {
+		if method == "*" {
+			continue
+		}
+
+		service, name, ok := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+		if !strings.HasPrefix(method, "/") || !ok || service == "" || name == "" ||
+			strings.Contains(name, "/") || (strings.Contains(name, "*") && name != "*") {
+			return errors.Wrapf(ErrInvalidPolicy, "%s: method %q, want /package.Service/Method, /package.Service/* or *", owner, method)
+		}
+	}
+
+	return nil
+}
+
+// Resolve returns identity with roles granted to its subject by policy
+func (e *Engine) Resolve(identity Identity) Identity {
+	granted := e.subjects[identity.Subject]
+	if len(granted) == 0 {
+		return identity
+	}
+
+	roles := make(map[string]bool, len(identity.Roles)+len(granted))
+	for _, role := range append(append([]string{}, identity.Roles...), granted...) {
+		roles[role] = true
+	}
+	identity.Roles = make([]string, 0, len(roles))
+	for role := range roles {
+		identity.Roles = append(identity.Roles, role)
+	}
+	sort.Strings(identity.Roles)
+
+	return identity
+}
+
+// Decide - check whether identity may call method, identity is resolved first
+func (e *Engine) Decide(identity Identity, method string) Decision {
+	if pattern, ok := match(e.public, method); ok {
+		return Decision{Allowed: true, Public: true, Reason: fmt.Sprintf("public %s", pattern)}
+	}
+
+	identity = e.Resolve(identity)
+	for _, role := range identity.Roles {
+		if pattern, ok := match(e.roles[role], method); ok {
+			return Decision{Allowed: true, Reason: fmt.Sprintf("role %s grants %s", role, pattern)}
+		}
+	}
+	for _, scope := range identity.Scopes {
+		if pattern, ok := match(e.scopes[scope], method); ok {
+			return Decision{Allowed: true, Reason: fmt.Sprintf("scope %s grants %s", scope, pattern)}
+		}
+	}
+
+	if identity.Subject == "" {
+		return Decision{Reason: "anonymous caller, method is not public"}
+	}
+
+	return Decision{Reason: "no role or scope of caller grants method"}
+}
+
+// match returns pattern of methods that matches method
+func match(patterns []string, method string) (string, bool) {
+	for _, pattern := range patterns {
+		switch {
+		case pattern == "*", pattern == method:
+			return pattern, true
+		case strings.HasSuffix(pattern, "/*") && strings.HasPrefix(method, strings.TrimSuffix(pattern, "*")):
+			return pattern, true
+		}
+	}
+
+	return "", false
+}
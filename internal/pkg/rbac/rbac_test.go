@@ -0,0 +1,213 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"cmd/main.go/internal/pkg/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	methodGetUser    = "/users.UserService/GetUser"
+	methodCreateUser = "/users.UserService/CreateUser"
+	methodAddMembers = "/users.GroupService/AddMembers"
+	methodHealth     = "/grpc.health.v1.Health/Check"
+)
+
+func testEngine(t *testing.T) *Engine {
+	t.Helper()
+
+	e, err := New(Policy{
+		Public: []string{"/grpc.health.v1.Health/*"},
+		Roles: map[string][]string{
+			"admin":   {"*"},
+			"viewer":  {methodGetUser},
+			"groups":  {"/users.GroupService/*"},
+			"creator": {methodCreateUser},
+		},
+		Scopes: map[string][]string{
+			"users:read": {methodGetUser},
+		},
+		Subjects: map[string][]string{
+			"cert:reporting-job": {"viewer"},
+			"user-7":             {"creator"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	return e
+}
+
+func TestDecide(t *testing.T) {
+	e := testEngine(t)
+
+	tests := []struct {
+		name     string
+		identity Identity
+		method   string
+		want     Decision
+	}{
+		{
+			name:   "public method for anonymous caller",
+			method: methodHealth,
+			want:   Decision{Allowed: true, Public: true, Reason: "public /grpc.health.v1.Health/*"},
+		},
+		{
+			name:   "anonymous caller is denied",
+			method: methodGetUser,
+			want:   Decision{Reason: "anonymous caller, method is not public"},
+		},
+		{
+			name:     "every method of admin",
+			identity: Identity{Subject: "user-1", Roles: []string{"admin"}},
+			method:   methodAddMembers,
+			want:     Decision{Allowed: true, Reason: "role admin grants *"},
+		},
+		{
+			name:     "wildcard service",
+			identity: Identity{Subject: "user-1", Roles: []string{"groups"}},
+			method:   methodAddMembers,
+			want:     Decision{Allowed: true, Reason: "role groups grants /users.GroupService/*"},
+		},
+		{
+			name:     "wildcard service does not grant other service",
+			identity: Identity{Subject: "user-1", Roles: []string{"groups"}},
+			method:   methodGetUser,
+			want:     Decision{Reason: "no role or scope of caller grants method"},
+		},
+		{
+			name:     "exact method",
+			identity: Identity{Subject: "user-1", Roles: []string{"viewer"}},
+			method:   methodGetUser,
+			want:     Decision{Allowed: true, Reason: "role viewer grants " + methodGetUser},
+		},
+		{
+			name:     "exact method does not grant others",
+			identity: Identity{Subject: "user-1", Roles: []string{"viewer"}},
+			method:   methodCreateUser,
+			want:     Decision{Reason: "no role or scope of caller grants method"},
+		},
+		{
+			name:     "scope",
+			identity: Identity{Subject: "client-1", Scopes: []string{"users:read"}},
+			method:   methodGetUser,
+			want:     Decision{Allowed: true, Reason: "scope users:read grants " + methodGetUser},
+		},
+		{
+			name:     "unknown role",
+			identity: Identity{Subject: "user-1", Roles: []string{"owner"}},
+			method:   methodGetUser,
+			want:     Decision{Reason: "no role or scope of caller grants method"},
+		},
+		{
+			name:     "certificate subject gets role",
+			identity: Identity{Subject: "cert:reporting-job"},
+			method:   methodGetUser,
+			want:     Decision{Allowed: true, Reason: "role viewer grants " + methodGetUser},
+		},
+		{
+			name:     "token subject gets role next to its own",
+			identity: Identity{Subject: "user-7", Roles: []string{"viewer"}},
+			method:   methodCreateUser,
+			want:     Decision{Allowed: true, Reason: "role creator grants " + methodCreateUser},
+		},
+		{
+			// denial of caller without subject points to missing authentication
+			name:     "anonymous caller with roles",
+			identity: Identity{Roles: []string{"viewer"}},
+			method:   methodCreateUser,
+			want:     Decision{Reason: "anonymous caller, method is not public"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := e.Decide(tt.identity, tt.method); got != tt.want {
+				t.Errorf("Decide() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	e := testEngine(t)
+
+	got := e.Resolve(Identity{Subject: "user-7", Roles: []string{"viewer", "creator"}, Scopes: []string{"users:read"}})
+	want := Identity{Subject: "user-7", Roles: []string{"creator", "viewer"}, Scopes: []string{"users:read"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Resolve() = %+v, want %+v", got, want)
+	}
+
+	unknown := Identity{Subject: "user-1", Roles: []string{"viewer"}}
+	if got = e.Resolve(unknown); !reflect.DeepEqual(got, unknown) {
+		t.Errorf("Resolve() = %+v, subject without roles of policy must stay as is", got)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+	}{
+		{name: "method without service", policy: Policy{Public: []string{"/GetUser"}}},
+		{name: "method without slash", policy: Policy{Roles: map[string][]string{"viewer": {"users.UserService/GetUser"}}}},
+		{name: "partial wildcard", policy: Policy{Scopes: map[string][]string{"users:read": {"/users.UserService/Get*"}}}},
+		{name: "subject of unknown role", policy: Policy{Subjects: map[string][]string{"user-1": {"owner"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.policy); !errors.Is(err, ErrInvalidPolicy) {
+				t.Errorf("New() error = %v, want ErrInvalidPolicy", err)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatalf("os.WriteFile(): %v", err)
+		}
+
+		return path
+	}
+
+	e, err := Load(write("policy.yml", "public: [\"*\"]\n"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if d := e.Decide(Identity{}, methodGetUser); !d.Allowed || !d.Public {
+		t.Errorf("Decide() = %+v, want public method", d)
+	}
+
+	if _, err = Load(write("unknown.yml", "publik: [\"*\"]\n")); !errors.Is(err, ErrInvalidPolicy) {
+		t.Errorf("Load() of unknown field error = %v, want ErrInvalidPolicy", err)
+	}
+}
+
+func TestIdentityFromContext(t *testing.T) {
+	if got := IdentityFromContext(context.Background()); !reflect.DeepEqual(got, Identity{}) {
+		t.Errorf("IdentityFromContext() = %+v, want anonymous identity", got)
+	}
+
+	claims := &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"},
+		Scope:            "users:read users:write",
+		Roles:            []string{"viewer"},
+	}
+	want := Identity{Subject: "user-1", Roles: []string{"viewer"}, Scopes: []string{"users:read", "users:write"}}
+	if got := IdentityFromContext(auth.WithClaims(context.Background(), claims)); !reflect.DeepEqual(got, want) {
+		t.Errorf("IdentityFromContext() = %+v, want %+v", got, want)
+	}
+}
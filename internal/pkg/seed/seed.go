@@ -0,0 +1,198 @@
+// Package seed reads fixture files of users and generates synthetic users for demo and integration databases,
+// users carry their ids, so loading the same fixtures or generation again finds them in place
+package seed
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Status - state of seeded user
+type Status string
+
+const (
+	// Active - user is not done
+	Active Status = "active"
+	// Done - user has done_at
+	Done Status = "done"
+)
+
+// generatedSince - created_at of the first generated user, the next ones are created a minute apart
+var generatedSince = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+var (
+	locales   = []string{"en-US", "en-GB", "de-DE", "fr-FR", "es-ES", "pt-BR", "ja-JP"}
+	timezones = []string{"America/New_York", "Europe/London", "Europe/Berlin", "Europe/Paris", "Europe/Madrid", "America/Sao_Paulo", "Asia/Tokyo"}
+)
+
+// ErrInvalidFixture is a "fixture file has wrong format" error
+var ErrInvalidFixture = errors.New("invalid fixture")
+
+// User is a user of fixture file
+type User struct {
+	ID        uint64                 `json:"id" yaml:"id"`
+	Name      string                 `json:"name" yaml:"name"`
+	Email     string                 `json:"email" yaml:"email"`
+	Labels    map[string]string      `json:"labels" yaml:"labels"`
+	Profile   map[string]interface{} `json:"profile" yaml:"profile"`
+	Status    Status                 `json:"status" yaml:"status"`
+	Deleted   bool                   `json:"deleted" yaml:"deleted"`
+	CreatedAt *time.Time             `json:"created_at" yaml:"created_at"`
+	DoneAt    *time.Time             `json:"done_at" yaml:"done_at"`
+}
+
+// file is a fixture file
+type file struct {
+	Users []User `json:"users" yaml:"users"`
+}
+
+// Load reads users of fixture file, .json files are JSON and other files are YAML.
+// Users without status are active
+func Load(path string) ([]User, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	var f file
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&f)
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&f)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidFixture, "%s: %v", path, err)
+	}
+
+	seen := make(map[uint64]bool, len(f.Users))
+	for i := range f.Users {
+		u := &f.Users[i]
+		if u.ID == 0 {
+			return nil, errors.Wrapf(ErrInvalidFixture, "%s: user %d has no id", path, i+1)
+		}
+		if seen[u.ID] {
+			return nil, errors.Wrapf(ErrInvalidFixture, "%s: id %d is repeated", path, u.ID)
+		}
+		seen[u.ID] = true
+
+		switch u.Status {
+		case "":
+			u.Status = Active
+		case Active, Done:
+		default:
+			return nil, errors.Wrapf(ErrInvalidFixture, "%s: user %d has unknown status %q", path, u.ID, u.Status)
+		}
+		if u.Status == Active && u.DoneAt != nil {
+			return nil, errors.Wrapf(ErrInvalidFixture, "%s: active user %d has done_at", path, u.ID)
+		}
+	}
+
+	return f.Users, nil
+}
+
+// Distribution - weights of statuses and share of deleted users among generated ones
+type Distribution struct {
+	Statuses map[Status]int
+	Deleted  float64
+}
+
+// ParseStatuses parses weights of statuses as "active=80,done=20"
+func ParseStatuses(value string) (map[Status]int, error) {
+	weights := make(map[Status]int)
+	total := 0
+	for _, part := range strings.Split(value, ",") {
+		name, weight, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("want status=weight, got %q", part)
+		}
+
+		status := Status(strings.TrimSpace(name))
+		if status != Active && status != Done {
+			return nil, fmt.Errorf("unknown status %q, use %s or %s", name, Active, Done)
+		}
+
+		w, err := strconv.Atoi(strings.TrimSpace(weight))
+		if err != nil || w < 0 {
+			return nil, fmt.Errorf("invalid weight %q of %s", weight, status)
+		}
+		weights[status] += w
+		total += w
+	}
+	if total == 0 {
+		return nil, errors.New("weights of statuses sum to zero")
+	}
+
+	return weights, nil
+}
+
+// Generate returns n synthetic users with ids from firstID, users depend on seed, distribution and their position only,
+// so generation of more users with the same seed starts with the same users
+func Generate(n int, firstID uint64, seed int64, dist Distribution) []User {
+	// statuses are drawn in fixed order, map order would make the users differ between runs
+	statuses := make([]Status, 0, len(dist.Statuses))
+	total := 0
+	for status, weight := range dist.Statuses {
+		statuses = append(statuses, status)
+		total += weight
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+
+	rnd := rand.New(rand.NewSource(seed)) //nolint:gosec
+	users := make([]User, n)
+	for i := range users {
+		id := firstID + uint64(i)
+		createdAt := generatedSince.Add(time.Duration(i) * time.Minute)
+		pick := rnd.Intn(len(locales))
+
+		u := User{
+			ID:    id,
+			Name:  fmt.Sprintf("Seed User %d", id),
+			Email: fmt.Sprintf("seed.user.%d@example.com", id),
+			Labels: map[string]string{
+				"source": "seed",
+				"cohort": fmt.Sprintf("c%d", rnd.Intn(4)),
+			},
+			Profile: map[string]interface{}{
+				"locale":   locales[pick],
+				"timezone": timezones[pick],
+			},
+			Status:    pickStatus(rnd, statuses, dist.Statuses, total),
+			Deleted:   rnd.Float64() < dist.Deleted,
+			CreatedAt: &createdAt,
+		}
+		if u.Status == Done {
+			doneAt := createdAt.Add(time.Duration(1+rnd.Intn(72)) * time.Hour)
+			u.DoneAt = &doneAt
+		}
+		users[i] = u
+	}
+
+	return users
+}
+
+func pickStatus(rnd *rand.Rand, statuses []Status, weights map[Status]int, total int) Status {
+	n := rnd.Intn(total)
+	for _, status := range statuses {
+		if n < weights[status] {
+			return status
+		}
+		n -= weights[status]
+	}
+
+	return statuses[len(statuses)-1]
+}
@@ -0,0 +1,164 @@
+package seed
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFixture(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name: "yaml",
+			file: "users.yml",
+			content: `users:
+  - id: 1
+    name: Bob
+    email: bob@example.com
+    labels: {team: a}
+  - id: 2
+    name: Alice
+    status: done
+    deleted: true
+    done_at: 2024-02-01T10:00:00Z
+`,
+		},
+		{
+			name: "json",
+			file: "users.JSON",
+			content: `{"users": [
+  {"id": 1, "name": "Bob", "email": "bob@example.com", "labels": {"team": "a"}},
+  {"id": 2, "name": "Alice", "status": "done", "deleted": true, "done_at": "2024-02-01T10:00:00Z"}
+]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users, err := Load(writeFixture(t, tt.file, tt.content))
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if len(users) != 2 {
+				t.Fatalf("Load() = %d users, want 2", len(users))
+			}
+
+			bob, alice := users[0], users[1]
+			if bob.Status != Active || bob.Email != "bob@example.com" || !reflect.DeepEqual(bob.Labels, map[string]string{"team": "a"}) {
+				t.Errorf("Load() user 1 = %+v", bob)
+			}
+			if alice.Status != Done || !alice.Deleted || alice.DoneAt == nil || alice.DoneAt.Month() != 2 {
+				t.Errorf("Load() user 2 = %+v", alice)
+			}
+		})
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "unknown field", content: "users:\n  - id: 1\n    password: secret\n"},
+		{name: "no id", content: "users:\n  - name: Bob\n"},
+		{name: "repeated id", content: "users:\n  - id: 1\n  - id: 1\n"},
+		{name: "unknown status", content: "users:\n  - id: 1\n    status: blocked\n"},
+		{name: "active with done_at", content: "users:\n  - id: 1\n    done_at: 2024-02-01T10:00:00Z\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(writeFixture(t, "users.yml", tt.content)); !errors.Is(err, ErrInvalidFixture) {
+				t.Errorf("Load() error = %v, want %v", err, ErrInvalidFixture)
+			}
+		})
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yml")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Load() of missing file error = %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestParseStatuses(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[Status]int
+		wantErr bool
+	}{
+		{name: "both", value: "active=80, done=20", want: map[Status]int{Active: 80, Done: 20}},
+		{name: "repeated status", value: "active=1,active=2", want: map[Status]int{Active: 3}},
+		{name: "zero weight", value: "active=0,done=1", want: map[Status]int{Active: 0, Done: 1}},
+		{name: "no weight", value: "active", wantErr: true},
+		{name: "unknown status", value: "blocked=1", wantErr: true},
+		{name: "negative weight", value: "active=-1,done=2", wantErr: true},
+		{name: "not a number", value: "active=many", wantErr: true},
+		{name: "zero sum", value: "active=0,done=0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStatuses(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseStatuses() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseStatuses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	dist := Distribution{Statuses: map[Status]int{Active: 1, Done: 1}, Deleted: 0.5}
+
+	users := Generate(50, 100, 7, dist)
+	if len(users) != 50 || users[0].ID != 100 || users[49].ID != 149 {
+		t.Fatalf("Generate() ids = %d..%d of %d users, want 100..149", users[0].ID, users[len(users)-1].ID, len(users))
+	}
+
+	statuses := make(map[Status]int)
+	deleted := 0
+	for _, u := range users {
+		statuses[u.Status]++
+		if u.Deleted {
+			deleted++
+		}
+		if (u.Status == Done) != (u.DoneAt != nil) || u.DoneAt != nil && !u.DoneAt.After(*u.CreatedAt) {
+			t.Errorf("user %d status = %s, created at = %v, done at = %v", u.ID, u.Status, u.CreatedAt, u.DoneAt)
+		}
+	}
+	if statuses[Active] == 0 || statuses[Done] == 0 || deleted == 0 || deleted == len(users) {
+		t.Errorf("Generate() statuses = %v, deleted = %d, want mix of both", statuses, deleted)
+	}
+
+	if more := Generate(80, 100, 7, dist); !reflect.DeepEqual(more[:50], users) {
+		t.Error("Generate() of more users with the same seed changed the first users")
+	}
+	if other := Generate(50, 100, 8, dist); reflect.DeepEqual(other, users) {
+		t.Error("Generate() with another seed returned the same users")
+	}
+
+	for _, u := range Generate(20, 1, 7, Distribution{Statuses: map[Status]int{Done: 1}}) {
+		if u.Status != Done || u.Deleted {
+			t.Errorf("user %d status = %s, deleted = %v, want done and not deleted", u.ID, u.Status, u.Deleted)
+		}
+	}
+}
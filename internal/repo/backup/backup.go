@@ -0,0 +1,365 @@
+// Package backup dumps users and related tables of a database to backup archive and restores them,
+// rows are written as stored, so sealed emails stay sealed and restore needs the same encryption keys
+package backup
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/pkg/backup"
+	desc "cmd/main.go/pkg/my-api"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+// ConflictPolicy - what restore does with rows whose primary key is already taken
+type ConflictPolicy string
+
+const (
+	// ConflictFail - abort restore and roll back everything restored
+	ConflictFail ConflictPolicy = "fail"
+	// ConflictSkip - keep row of database
+	ConflictSkip ConflictPolicy = "skip"
+	// ConflictOverwrite - replace row of database with row of archive
+	ConflictOverwrite ConflictPolicy = "overwrite"
+)
+
+// restoreBatchSize keeps bind parameters of one SQLite insert below its limit
+const restoreBatchSize = 500
+
+// ErrUnknownConflictPolicy is a "conflict policy is not one of fail, skip, overwrite" error
+var ErrUnknownConflictPolicy = errors.New("unknown conflict policy")
+
+// table - how rows of backed up table are read and written
+type table struct {
+	name    string
+	columns []string
+	key     []string
+	// serial - column filled by sequence on Postgres, the sequence is moved past restored ids
+	serial string
+}
+
+var tables = map[string]table{
+	backup.UsersTable: {
+		name: backup.UsersTable,
+		columns: []string{
+			"id_user", "name", "email", "created_at", "updated_at", "deleted_at", "done_at",
+			"labels", "profile", "merged_into", "email_bidx", "erased_at",
+		},
+		key: []string{"id_user"},
+	},
+	backup.GroupsTable: {
+		name:    backup.GroupsTable,
+		columns: []string{"id_group", "name", "description", "created_at", "updated_at", "deleted_at"},
+		key:     []string{"id_group"},
+		serial:  "id_group",
+	},
+	backup.GroupMembersTable: {
+		name:    backup.GroupMembersTable,
+		columns: []string{"id_group", "id_user", "created_at"},
+		key:     []string{"id_group", "id_user"},
+	},
+	backup.UserEventsTable: {
+		name:    backup.UserEventsTable,
+		columns: []string{"id", "id_user", "type", "payload", "created_at"},
+		key:     []string{"id"},
+		serial:  "id",
+	},
+	backup.ErasureReceiptsTable: {
+		name:    backup.ErasureReceiptsTable,
+		columns: []string{"id", "id_user", "reason", "summary", "erased_at"},
+		key:     []string{"id"},
+		serial:  "id",
+	},
+}
+
+// ParseConflictPolicy returns policy by name
+func ParseConflictPolicy(name string) (ConflictPolicy, error) {
+	switch policy := ConflictPolicy(name); policy {
+	case ConflictFail, ConflictSkip, ConflictOverwrite:
+		return policy, nil
+	default:
+		return "", errors.Wrapf(ErrUnknownConflictPolicy, "%q, use %s, %s or %s", name, ConflictFail, ConflictSkip, ConflictOverwrite)
+	}
+}
+
+// Dump - write rows of backed up tables of db to w in order of primary key,
+// tables are read in one read only repeatable read transaction, so they form one snapshot
+func Dump(ctx context.Context, db *sqlx.DB, w *backup.Writer) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "backup.Dump")
+	defer span.Finish()
+
+	_, err := database.WithTx(ctx, database.NewTransactor(db), func(ctx context.Context) (struct{}, error) {
+		for _, name := range backup.Tables {
+			if err := dumpTable(ctx, db, tables[name], w); err != nil {
+				return struct{}{}, errors.Wrap(err, name)
+			}
+		}
+
+		return struct{}{}, nil
+	}, database.WithIsolation(sql.LevelRepeatableRead), database.ReadOnly(), database.NoRetries())
+
+	return err
+}
+
+func dumpTable(ctx context.Context, db *sqlx.DB, t table, w *backup.Writer) error {
+	query, args, err := database.DialectOf(db).Builder().
+		Select(t.columns...).
+		From(t.name).
+		OrderBy(t.key...).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := database.Queryer(ctx, db).QueryxContext(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "db.QueryxContext()")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		record, err := scanRecord(t.name, rows)
+		if err != nil {
+			return err
+		}
+		if err = w.Write(record); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func scanRecord(name string, rows *sqlx.Rows) (*desc.BackupRecord, error) {
+	switch name {
+	case backup.UsersTable:
+		var u model.UserRequest
+		if err := rows.StructScan(&u); err != nil {
+			return nil, errors.Wrap(err, "rows.StructScan()")
+		}
+
+		return userToRecord(u)
+	case backup.GroupsTable:
+		var g model.Group
+		if err := rows.StructScan(&g); err != nil {
+			return nil, errors.Wrap(err, "rows.StructScan()")
+		}
+
+		return groupToRecord(g), nil
+	case backup.GroupMembersTable:
+		var m groupMember
+		if err := rows.StructScan(&m); err != nil {
+			return nil, errors.Wrap(err, "rows.StructScan()")
+		}
+
+		return memberToRecord(m), nil
+	case backup.UserEventsTable:
+		var e model.UserEvent
+		if err := rows.StructScan(&e); err != nil {
+			return nil, errors.Wrap(err, "rows.StructScan()")
+		}
+
+		return eventToRecord(e)
+	default:
+		var r model.ErasureReceipt
+		if err := rows.StructScan(&r); err != nil {
+			return nil, errors.Wrap(err, "rows.StructScan()")
+		}
+
+		return receiptToRecord(r)
+	}
+}
+
+// Restore - insert records of r into db in one transaction and return number of written rows by table,
+// rows skipped by ConflictSkip are not counted. Nothing is kept when archive fails verification
+func Restore(ctx context.Context, db *sqlx.DB, r *backup.Reader, policy ConflictPolicy) (map[string]int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "backup.Restore")
+	defer span.Finish()
+
+	if _, err := ParseConflictPolicy(string(policy)); err != nil {
+		return nil, err
+	}
+
+	return database.WithTx(ctx, database.NewTransactor(db), func(ctx context.Context) (map[string]int64, error) {
+		rs := &restorer{
+			db:      db,
+			dialect: database.DialectOf(db),
+			policy:  policy,
+			written: make(map[string]int64),
+		}
+
+		for {
+			record, err := r.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if err = rs.add(ctx, record); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := rs.flush(ctx); err != nil {
+			return nil, err
+		}
+
+		return rs.written, rs.moveSequences(ctx)
+	}, database.NoRetries())
+}
+
+// restorer - collects records of one table into multi-row inserts
+type restorer struct {
+	db      *sqlx.DB
+	dialect *database.Dialect
+	policy  ConflictPolicy
+	written map[string]int64
+
+	table  table
+	values [][]interface{}
+}
+
+func (rs *restorer) add(ctx context.Context, record *desc.BackupRecord) error {
+	name := backup.TableOf(record)
+	if name != rs.table.name || len(rs.values) >= restoreBatchSize {
+		if err := rs.flush(ctx); err != nil {
+			return err
+		}
+		rs.table = tables[name]
+	}
+
+	values, err := recordValues(record)
+	if err != nil {
+		return err
+	}
+	rs.values = append(rs.values, values)
+
+	return nil
+}
+
+func (rs *restorer) flush(ctx context.Context) error {
+	if len(rs.values) == 0 {
+		return nil
+	}
+
+	ib := rs.dialect.Builder().
+		Insert(rs.table.name).
+		Columns(rs.table.columns...)
+	for _, values := range rs.values {
+		ib = ib.Values(values...)
+	}
+	if suffix := rs.conflictClause(); suffix != "" {
+		ib = ib.Suffix(suffix)
+	}
+
+	query, args, err := ib.ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := database.Queryer(ctx, rs.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return errors.Wrapf(err, "db.ExecContext(%s)", rs.table.name)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "repo.RowsAffected()")
+	}
+	rs.written[rs.table.name] += affected
+	rs.values = rs.values[:0]
+
+	return nil
+}
+
+// conflictClause - ON CONFLICT clause of policy, the clause is understood by Postgres and SQLite
+func (rs *restorer) conflictClause() string {
+	key := strings.Join(rs.table.key, ", ")
+
+	switch rs.policy {
+	case ConflictSkip:
+		return fmt.Sprintf("ON CONFLICT (%s) DO NOTHING", key)
+	case ConflictOverwrite:
+		isKey := make(map[string]bool, len(rs.table.key))
+		for _, column := range rs.table.key {
+			isKey[column] = true
+		}
+
+		var set []string
+		for _, column := range rs.table.columns {
+			if !isKey[column] {
+				set = append(set, fmt.Sprintf("%s = excluded.%s", column, column))
+			}
+		}
+
+		return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s", key, strings.Join(set, ", "))
+	default:
+		return ""
+	}
+}
+
+// moveSequences - ids were inserted explicitly, so Postgres sequences must continue after them.
+// SQLite moves AUTOINCREMENT counters by itself
+func (rs *restorer) moveSequences(ctx context.Context) error {
+	if rs.dialect != database.Postgres {
+		return nil
+	}
+
+	for _, name := range backup.Tables {
+		t := tables[name]
+		if t.serial == "" || rs.written[name] == 0 {
+			continue
+		}
+
+		query := fmt.Sprintf("SELECT setval(pg_get_serial_sequence($1, $2), MAX(%s)) FROM %s", t.serial, t.name)
+		if _, err := database.Queryer(ctx, rs.db).ExecContext(ctx, query, t.name, t.serial); err != nil {
+			return errors.Wrapf(err, "db.ExecContext(setval %s)", t.name)
+		}
+	}
+
+	return nil
+}
+
+// recordValues - values of record in order of columns of its table
+func recordValues(record *desc.BackupRecord) ([]interface{}, error) {
+	switch row := record.GetRow().(type) {
+	case *desc.BackupRecord_User:
+		u := userFromRecord(row.User)
+
+		return []interface{}{
+			u.ID_user, u.Name, u.Email, u.CreatedAt, u.UpdatedAt, u.DeletedAt, u.DoneAt,
+			u.Labels, u.Profile, u.MergedInto, u.EmailBlindIndex, u.ErasedAt,
+		}, nil
+	case *desc.BackupRecord_Group:
+		g := groupFromRecord(row.Group)
+
+		return []interface{}{g.ID_group, g.Name, g.Description, g.CreatedAt, g.UpdatedAt, g.DeletedAt}, nil
+	case *desc.BackupRecord_GroupMember:
+		m := memberFromRecord(row.GroupMember)
+
+		return []interface{}{m.GroupID, m.UserID, m.CreatedAt}, nil
+	case *desc.BackupRecord_UserEvent:
+		e := eventFromRecord(row.UserEvent)
+
+		return []interface{}{e.ID, e.UserID, string(e.Type), e.Payload, e.CreatedAt}, nil
+	case *desc.BackupRecord_ErasureReceipt:
+		receipt, err := receiptFromRecord(row.ErasureReceipt)
+		if err != nil {
+			return nil, err
+		}
+
+		return []interface{}{receipt.ID, receipt.UserID, receipt.Reason, receipt.Summary, receipt.ErasedAt}, nil
+	default:
+		return nil, errors.New("record has no row")
+	}
+}
@@ -0,0 +1,178 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"cmd/main.go/internal/database/dbtest"
+	"cmd/main.go/internal/pkg/backup"
+	desc "cmd/main.go/pkg/my-api"
+
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestParseConflictPolicy(t *testing.T) {
+	for _, name := range []string{"fail", "skip", "overwrite"} {
+		if got, err := ParseConflictPolicy(name); err != nil || string(got) != name {
+			t.Errorf("ParseConflictPolicy(%s) = %v, %v", name, got, err)
+		}
+	}
+
+	if _, err := ParseConflictPolicy("merge"); !errors.Is(err, ErrUnknownConflictPolicy) {
+		t.Errorf("ParseConflictPolicy() error = %v, want %v", err, ErrUnknownConflictPolicy)
+	}
+}
+
+// testDB returns SQLite DB with two users, one group of the second user and one event
+func testDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	db := dbtest.SQLite(t)
+	for _, query := range []string{
+		`INSERT INTO users (id_user, name, email) VALUES (1, 'Bob', 'bob@example.com'), (2, 'Alice', 'alice@example.com')`,
+		`INSERT INTO groups (id_group, name) VALUES (1, 'team')`,
+		`INSERT INTO group_members (id_group, id_user) VALUES (1, 2)`,
+		`INSERT INTO users_events (id_user, type, payload) VALUES (2, 'created', '{"name":"Alice"}')`,
+	} {
+		if _, err := db.Exec(query); err != nil {
+			t.Fatalf("db.Exec(%s): %v", query, err)
+		}
+	}
+
+	return db
+}
+
+func dump(t *testing.T, db *sqlx.DB) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := backup.NewWriter(&buf, 8, db.DriverName())
+	if err != nil {
+		t.Fatalf("NewWriter() error = %v", err)
+	}
+	if err = Dump(context.Background(), db, w); err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func restore(t *testing.T, db *sqlx.DB, archive []byte, policy ConflictPolicy) (map[string]int64, error) {
+	t.Helper()
+
+	r, err := backup.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+
+	return Restore(context.Background(), db, r, policy)
+}
+
+func names(t *testing.T, db *sqlx.DB) []string {
+	t.Helper()
+
+	var got []string
+	if err := db.Select(&got, "SELECT name FROM users ORDER BY id_user"); err != nil {
+		t.Fatalf("select users: %v", err)
+	}
+
+	return got
+}
+
+func TestDumpRestore(t *testing.T) {
+	archive := dump(t, testDB(t))
+
+	db := dbtest.SQLite(t)
+	written, err := restore(t, db, archive, ConflictFail)
+	if err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	want := map[string]int64{
+		backup.UsersTable:        2,
+		backup.GroupsTable:       1,
+		backup.GroupMembersTable: 1,
+		backup.UserEventsTable:   1,
+	}
+	if !reflect.DeepEqual(written, want) {
+		t.Errorf("Restore() = %v, want %v", written, want)
+	}
+
+	got, wantRecords := records(t, dump(t, db)), records(t, archive)
+	if len(got) != len(wantRecords) {
+		t.Fatalf("dump of restored database has %d records, want %d", len(got), len(wantRecords))
+	}
+	for i := range wantRecords {
+		if !proto.Equal(got[i], wantRecords[i]) {
+			t.Errorf("restored record %d = %v, want %v", i, got[i], wantRecords[i])
+		}
+	}
+}
+
+func records(t *testing.T, archive []byte) []*desc.BackupRecord {
+	t.Helper()
+
+	r, err := backup.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("NewReader() error = %v", err)
+	}
+
+	var got []*desc.BackupRecord
+	for {
+		record, err := r.Next()
+		if err == io.EOF {
+			return got
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		got = append(got, record)
+	}
+}
+
+func TestRestoreConflicts(t *testing.T) {
+	archive := dump(t, testDB(t))
+
+	tests := []struct {
+		name      string
+		policy    ConflictPolicy
+		wantUsers int64
+		wantNames []string
+		wantErr   bool
+	}{
+		{name: "fail", policy: ConflictFail, wantNames: []string{"Robert"}, wantErr: true},
+		{name: "skip", policy: ConflictSkip, wantUsers: 1, wantNames: []string{"Robert", "Alice"}},
+		{name: "overwrite", policy: ConflictOverwrite, wantUsers: 2, wantNames: []string{"Bob", "Alice"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := dbtest.SQLite(t)
+			if _, err := db.Exec(`INSERT INTO users (id_user, name) VALUES (1, 'Robert')`); err != nil {
+				t.Fatalf("insert user: %v", err)
+			}
+
+			written, err := restore(t, db, archive, tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Restore() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := written[backup.UsersTable]; got != tt.wantUsers {
+				t.Errorf("Restore() users = %d, want %d", got, tt.wantUsers)
+			}
+			if got := names(t, db); !reflect.DeepEqual(got, tt.wantNames) {
+				t.Errorf("users = %v, want %v", got, tt.wantNames)
+			}
+		})
+	}
+
+	if _, err := restore(t, dbtest.SQLite(t), archive, "merge"); !errors.Is(err, ErrUnknownConflictPolicy) {
+		t.Errorf("Restore() error = %v, want %v", err, ErrUnknownConflictPolicy)
+	}
+}
@@ -0,0 +1,164 @@
+package backup
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"cmd/main.go/internal/model"
+	desc "cmd/main.go/pkg/my-api"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// groupMember is a row of group_members, the table has no model of its own
+type groupMember struct {
+	GroupID   uint64    `db:"id_group"`
+	UserID    uint64    `db:"id_user"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func userToRecord(u model.UserRequest) (*desc.BackupRecord, error) {
+	profile, err := structpb.NewStruct(u.Profile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "profile of user %d", u.ID_user)
+	}
+
+	user := &desc.BackupUser{
+		IdUser:    u.ID_user,
+		Name:      u.Name,
+		Email:     u.Email,
+		CreatedAt: timestamppb.New(u.CreatedAt),
+		UpdatedAt: model.ConvertNullableTimeToPb(u.UpdatedAt),
+		DeletedAt: model.ConvertNullableTimeToPb(u.DeletedAt),
+		DoneAt:    model.ConvertNullableTimeToPb(u.DoneAt),
+		Labels:    u.Labels,
+		Profile:   profile,
+		ErasedAt:  model.ConvertNullableTimeToPb(u.ErasedAt),
+	}
+	if u.MergedInto.Valid {
+		user.MergedInto = &u.MergedInto.Int64
+	}
+	if u.EmailBlindIndex.Valid {
+		user.EmailBidx = &u.EmailBlindIndex.String
+	}
+
+	return &desc.BackupRecord{Row: &desc.BackupRecord_User{User: user}}, nil
+}
+
+func userFromRecord(user *desc.BackupUser) model.UserRequest {
+	u := model.UserRequest{
+		ID_user:   user.GetIdUser(),
+		Name:      user.GetName(),
+		Email:     user.GetEmail(),
+		CreatedAt: user.GetCreatedAt().AsTime(),
+		UpdatedAt: model.ConvertPbTimeToNullableTime(user.GetUpdatedAt()),
+		DeletedAt: model.ConvertPbTimeToNullableTime(user.GetDeletedAt()),
+		DoneAt:    model.ConvertPbTimeToNullableTime(user.GetDoneAt()),
+		Labels:    user.GetLabels(),
+		Profile:   user.GetProfile().AsMap(),
+		ErasedAt:  model.ConvertPbTimeToNullableTime(user.GetErasedAt()),
+	}
+	if user.MergedInto != nil {
+		u.MergedInto = sql.NullInt64{Int64: user.GetMergedInto(), Valid: true}
+	}
+	if user.EmailBidx != nil {
+		u.EmailBlindIndex = sql.NullString{String: user.GetEmailBidx(), Valid: true}
+	}
+
+	return u
+}
+
+func groupToRecord(g model.Group) *desc.BackupRecord {
+	return &desc.BackupRecord{Row: &desc.BackupRecord_Group{Group: &desc.BackupGroup{
+		IdGroup:     g.ID_group,
+		Name:        g.Name,
+		Description: g.Description,
+		CreatedAt:   timestamppb.New(g.CreatedAt),
+		UpdatedAt:   model.ConvertNullableTimeToPb(g.UpdatedAt),
+		DeletedAt:   model.ConvertNullableTimeToPb(g.DeletedAt),
+	}}}
+}
+
+func groupFromRecord(group *desc.BackupGroup) model.Group {
+	return model.Group{
+		ID_group:    group.GetIdGroup(),
+		Name:        group.GetName(),
+		Description: group.GetDescription(),
+		CreatedAt:   group.GetCreatedAt().AsTime(),
+		UpdatedAt:   model.ConvertPbTimeToNullableTime(group.GetUpdatedAt()),
+		DeletedAt:   model.ConvertPbTimeToNullableTime(group.GetDeletedAt()),
+	}
+}
+
+func memberToRecord(m groupMember) *desc.BackupRecord {
+	return &desc.BackupRecord{Row: &desc.BackupRecord_GroupMember{GroupMember: &desc.BackupGroupMember{
+		IdGroup:   m.GroupID,
+		IdUser:    m.UserID,
+		CreatedAt: timestamppb.New(m.CreatedAt),
+	}}}
+}
+
+func memberFromRecord(member *desc.BackupGroupMember) groupMember {
+	return groupMember{
+		GroupID:   member.GetIdGroup(),
+		UserID:    member.GetIdUser(),
+		CreatedAt: member.GetCreatedAt().AsTime(),
+	}
+}
+
+func eventToRecord(e model.UserEvent) (*desc.BackupRecord, error) {
+	payload, err := structpb.NewStruct(e.Payload)
+	if err != nil {
+		return nil, errors.Wrapf(err, "payload of event %d", e.ID)
+	}
+
+	return &desc.BackupRecord{Row: &desc.BackupRecord_UserEvent{UserEvent: &desc.BackupUserEvent{
+		Id:        e.ID,
+		IdUser:    e.UserID,
+		Type:      string(e.Type),
+		Payload:   payload,
+		CreatedAt: timestamppb.New(e.CreatedAt),
+	}}}, nil
+}
+
+func eventFromRecord(event *desc.BackupUserEvent) model.UserEvent {
+	return model.UserEvent{
+		ID:        event.GetId(),
+		UserID:    event.GetIdUser(),
+		Type:      model.EventType(event.GetType()),
+		Payload:   event.GetPayload().AsMap(),
+		CreatedAt: event.GetCreatedAt().AsTime(),
+	}
+}
+
+func receiptToRecord(r model.ErasureReceipt) (*desc.BackupRecord, error) {
+	summary, err := json.Marshal(r.Summary)
+	if err != nil {
+		return nil, errors.Wrapf(err, "summary of erasure receipt %d", r.ID)
+	}
+
+	return &desc.BackupRecord{Row: &desc.BackupRecord_ErasureReceipt{ErasureReceipt: &desc.BackupErasureReceipt{
+		Id:       r.ID,
+		IdUser:   r.UserID,
+		Reason:   r.Reason,
+		Summary:  string(summary),
+		ErasedAt: timestamppb.New(r.ErasedAt),
+	}}}, nil
+}
+
+func receiptFromRecord(receipt *desc.BackupErasureReceipt) (model.ErasureReceipt, error) {
+	r := model.ErasureReceipt{
+		ID:       receipt.GetId(),
+		UserID:   receipt.GetIdUser(),
+		Reason:   receipt.GetReason(),
+		ErasedAt: receipt.GetErasedAt().AsTime(),
+	}
+	if err := r.Summary.Scan(receipt.GetSummary()); err != nil {
+		return r, errors.Wrapf(err, "summary of erasure receipt %d", r.ID)
+	}
+
+	return r, nil
+}
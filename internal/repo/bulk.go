@@ -0,0 +1,233 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+const (
+	userRequestStagingTable     = "users_staging"
+	userRequestStagingOrdColumn = "ord"
+
+	// userRequestInsertBatchSize keeps bind parameters of one SQLite insert below its limit
+	userRequestInsertBatchSize = 1000
+)
+
+// createUserRequestStagingQuery - staging table lives until the end of bulk insert transaction,
+// ord keeps position of row in the batch, so the first of repeated ids wins
+var createUserRequestStagingQuery = fmt.Sprintf(
+	"CREATE TEMP TABLE %s (LIKE %s INCLUDING DEFAULTS, %s BIGINT NOT NULL) ON COMMIT DROP",
+	userRequestStagingTable, userRequestTable, userRequestStagingOrdColumn,
+)
+
+var userRequestBulkColumns = []string{
+	userRequestIDColumn,
+	userRequestNameColumn,
+	userRequestEmailColumn,
+	userRequestCreatedAtColumn,
+	userRequestUpdatedAtColumn,
+	userRequestDeletedAtAtColumn,
+	userRequestDoneAtColumn,
+	userRequestLabelsColumn,
+	userRequestProfileColumn,
+	userRequestEmailIndexColumn,
+}
+
+var (
+	// ErrBulkInTx - COPY runs on its own connection, so bulk insert can not join transaction from ctx
+	ErrBulkInTx = errors.New("bulk insert can not run in transaction from context")
+	// ErrBulkNotSupported - COPY is available only with pgx and pgxpool drivers, SQLite inserts batches instead
+	ErrBulkNotSupported = errors.New("bulk insert requires pgx or sqlite3 driver")
+)
+
+// BulkCreateUserRequest - stream users with COPY into staging table and merge them into users in one transaction,
+// returns ids of inserted users and ids that were taken, repeated ids of the batch are conflicts after the first one
+func (r *userRequestRepo) BulkCreateUserRequest(ctx context.Context, userRequests []model.UserRequest) ([]uint64, []uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.BulkCreateUserRequest")
+	defer span.Finish()
+	defer database.QueryTimer("repo.BulkCreateUserRequest").ObserveDuration()
+
+	if len(userRequests) == 0 {
+		return nil, nil, nil
+	}
+
+	if database.TxFromContext(ctx) != nil {
+		return nil, nil, ErrBulkInTx
+	}
+
+	sealed, err := r.sealUsers(userRequests)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if r.dialect == database.SQLite {
+		return r.insertUserRequestBatches(ctx, sealed)
+	}
+
+	mergeQuery, _, err := r.dialect.Builder().
+		Insert(userRequestTable).
+		Columns(userRequestBulkColumns...).
+		Select(sq.Select(userRequestBulkColumns...).
+			Options(fmt.Sprintf("DISTINCT ON (%s)", userRequestIDColumn)).
+			From(userRequestStagingTable).
+			OrderBy(userRequestIDColumn, userRequestStagingOrdColumn)).
+		Suffix(fmt.Sprintf("ON CONFLICT (%s) DO NOTHING RETURNING %s", userRequestIDColumn, userRequestIDColumn)).
+		ToSql()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "db.Conn()")
+	}
+	//nolint
+	defer conn.Close()
+
+	var inserted []uint64
+	err = conn.Raw(func(driverConn interface{}) error {
+		c, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return ErrBulkNotSupported
+		}
+
+		return pgx.BeginFunc(ctx, c.Conn(), func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, createUserRequestStagingQuery); err != nil {
+				return errors.Wrap(err, "tx.Exec(create staging)")
+			}
+
+			columns := append(append([]string(nil), userRequestBulkColumns...), userRequestStagingOrdColumn)
+			source := pgx.CopyFromSlice(len(sealed), func(i int) ([]interface{}, error) {
+				u := sealed[i]
+
+				return []interface{}{
+					u.ID_user,
+					u.Name,
+					u.Email,
+					u.CreatedAt,
+					u.UpdatedAt,
+					u.DeletedAt,
+					u.DoneAt,
+					u.Labels,
+					u.Profile,
+					u.EmailBlindIndex,
+					i,
+				}, nil
+			})
+			if _, err := tx.CopyFrom(ctx, pgx.Identifier{userRequestStagingTable}, columns, source); err != nil {
+				return errors.Wrap(err, "tx.CopyFrom()")
+			}
+
+			// staging table is recreated by every call, so the statement must not be cached
+			rows, err := tx.Query(ctx, mergeQuery, pgx.QueryExecModeExec)
+			if err != nil {
+				return errors.Wrap(err, "tx.Query(merge)")
+			}
+
+			inserted, err = pgx.CollectRows(rows, pgx.RowTo[uint64])
+			if err != nil {
+				return errors.Wrap(err, "pgx.CollectRows()")
+			}
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sort.Slice(inserted, func(i, j int) bool { return inserted[i] < inserted[j] })
+
+	return inserted, bulkConflicts(userRequests, inserted), nil
+}
+
+// insertUserRequestBatches - SQLite has no COPY, users are inserted with multi-row INSERT statements
+// in one transaction, rows are inserted in order of the batch, so the first of repeated ids wins
+func (r *userRequestRepo) insertUserRequestBatches(ctx context.Context, userRequests []model.UserRequest) ([]uint64, []uint64, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "db.BeginTxx()")
+	}
+	//nolint
+	defer tx.Rollback()
+
+	var inserted []uint64
+	for start := 0; start < len(userRequests); start += userRequestInsertBatchSize {
+		end := start + userRequestInsertBatchSize
+		if end > len(userRequests) {
+			end = len(userRequests)
+		}
+
+		sb := r.dialect.Builder().
+			Insert(userRequestTable).
+			Columns(userRequestBulkColumns...).
+			Suffix(fmt.Sprintf("ON CONFLICT (%s) DO NOTHING RETURNING %s", userRequestIDColumn, userRequestIDColumn))
+		for _, u := range userRequests[start:end] {
+			sb = sb.Values(u.ID_user, u.Name, u.Email, u.CreatedAt, u.UpdatedAt, u.DeletedAt, u.DoneAt, u.Labels, u.Profile, u.EmailBlindIndex)
+		}
+
+		query, args, err := sb.ToSql()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var ids []uint64
+		if err = tx.SelectContext(ctx, &ids, query, args...); err != nil {
+			return nil, nil, errors.Wrap(err, "tx.SelectContext()")
+		}
+		inserted = append(inserted, ids...)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, errors.Wrap(err, "Tx.Commit")
+	}
+
+	sort.Slice(inserted, func(i, j int) bool { return inserted[i] < inserted[j] })
+
+	return inserted, bulkConflicts(userRequests, inserted), nil
+}
+
+// sealUsers returns copies of users with sealed emails
+func (r *userRequestRepo) sealUsers(userRequests []model.UserRequest) ([]model.UserRequest, error) {
+	sealed := make([]model.UserRequest, len(userRequests))
+	for i, u := range userRequests {
+		email, emailIndex, err := r.fields.SealEmail(u.ID_user, u.Email)
+		if err != nil {
+			return nil, err
+		}
+		u.Email = email
+		u.EmailBlindIndex = emailIndex
+		sealed[i] = u
+	}
+
+	return sealed, nil
+}
+
+// bulkConflicts - ids of the batch that were not inserted, in order of the batch
+func bulkConflicts(userRequests []model.UserRequest, inserted []uint64) []uint64 {
+	pending := make(map[uint64]struct{}, len(inserted))
+	for _, id := range inserted {
+		pending[id] = struct{}{}
+	}
+
+	var conflicts []uint64
+	for _, u := range userRequests {
+		if _, ok := pending[u.ID_user]; ok {
+			delete(pending, u.ID_user)
+			continue
+		}
+		conflicts = append(conflicts, u.ID_user)
+	}
+
+	return conflicts
+}
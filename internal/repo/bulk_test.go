@@ -0,0 +1,209 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"cmd/main.go/internal/database/dbtest"
+	"cmd/main.go/internal/model"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// benchRows - users inserted by one iteration of benchmarks
+const benchRows = 1000
+
+func TestBulkConflicts(t *testing.T) {
+	tests := []struct {
+		name      string
+		batch     []uint64
+		inserted  []uint64
+		conflicts []uint64
+	}{
+		{
+			name:     "all inserted",
+			batch:    []uint64{3, 1, 2},
+			inserted: []uint64{1, 2, 3},
+		},
+		{
+			name:      "repeated ids of batch",
+			batch:     []uint64{1, 2, 1, 2, 1},
+			inserted:  []uint64{1, 2},
+			conflicts: []uint64{1, 2, 1},
+		},
+		{
+			name:      "taken ids",
+			batch:     []uint64{5, 6, 7},
+			inserted:  []uint64{6},
+			conflicts: []uint64{5, 7},
+		},
+		{
+			name:      "taken and repeated ids",
+			batch:     []uint64{5, 6, 5, 6},
+			inserted:  []uint64{6},
+			conflicts: []uint64{5, 5, 6},
+		},
+		{
+			name:      "nothing inserted",
+			batch:     []uint64{1, 1},
+			conflicts: []uint64{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := make([]model.UserRequest, 0, len(tt.batch))
+			for _, id := range tt.batch {
+				users = append(users, model.UserRequest{ID_user: id})
+			}
+
+			if got := bulkConflicts(users, tt.inserted); !reflect.DeepEqual(got, tt.conflicts) {
+				t.Errorf("bulkConflicts() = %v, want %v", got, tt.conflicts)
+			}
+		})
+	}
+}
+
+func TestBulkCreateUserRequest(t *testing.T) {
+	backends := []struct {
+		name string
+		open func(tb testing.TB) *sqlx.DB
+	}{
+		{name: "sqlite", open: dbtest.SQLite},
+		{name: "postgres", open: dbtest.Postgres},
+	}
+
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			db := backend.open(t)
+			r := NewUserRequestRepo(dbtest.Cluster(t, db), 0, nil)
+			ctx := context.Background()
+
+			fromID := freeIDs(t, db, 4)
+			taken, first, second := fromID, fromID+1, fromID+2
+
+			if _, err := r.CreateUserRequest(ctx, &model.UserRequest{ID_user: taken, Name: "taken", Email: "taken@example.com"}); err != nil {
+				t.Fatalf("CreateUserRequest() error = %v", err)
+			}
+
+			batch := newBenchUsers(fromID, 3)
+			batch = append(batch, batch[1], batch[0])
+			batch[3].Name = "repeated"
+
+			inserted, conflicts, err := r.BulkCreateUserRequest(ctx, batch)
+			if err != nil {
+				t.Fatalf("BulkCreateUserRequest() error = %v", err)
+			}
+			if want := []uint64{first, second}; !reflect.DeepEqual(inserted, want) {
+				t.Errorf("inserted = %v, want %v", inserted, want)
+			}
+			if want := []uint64{taken, first, taken}; !reflect.DeepEqual(conflicts, want) {
+				t.Errorf("conflicts = %v, want %v", conflicts, want)
+			}
+
+			users, err := r.GetUserByIdRequest(ctx, []uint64{taken, first})
+			if err != nil {
+				t.Fatalf("GetUserByIdRequest() error = %v", err)
+			}
+			names := make(map[uint64]string, len(users))
+			for _, u := range users {
+				names[u.ID_user] = u.Name
+			}
+			if names[taken] != "taken" || names[first] != batch[1].Name {
+				t.Errorf("names = %v, the first of repeated ids must win and taken ids must be kept", names)
+			}
+		})
+	}
+}
+
+func BenchmarkCreateUserRequest(b *testing.B) {
+	db := dbtest.Postgres(b)
+	r := NewUserRequestRepo(dbtest.Cluster(b, db), 0, nil)
+	ctx := context.Background()
+
+	fromID := freeIDs(b, db, benchRows)
+	users := newBenchUsers(fromID, benchRows)
+
+	benchmarkInsert(b, db, fromID, func() error {
+		for i := range users {
+			if _, err := r.CreateUserRequest(ctx, &users[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func BenchmarkBulkCreateUserRequest(b *testing.B) {
+	db := dbtest.Postgres(b)
+	r := NewUserRequestRepo(dbtest.Cluster(b, db), 0, nil)
+	ctx := context.Background()
+
+	fromID := freeIDs(b, db, benchRows)
+	users := newBenchUsers(fromID, benchRows)
+
+	benchmarkInsert(b, db, fromID, func() error {
+		_, conflicts, err := r.BulkCreateUserRequest(ctx, users)
+		if err == nil && len(conflicts) > 0 {
+			err = fmt.Errorf("%d ids are taken", len(conflicts))
+		}
+
+		return err
+	})
+}
+
+// benchmarkInsert - run insert of benchRows users from fromID, inserted users are deleted
+// after every iteration out of the timer
+func benchmarkInsert(b *testing.B, db *sqlx.DB, fromID uint64, insert func() error) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if err := insert(); err != nil {
+			b.Fatalf("insert: %v", err)
+		}
+
+		b.StopTimer()
+		deleteUsers(b, db, fromID, benchRows)
+		b.StartTimer()
+	}
+
+	b.ReportMetric(float64(b.N*benchRows)/b.Elapsed().Seconds(), "rows/s")
+}
+
+// freeIDs returns the first of n ids that are not used by earlier runs against the same database,
+// users with them are deleted when the test ends
+func freeIDs(tb testing.TB, db *sqlx.DB, n int) uint64 {
+	fromID := uint64(time.Now().UnixNano()/1000) * 1000
+	tb.Cleanup(func() { deleteUsers(tb, db, fromID, n) })
+
+	return fromID
+}
+
+func deleteUsers(tb testing.TB, db *sqlx.DB, fromID uint64, n int) {
+	query := db.Rebind("DELETE FROM " + userRequestTable + " WHERE " + userRequestIDColumn + " >= ? AND " + userRequestIDColumn + " < ?")
+	if _, err := db.ExecContext(context.Background(), query, fromID, fromID+uint64(n)); err != nil {
+		tb.Fatalf("delete users: %v", err)
+	}
+}
+
+func newBenchUsers(fromID uint64, n int) []model.UserRequest {
+	now := time.Now()
+	users := make([]model.UserRequest, n)
+	for i := range users {
+		users[i] = model.UserRequest{
+			ID_user:   fromID + uint64(i),
+			Name:      fmt.Sprintf("bench user %d", i),
+			Email:     fmt.Sprintf("bench.user.%d@example.com", i),
+			CreatedAt: now,
+			Labels:    model.Labels{"source": "bench"},
+			Profile:   model.Profile{},
+		}
+	}
+
+	return users
+}
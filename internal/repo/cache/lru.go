@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"cmd/main.go/internal/model"
+)
+
+type entry struct {
+	user      model.UserRequest
+	expiresAt time.Time
+}
+
+// lru is a size-bounded cache of users with expiration, stored users are never handed out, only their clones
+type lru struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	order *list.List
+	items map[uint64]*list.Element
+	// generation changes on every invalidation, users loaded before it are not stored
+	generation uint64
+}
+
+func newLRU(size int, ttl time.Duration) *lru {
+	return &lru{
+		size:  size,
+		ttl:   ttl,
+		order: list.New(),
+		items: make(map[uint64]*list.Element),
+	}
+}
+
+func (c *lru) get(id uint64) (model.UserRequest, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[id]
+	if !ok {
+		return model.UserRequest{}, false
+	}
+
+	e := el.Value.(*entry)
+	if time.Now().After(e.expiresAt) {
+		c.remove(el)
+
+		return model.UserRequest{}, false
+	}
+	c.order.MoveToFront(el)
+
+	return e.user.Clone(), true
+}
+
+// currentGeneration - take it before loading users that are going to be added
+func (c *lru) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generation
+}
+
+// add - store users loaded at generation, they are dropped when cache was invalidated since then
+func (c *lru) add(users []model.UserRequest, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	expiresAt := time.Now().Add(c.ttl)
+	for _, u := range users {
+		if el, ok := c.items[u.ID_user]; ok {
+			el.Value = &entry{user: u.Clone(), expiresAt: expiresAt}
+			c.order.MoveToFront(el)
+			continue
+		}
+
+		c.items[u.ID_user] = c.order.PushFront(&entry{user: u.Clone(), expiresAt: expiresAt})
+		for c.order.Len() > c.size {
+			c.remove(c.order.Back())
+			cacheEvictions.Inc()
+		}
+	}
+}
+
+func (c *lru) invalidate(IDs []uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for _, id := range IDs {
+		if el, ok := c.items[id]; ok {
+			c.remove(el)
+		}
+	}
+}
+
+// purge - drop all users, used when invalidations could have been missed
+func (c *lru) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.order.Init()
+	c.items = make(map[uint64]*list.Element)
+}
+
+// remove - caller holds the lock
+func (c *lru) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*entry).user.ID_user)
+}
@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"cmd/main.go/internal/model"
+)
+
+func TestLRU(t *testing.T) {
+	users := func(IDs ...uint64) []model.UserRequest {
+		result := make([]model.UserRequest, 0, len(IDs))
+		for _, id := range IDs {
+			result = append(result, model.UserRequest{ID_user: id, Name: "user"})
+		}
+
+		return result
+	}
+
+	tests := []struct {
+		name   string
+		size   int
+		ttl    time.Duration
+		run    func(c *lru)
+		cached []uint64
+		missed []uint64
+	}{
+		{
+			name:   "added at current generation",
+			size:   10,
+			ttl:    time.Minute,
+			run:    func(c *lru) { c.add(users(1, 2), c.currentGeneration()) },
+			cached: []uint64{1, 2},
+		},
+		{
+			name: "loaded before invalidation",
+			size: 10,
+			ttl:  time.Minute,
+			run: func(c *lru) {
+				generation := c.currentGeneration()
+				c.invalidate([]uint64{2})
+				c.add(users(1, 2), generation)
+			},
+			missed: []uint64{1, 2},
+		},
+		{
+			name: "invalidated",
+			size: 10,
+			ttl:  time.Minute,
+			run: func(c *lru) {
+				c.add(users(1, 2), c.currentGeneration())
+				c.invalidate([]uint64{2})
+			},
+			cached: []uint64{1},
+			missed: []uint64{2},
+		},
+		{
+			name: "purged",
+			size: 10,
+			ttl:  time.Minute,
+			run: func(c *lru) {
+				generation := c.currentGeneration()
+				c.add(users(1), generation)
+				c.purge()
+				c.add(users(2), generation)
+			},
+			missed: []uint64{1, 2},
+		},
+		{
+			name: "expired",
+			size: 10,
+			ttl:  time.Nanosecond,
+			run: func(c *lru) {
+				c.add(users(1), c.currentGeneration())
+				time.Sleep(time.Millisecond)
+			},
+			missed: []uint64{1},
+		},
+		{
+			name: "least recently used evicted",
+			size: 2,
+			ttl:  time.Minute,
+			run: func(c *lru) {
+				c.add(users(1, 2), c.currentGeneration())
+				c.get(1)
+				c.add(users(3), c.currentGeneration())
+			},
+			cached: []uint64{1, 3},
+			missed: []uint64{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newLRU(tt.size, tt.ttl)
+			tt.run(c)
+
+			for _, id := range tt.cached {
+				if u, ok := c.get(id); !ok || u.ID_user != id {
+					t.Errorf("get(%d) = %v, %v, want cached user", id, u.ID_user, ok)
+				}
+			}
+			for _, id := range tt.missed {
+				if _, ok := c.get(id); ok {
+					t.Errorf("get(%d) found user, want miss", id)
+				}
+			}
+		})
+	}
+}
+
+func TestLRUClones(t *testing.T) {
+	c := newLRU(10, time.Minute)
+	loaded := []model.UserRequest{{ID_user: 1, Labels: model.Labels{"team": "a"}}}
+	c.add(loaded, c.currentGeneration())
+	loaded[0].Labels["team"] = "b"
+
+	u, _ := c.get(1)
+	u.Labels["team"] = "c"
+
+	if u, _ = c.get(1); u.Labels["team"] != "a" {
+		t.Errorf("cached labels = %v, cached users must not share labels with callers", u.Labels)
+	}
+}
@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+const listenLogTag = "UserCache.Listen()"
+
+// NotifyChannel - Postgres channel that carries comma separated ids of changed users
+const NotifyChannel = "users_cache_invalidate"
+
+const (
+	// notifyBatchSize keeps payload below 8000 bytes limit of NOTIFY
+	notifyBatchSize = 300
+
+	listenMinBackoff = time.Second
+	listenMaxBackoff = time.Minute
+)
+
+// Publisher announces changed users to caches of other instances
+type Publisher interface {
+	Publish(ctx context.Context, IDs []uint64) error
+}
+
+type pgPublisher struct {
+	db *sqlx.DB
+}
+
+// NewPgPublisher returns Publisher that sends NOTIFY, in transaction the notification is delivered on commit
+func NewPgPublisher(db *sqlx.DB) Publisher {
+	return pgPublisher{db: db}
+}
+
+func (p pgPublisher) Publish(ctx context.Context, IDs []uint64) error {
+	queryer := database.Queryer(ctx, p.db)
+
+	for start := 0; start < len(IDs); start += notifyBatchSize {
+		end := start + notifyBatchSize
+		if end > len(IDs) {
+			end = len(IDs)
+		}
+
+		if _, err := queryer.ExecContext(ctx, "SELECT pg_notify($1, $2)", NotifyChannel, loadKey(IDs[start:end])); err != nil {
+			return errors.Wrap(err, "db.ExecContext(pg_notify)")
+		}
+	}
+
+	return nil
+}
+
+// Listen - invalidate users announced by other instances until ctx is done, the connection to dsn is
+// reestablished after errors and the whole cache is dropped then, since notifications could be missed
+func (r *userRequestRepo) Listen(ctx context.Context, dsn string) {
+	backoff := listenMinBackoff
+	for {
+		listening, err := r.listen(ctx, dsn)
+		if ctx.Err() != nil {
+			return
+		}
+
+		r.cache.purge()
+		logger.WarnKV(ctx, fmt.Sprintf("%s: listener stopped", listenLogTag),
+			"err", err,
+			"retryIn", backoff.String(),
+		)
+
+		if listening {
+			backoff = listenMinBackoff
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		if backoff *= 2; backoff > listenMaxBackoff {
+			backoff = listenMaxBackoff
+		}
+	}
+}
+
+// listen - returns whether LISTEN succeeded before the error
+func (r *userRequestRepo) listen(ctx context.Context, dsn string) (bool, error) {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return false, errors.Wrap(err, "pgx.Connect()")
+	}
+	//nolint
+	defer conn.Close(context.Background())
+
+	if _, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{NotifyChannel}.Sanitize()); err != nil {
+		return false, errors.Wrap(err, "conn.Exec(LISTEN)")
+	}
+	// changes made before LISTEN are unknown
+	r.cache.purge()
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return true, errors.Wrap(err, "conn.WaitForNotification()")
+		}
+
+		IDs, err := parseIDs(n.Payload)
+		if err != nil {
+			logger.WarnKV(ctx, fmt.Sprintf("%s: malformed notification, cache is dropped", listenLogTag),
+				"payload", n.Payload,
+				"err", err,
+			)
+			r.cache.purge()
+			continue
+		}
+		r.cache.invalidate(IDs)
+	}
+}
+
+func parseIDs(payload string) ([]uint64, error) {
+	parts := strings.Split(payload, ",")
+	IDs := make([]uint64, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		IDs = append(IDs, id)
+	}
+
+	return IDs, nil
+}
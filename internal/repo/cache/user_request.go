@@ -0,0 +1,256 @@
+// Package cache contains read-through cache of users in front of repo.UserRequestRepo
+package cache
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/repo"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultSize = 10000
+	defaultTTL  = time.Minute
+)
+
+var (
+	cacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "user_cache_hits_total",
+		Help: "The total number of users found in cache",
+	})
+	cacheMisses = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "user_cache_misses_total",
+		Help: "The total number of users loaded from repository because they were not in cache",
+	})
+	cacheEvictions = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "user_cache_evictions_total",
+		Help: "The total number of users evicted from cache because it was full",
+	})
+)
+
+type userRequestRepo struct {
+	repo.UserRequestRepo
+
+	cache     *lru
+	loads     singleflight.Group
+	publisher Publisher
+}
+
+// NewUserRequestRepo returns repo.UserRequestRepo that serves GetUserByIdRequest from cache and loads misses from
+// primary of next, concurrent loads of the same users are done once, mutations invalidate changed users
+// and announce them with publisher when it is not nil
+func NewUserRequestRepo(next repo.UserRequestRepo, cfg config.UserCache, publisher Publisher) *userRequestRepo {
+	size := cfg.Size
+	if size <= 0 {
+		size = defaultSize
+	}
+
+	ttl := time.Duration(cfg.TTL) * time.Second
+	if ttl <= 0 {
+		ttl = defaultTTL
+	}
+
+	return &userRequestRepo{
+		UserRequestRepo: next,
+		cache:           newLRU(size, ttl),
+		publisher:       publisher,
+	}
+}
+
+// GetUserByIdRequest - reads in transaction and read-your-writes reads bypass cache,
+// they must see uncommitted or just committed changes
+func (r *userRequestRepo) GetUserByIdRequest(ctx context.Context, IDs []uint64) ([]model.UserRequest, error) {
+	if database.InTx(ctx) || database.IsReadYourWrites(ctx) {
+		return r.UserRequestRepo.GetUserByIdRequest(ctx, IDs)
+	}
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "cache.GetUserByIdRequest")
+	defer span.Finish()
+
+	var (
+		userRequests []model.UserRequest
+		missing      []uint64
+	)
+	seen := make(map[uint64]struct{}, len(IDs))
+	for _, id := range IDs {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		if u, ok := r.cache.get(id); ok {
+			userRequests = append(userRequests, u)
+			continue
+		}
+		missing = append(missing, id)
+	}
+	cacheHits.Add(float64(len(userRequests)))
+	cacheMisses.Add(float64(len(missing)))
+
+	if len(missing) > 0 {
+		sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+
+		loaded, err, _ := r.loads.Do(loadKey(missing), func() (interface{}, error) {
+			generation := r.cache.currentGeneration()
+			// the load is shared by concurrent callers, so it must not be canceled with the first of them.
+			// Users are read from primary, a lagging replica would return versions older than the last
+			// invalidation and they would stay in cache for the whole TTL
+			loadCtx := database.WithReadYourWrites(context.WithoutCancel(ctx))
+			users, err := r.UserRequestRepo.GetUserByIdRequest(loadCtx, missing)
+			if err != nil {
+				return nil, err
+			}
+			r.cache.add(users, generation)
+
+			return users, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range loaded.([]model.UserRequest) {
+			userRequests = append(userRequests, u.Clone())
+		}
+	}
+
+	sort.Slice(userRequests, func(i, j int) bool {
+		return userRequests[i].ID_user < userRequests[j].ID_user
+	})
+
+	return userRequests, nil
+}
+
+func (r *userRequestRepo) CreateUserRequest(ctx context.Context, userRequest *model.UserRequest) (uint64, error) {
+	id, err := r.UserRequestRepo.CreateUserRequest(ctx, userRequest)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, r.invalidate(ctx, id)
+}
+
+func (r *userRequestRepo) BulkCreateUserRequest(ctx context.Context, userRequests []model.UserRequest) ([]uint64, []uint64, error) {
+	inserted, conflicts, err := r.UserRequestRepo.BulkCreateUserRequest(ctx, userRequests)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return inserted, conflicts, r.invalidate(ctx, inserted...)
+}
+
+func (r *userRequestRepo) RemoveUserRequest(ctx context.Context, IDs []uint64) (bool, error) {
+	removed, err := r.UserRequestRepo.RemoveUserRequest(ctx, IDs)
+	if err != nil || !removed {
+		return removed, err
+	}
+
+	return removed, r.invalidate(ctx, IDs...)
+}
+
+func (r *userRequestRepo) RestoreUserRequest(ctx context.Context, IDs []uint64) (int64, error) {
+	restored, err := r.UserRequestRepo.RestoreUserRequest(ctx, IDs)
+	if err != nil || restored == 0 {
+		return restored, err
+	}
+
+	return restored, r.invalidate(ctx, IDs...)
+}
+
+func (r *userRequestRepo) UpdateUserByIdRequest(ctx context.Context, userRequestID uint64, name, email string) (bool, error) {
+	updated, err := r.UserRequestRepo.UpdateUserByIdRequest(ctx, userRequestID, name, email)
+	if err != nil || !updated {
+		return updated, err
+	}
+
+	return updated, r.invalidate(ctx, userRequestID)
+}
+
+func (r *userRequestRepo) UpdateUserLabelsRequest(ctx context.Context, userRequestID uint64, set model.Labels, removeKeys []string) (bool, error) {
+	updated, err := r.UserRequestRepo.UpdateUserLabelsRequest(ctx, userRequestID, set, removeKeys)
+	if err != nil || !updated {
+		return updated, err
+	}
+
+	return updated, r.invalidate(ctx, userRequestID)
+}
+
+func (r *userRequestRepo) UpdateUserProfileRequest(ctx context.Context, userRequestID uint64, profile model.Profile) (bool, error) {
+	updated, err := r.UserRequestRepo.UpdateUserProfileRequest(ctx, userRequestID, profile)
+	if err != nil || !updated {
+		return updated, err
+	}
+
+	return updated, r.invalidate(ctx, userRequestID)
+}
+
+func (r *userRequestRepo) SaveMergedUserRequest(ctx context.Context, userRequest *model.UserRequest) (bool, error) {
+	saved, err := r.UserRequestRepo.SaveMergedUserRequest(ctx, userRequest)
+	if err != nil || !saved {
+		return saved, err
+	}
+
+	return saved, r.invalidate(ctx, userRequest.ID_user)
+}
+
+func (r *userRequestRepo) MarkMergedUserRequest(ctx context.Context, survivorID uint64, loserIDs []uint64) (int64, error) {
+	affected, err := r.UserRequestRepo.MarkMergedUserRequest(ctx, survivorID, loserIDs)
+	if err != nil || affected == 0 {
+		return affected, err
+	}
+
+	return affected, r.invalidate(ctx, append([]uint64{survivorID}, loserIDs...)...)
+}
+
+func (r *userRequestRepo) EraseUserRequest(ctx context.Context, IDs []uint64) (int64, error) {
+	affected, err := r.UserRequestRepo.EraseUserRequest(ctx, IDs)
+	if err != nil || affected == 0 {
+		return affected, err
+	}
+
+	return affected, r.invalidate(ctx, IDs...)
+}
+
+// invalidate - drop users now and once more after commit, so reads that raced with transaction
+// do not leave old versions in cache, other instances are notified with the same transaction
+func (r *userRequestRepo) invalidate(ctx context.Context, IDs ...uint64) error {
+	if len(IDs) == 0 {
+		return nil
+	}
+
+	r.cache.invalidate(IDs)
+	database.AfterCommit(ctx, func() { r.cache.invalidate(IDs) })
+
+	if r.publisher == nil {
+		return nil
+	}
+
+	if err := r.publisher.Publish(ctx, IDs); err != nil {
+		return errors.Wrap(err, "publisher.Publish()")
+	}
+
+	return nil
+}
+
+func loadKey(IDs []uint64) string {
+	var sb strings.Builder
+	for i, id := range IDs {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(strconv.FormatUint(id, 10))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,162 @@
+package cache
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/repo"
+)
+
+// fakeRepo serves users from map and counts loads, methods that are not overridden panic
+type fakeRepo struct {
+	repo.UserRequestRepo
+
+	users map[uint64]model.UserRequest
+	loads int
+	// fromReplica - loads that could be served by replica
+	fromReplica int
+	// onLoad runs after users are read, before they are returned
+	onLoad func()
+}
+
+func (f *fakeRepo) GetUserByIdRequest(ctx context.Context, IDs []uint64) ([]model.UserRequest, error) {
+	f.loads++
+	if !database.IsReadYourWrites(ctx) {
+		f.fromReplica++
+	}
+
+	var result []model.UserRequest
+	for _, id := range IDs {
+		if u, ok := f.users[id]; ok {
+			result = append(result, u)
+		}
+	}
+	if f.onLoad != nil {
+		f.onLoad()
+	}
+
+	return result, nil
+}
+
+func (f *fakeRepo) UpdateUserByIdRequest(_ context.Context, userRequestID uint64, name, _ string) (bool, error) {
+	u, ok := f.users[userRequestID]
+	if !ok {
+		return false, nil
+	}
+	u.Name = name
+	f.users[userRequestID] = u
+
+	return true, nil
+}
+
+func (f *fakeRepo) RestoreUserRequest(_ context.Context, IDs []uint64) (int64, error) {
+	var restored int64
+	for _, id := range IDs {
+		if u, ok := f.users[id]; ok && u.DeletedAt.Valid {
+			u.DeletedAt = sql.NullTime{}
+			f.users[id] = u
+			restored++
+		}
+	}
+
+	return restored, nil
+}
+
+func TestUserRequestRepo(t *testing.T) {
+	ctx := context.Background()
+	get := func(t *testing.T, r *userRequestRepo, id uint64) string {
+		t.Helper()
+
+		users, err := r.GetUserByIdRequest(ctx, []uint64{id})
+		if err != nil || len(users) != 1 {
+			t.Fatalf("GetUserByIdRequest() = %v, %v", users, err)
+		}
+
+		return users[0].Name
+	}
+
+	t.Run("misses are loaded from primary once", func(t *testing.T) {
+		next := &fakeRepo{users: map[uint64]model.UserRequest{1: {ID_user: 1, Name: "a"}}}
+		r := NewUserRequestRepo(next, config.UserCache{}, nil)
+
+		get(t, r, 1)
+		get(t, r, 1)
+
+		if next.loads != 1 || next.fromReplica != 0 {
+			t.Errorf("loads = %d, from replica = %d, want 1 load from primary", next.loads, next.fromReplica)
+		}
+	})
+
+	t.Run("update invalidates user", func(t *testing.T) {
+		next := &fakeRepo{users: map[uint64]model.UserRequest{1: {ID_user: 1, Name: "a"}}}
+		r := NewUserRequestRepo(next, config.UserCache{}, nil)
+
+		get(t, r, 1)
+		if _, err := r.UpdateUserByIdRequest(ctx, 1, "b", ""); err != nil {
+			t.Fatalf("UpdateUserByIdRequest() error = %v", err)
+		}
+
+		if name := get(t, r, 1); name != "b" {
+			t.Errorf("name = %q, want updated one", name)
+		}
+	})
+
+	t.Run("restore invalidates user", func(t *testing.T) {
+		removed := sql.NullTime{Time: time.Now(), Valid: true}
+		next := &fakeRepo{users: map[uint64]model.UserRequest{1: {ID_user: 1, Name: "a", DeletedAt: removed}}}
+		r := NewUserRequestRepo(next, config.UserCache{}, nil)
+
+		get(t, r, 1)
+		if n, err := r.RestoreUserRequest(ctx, []uint64{1}); err != nil || n != 1 {
+			t.Fatalf("RestoreUserRequest() = %d, %v", n, err)
+		}
+
+		users, err := r.GetUserByIdRequest(ctx, []uint64{1})
+		if err != nil || len(users) != 1 || users[0].DeletedAt.Valid {
+			t.Errorf("GetUserByIdRequest() = %v, %v, want restored user", users, err)
+		}
+		if next.loads != 2 {
+			t.Errorf("loads = %d, want 2", next.loads)
+		}
+	})
+
+	t.Run("load raced with invalidation is not cached", func(t *testing.T) {
+		next := &fakeRepo{users: map[uint64]model.UserRequest{1: {ID_user: 1, Name: "a"}}}
+		r := NewUserRequestRepo(next, config.UserCache{}, nil)
+		next.onLoad = func() {
+			next.onLoad = nil
+			if _, err := r.UpdateUserByIdRequest(ctx, 1, "b", ""); err != nil {
+				t.Fatalf("UpdateUserByIdRequest() error = %v", err)
+			}
+		}
+
+		if name := get(t, r, 1); name != "a" {
+			t.Fatalf("name = %q, want the loaded one", name)
+		}
+		if name := get(t, r, 1); name != "b" {
+			t.Errorf("name = %q, stale user was cached", name)
+		}
+		if next.loads != 2 {
+			t.Errorf("loads = %d, want 2", next.loads)
+		}
+	})
+
+	t.Run("read-your-writes reads bypass cache", func(t *testing.T) {
+		next := &fakeRepo{users: map[uint64]model.UserRequest{1: {ID_user: 1, Name: "a"}}}
+		r := NewUserRequestRepo(next, config.UserCache{}, nil)
+
+		get(t, r, 1)
+		if _, err := r.GetUserByIdRequest(database.WithReadYourWrites(ctx), []uint64{1}); err != nil {
+			t.Fatalf("GetUserByIdRequest() error = %v", err)
+		}
+
+		if next.loads != 2 {
+			t.Errorf("loads = %d, read-your-writes read must not be served by cache", next.loads)
+		}
+	})
+}
@@ -0,0 +1,777 @@
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/pkg/labels"
+	"cmd/main.go/internal/repo"
+	erasurerepo "cmd/main.go/internal/repo/erasure"
+	eventrepo "cmd/main.go/internal/repo/event"
+	grouprepo "cmd/main.go/internal/repo/group"
+
+	"github.com/pkg/errors"
+)
+
+// backend - repositories of one storage
+type backend struct {
+	Transactor database.Transactor
+	Users      repo.UserRequestRepo
+	Groups     grouprepo.Repo
+	Events     eventrepo.Repo
+	Erasures   erasurerepo.Repo
+}
+
+// errRollback - returned from transactions that checks roll back on purpose
+var errRollback = errors.New("rollback")
+
+type check struct {
+	name string
+	run  func(ctx context.Context, b backend, s *suite) error
+}
+
+var checks = []check{
+	{name: "create and get user", run: checkCreateAndGet},
+	{name: "duplicate id is rejected", run: checkDuplicateID},
+	{name: "update name and email", run: checkUpdate},
+	{name: "update labels", run: checkUpdateLabels},
+	{name: "update profile", run: checkUpdateProfile},
+	{name: "list by label selector", run: checkListSelector},
+	{name: "remove is soft delete", run: checkRemove},
+	{name: "restore removed users", run: checkRestore},
+	{name: "rollback undoes writes", run: checkRollback},
+	{name: "bulk create reports conflicts", run: checkBulkCreate},
+	{name: "merge users", run: checkMerge},
+	{name: "group members", run: checkGroupMembers},
+	{name: "add events", run: checkEvents},
+	{name: "erase user", run: checkErase},
+}
+
+// suite - state shared by checks of one run
+type suite struct {
+	nextID uint64
+	run    string
+}
+
+// runChecks - run every check against b as subtest, users get ids starting at firstID
+func runChecks(t *testing.T, b backend, firstID uint64) {
+	s := &suite{
+		nextID: firstID,
+		run:    fmt.Sprintf("r%d", firstID),
+	}
+
+	for _, c := range checks {
+		t.Run(c.name, func(t *testing.T) {
+			if err := c.run(context.Background(), b, s); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// newUser returns user with fresh id labeled with run, so selectors of the run do not see users of others
+func (s *suite) newUser(name string, userLabels model.Labels) model.UserRequest {
+	id := s.nextID
+	s.nextID++
+
+	set := model.Labels{"conformance/run": s.run}
+	for k, v := range userLabels {
+		set[k] = v
+	}
+
+	return model.UserRequest{
+		ID_user:   id,
+		Name:      name,
+		Email:     fmt.Sprintf("%s.%d@example.com", name, id),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Labels:    set,
+		Profile:   model.Profile{},
+	}
+}
+
+func (s *suite) create(ctx context.Context, b backend, users ...*model.UserRequest) error {
+	for _, u := range users {
+		id, err := b.Users.CreateUserRequest(ctx, u)
+		if err != nil {
+			return errors.Wrapf(err, "create user %d", u.ID_user)
+		}
+		if id != u.ID_user {
+			return errors.Errorf("create user %d returned id %d", u.ID_user, id)
+		}
+	}
+
+	return nil
+}
+
+func getUser(ctx context.Context, b backend, id uint64) (model.UserRequest, error) {
+	users, err := b.Users.GetUserByIdRequest(ctx, []uint64{id})
+	if err != nil {
+		return model.UserRequest{}, errors.Wrapf(err, "get user %d", id)
+	}
+	if len(users) != 1 {
+		return model.UserRequest{}, errors.Errorf("get user %d returned %d users", id, len(users))
+	}
+
+	return users[0], nil
+}
+
+func checkCreateAndGet(ctx context.Context, b backend, s *suite) error {
+	u := s.newUser("alice", model.Labels{"tier": "gold"})
+	u.Profile = model.Profile{"company": "ACME", "address": map[string]interface{}{"city": "Oslo"}}
+	if err := s.create(ctx, b, &u); err != nil {
+		return err
+	}
+
+	got, err := getUser(ctx, b, u.ID_user)
+	if err != nil {
+		return err
+	}
+	if got.Name != u.Name || got.Email != u.Email {
+		return errors.Errorf("got name %q email %q, want %q %q", got.Name, got.Email, u.Name, u.Email)
+	}
+	if !got.CreatedAt.Equal(u.CreatedAt) {
+		return errors.Errorf("got created_at %v, want %v", got.CreatedAt, u.CreatedAt)
+	}
+	if got.UpdatedAt.Valid || got.DeletedAt.Valid || got.MergedInto.Valid {
+		return errors.Errorf("new user has updated_at, deleted_at or merged_into set")
+	}
+	if err = equalLabels(got.Labels, u.Labels); err != nil {
+		return err
+	}
+
+	return equalJSON("profile", got.Profile, u.Profile)
+}
+
+func checkDuplicateID(ctx context.Context, b backend, s *suite) error {
+	u := s.newUser("bob", nil)
+	if err := s.create(ctx, b, &u); err != nil {
+		return err
+	}
+
+	again := u
+	again.Name = "bob again"
+	if _, err := b.Users.CreateUserRequest(ctx, &again); err == nil {
+		return errors.Errorf("second create of user %d succeeded", u.ID_user)
+	}
+
+	got, err := getUser(ctx, b, u.ID_user)
+	if err != nil {
+		return err
+	}
+	if got.Name != u.Name {
+		return errors.Errorf("user was overwritten by duplicate, name %q", got.Name)
+	}
+
+	return nil
+}
+
+func checkUpdate(ctx context.Context, b backend, s *suite) error {
+	u := s.newUser("carol", nil)
+	if err := s.create(ctx, b, &u); err != nil {
+		return err
+	}
+
+	ok, err := b.Users.UpdateUserByIdRequest(ctx, u.ID_user, "caroline", "caroline@example.com")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("update of existing user reported no user")
+	}
+
+	got, err := getUser(ctx, b, u.ID_user)
+	if err != nil {
+		return err
+	}
+	if got.Name != "caroline" || got.Email != "caroline@example.com" || !got.UpdatedAt.Valid {
+		return errors.Errorf("got name %q email %q updated_at %v after update", got.Name, got.Email, got.UpdatedAt)
+	}
+
+	missing := s.newUser("missing", nil)
+	if ok, err = b.Users.UpdateUserByIdRequest(ctx, missing.ID_user, "x", "x@example.com"); err != nil {
+		return err
+	}
+	if ok {
+		return errors.New("update of missing user reported a user")
+	}
+
+	return nil
+}
+
+func checkUpdateLabels(ctx context.Context, b backend, s *suite) error {
+	u := s.newUser("dave", model.Labels{"tier": "gold", "region": "eu", "team.name/a": "x"})
+	if err := s.create(ctx, b, &u); err != nil {
+		return err
+	}
+
+	ok, err := b.Users.UpdateUserLabelsRequest(ctx, u.ID_user, model.Labels{"tier": "silver", "beta": "true"}, []string{"region", "team.name/a", "absent"})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("labels update of existing user reported no user")
+	}
+
+	got, err := getUser(ctx, b, u.ID_user)
+	if err != nil {
+		return err
+	}
+	if err = equalLabels(got.Labels, model.Labels{"conformance/run": s.run, "tier": "silver", "beta": "true"}); err != nil {
+		return err
+	}
+
+	// neither set nor removed keys leave labels as they are
+	if _, err = b.Users.UpdateUserLabelsRequest(ctx, u.ID_user, model.Labels{}, nil); err != nil {
+		return err
+	}
+	if got, err = getUser(ctx, b, u.ID_user); err != nil {
+		return err
+	}
+
+	return equalLabels(got.Labels, model.Labels{"conformance/run": s.run, "tier": "silver", "beta": "true"})
+}
+
+func checkUpdateProfile(ctx context.Context, b backend, s *suite) error {
+	u := s.newUser("erin", nil)
+	if err := s.create(ctx, b, &u); err != nil {
+		return err
+	}
+
+	profile := model.Profile{
+		"company": "Initech",
+		"phones":  []interface{}{"+100", "+200"},
+		"age":     float64(42),
+		"address": map[string]interface{}{"city": "Austin", "zip": "73301"},
+	}
+	ok, err := b.Users.UpdateUserProfileRequest(ctx, u.ID_user, profile)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("profile update of existing user reported no user")
+	}
+
+	got, err := getUser(ctx, b, u.ID_user)
+	if err != nil {
+		return err
+	}
+
+	return equalJSON("profile", got.Profile, profile)
+}
+
+func checkListSelector(ctx context.Context, b backend, s *suite) error {
+	// users of other checks have labels of the run too
+	const check = "conformance/check=list"
+	gold := s.newUser("frank", model.Labels{"conformance/check": "list", "tier": "gold", "region": "eu"})
+	silver := s.newUser("grace", model.Labels{"conformance/check": "list", "tier": "silver"})
+	plain := s.newUser("heidi", model.Labels{"conformance/check": "list"})
+	removed := s.newUser("ivan", model.Labels{"conformance/check": "list", "tier": "gold"})
+	if err := s.create(ctx, b, &gold, &silver, &plain, &removed); err != nil {
+		return err
+	}
+	if _, err := b.Users.RemoveUserRequest(ctx, []uint64{removed.ID_user}); err != nil {
+		return err
+	}
+
+	cases := []struct {
+		selector string
+		want     []uint64
+	}{
+		{selector: "tier=gold", want: []uint64{gold.ID_user}},
+		{selector: "tier!=gold", want: []uint64{silver.ID_user, plain.ID_user}},
+		{selector: "tier", want: []uint64{gold.ID_user, silver.ID_user}},
+		{selector: "!tier", want: []uint64{plain.ID_user}},
+		{selector: "tier=gold,region=eu", want: []uint64{gold.ID_user}},
+		{selector: "tier=gold,!region", want: nil},
+	}
+
+	for _, c := range cases {
+		selector, err := labels.Parse("conformance/run=" + s.run + "," + check + "," + c.selector)
+		if err != nil {
+			return errors.Wrapf(err, "selector %q", c.selector)
+		}
+
+		users, err := b.Users.ListUserRequest(ctx, 100, 0, selector)
+		if err != nil {
+			return errors.Wrapf(err, "list %q", c.selector)
+		}
+
+		var got []uint64
+		for _, u := range users {
+			got = append(got, u.ID_user)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			return errors.Errorf("list %q returned %v, want %v", c.selector, got, c.want)
+		}
+	}
+
+	// pages follow order of ids
+	selector, err := labels.Parse("conformance/run=" + s.run + "," + check + ",tier")
+	if err != nil {
+		return err
+	}
+	page, err := b.Users.ListUserRequest(ctx, 1, 1, selector)
+	if err != nil {
+		return err
+	}
+	if len(page) != 1 || page[0].ID_user != silver.ID_user {
+		return errors.Errorf("second page of one user returned %v, want user %d", ids(page), silver.ID_user)
+	}
+
+	return nil
+}
+
+func checkRemove(ctx context.Context, b backend, s *suite) error {
+	u := s.newUser("judy", nil)
+	if err := s.create(ctx, b, &u); err != nil {
+		return err
+	}
+
+	ok, err := b.Users.RemoveUserRequest(ctx, []uint64{u.ID_user})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("remove of existing user reported no user")
+	}
+
+	exists, err := b.Users.Exists(ctx, u.ID_user)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("removed user exists")
+	}
+
+	if ok, err = b.Users.RemoveUserRequest(ctx, []uint64{u.ID_user}); err != nil {
+		return err
+	}
+	if ok {
+		return errors.New("second remove reported a user")
+	}
+
+	got, err := getUser(ctx, b, u.ID_user)
+	if err != nil {
+		return err
+	}
+	if !got.DeletedAt.Valid {
+		return errors.New("removed user has no deleted_at")
+	}
+
+	if ok, err = b.Users.UpdateUserByIdRequest(ctx, u.ID_user, "x", "x@example.com"); err != nil {
+		return err
+	}
+	if ok {
+		return errors.New("update of removed user reported a user")
+	}
+
+	return nil
+}
+
+func checkRestore(ctx context.Context, b backend, s *suite) error {
+	active := s.newUser("kate", nil)
+	removed := s.newUser("kim", nil)
+	survivor := s.newUser("kit", nil)
+	merged := s.newUser("kitty", nil)
+	erased := s.newUser("kay", nil)
+	unknown := s.newUser("ken", nil)
+	if err := s.create(ctx, b, &active, &removed, &survivor, &merged, &erased); err != nil {
+		return err
+	}
+
+	if _, err := b.Users.RemoveUserRequest(ctx, []uint64{removed.ID_user, erased.ID_user}); err != nil {
+		return err
+	}
+	if _, err := b.Users.MarkMergedUserRequest(ctx, survivor.ID_user, []uint64{merged.ID_user}); err != nil {
+		return err
+	}
+	if _, err := b.Users.EraseUserRequest(ctx, []uint64{erased.ID_user}); err != nil {
+		return err
+	}
+
+	all := []uint64{active.ID_user, removed.ID_user, merged.ID_user, erased.ID_user, unknown.ID_user}
+	n, err := b.Users.RestoreUserRequest(ctx, all)
+	if err != nil {
+		return err
+	}
+	if n != 1 {
+		return errors.Errorf("restored %d users, want 1", n)
+	}
+
+	got, err := getUser(ctx, b, removed.ID_user)
+	if err != nil {
+		return err
+	}
+	if got.DeletedAt.Valid || got.Name != removed.Name {
+		return errors.Errorf("restored user has deleted_at %v name %q", got.DeletedAt, got.Name)
+	}
+	exists, err := b.Users.Exists(ctx, removed.ID_user)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.New("restored user does not exist")
+	}
+
+	for _, id := range []uint64{merged.ID_user, erased.ID_user} {
+		if exists, err = b.Users.Exists(ctx, id); err != nil {
+			return err
+		}
+		if exists {
+			return errors.Errorf("user %d is restored, merged and erased users stay removed", id)
+		}
+	}
+
+	if n, err = b.Users.RestoreUserRequest(ctx, all); err != nil {
+		return err
+	}
+	if n != 0 {
+		return errors.Errorf("second restore restored %d users, want 0", n)
+	}
+
+	return nil
+}
+
+func checkRollback(ctx context.Context, b backend, s *suite) error {
+	kept := s.newUser("kim", nil)
+	if err := s.create(ctx, b, &kept); err != nil {
+		return err
+	}
+	rolledBack := s.newUser("leo", nil)
+
+	err := b.Transactor.InTx(ctx, func(ctx context.Context) error {
+		if err := s.create(ctx, b, &rolledBack); err != nil {
+			return err
+		}
+		if _, err := b.Users.UpdateUserByIdRequest(ctx, kept.ID_user, "changed", "changed@example.com"); err != nil {
+			return err
+		}
+
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		return errors.Errorf("transaction returned %v, want %v", err, errRollback)
+	}
+
+	exists, err := b.Users.Exists(ctx, rolledBack.ID_user)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("user created in rolled back transaction exists")
+	}
+
+	got, err := getUser(ctx, b, kept.ID_user)
+	if err != nil {
+		return err
+	}
+	if got.Name != kept.Name {
+		return errors.Errorf("update of rolled back transaction is visible, name %q", got.Name)
+	}
+
+	return nil
+}
+
+func checkBulkCreate(ctx context.Context, b backend, s *suite) error {
+	taken := s.newUser("mallory", nil)
+	if err := s.create(ctx, b, &taken); err != nil {
+		return err
+	}
+
+	first := s.newUser("nina", nil)
+	second := s.newUser("oscar", nil)
+	repeated := first
+	repeated.Name = "nina again"
+
+	inserted, conflicts, err := b.Users.BulkCreateUserRequest(ctx, []model.UserRequest{second, first, taken, repeated})
+	if err != nil {
+		return err
+	}
+	if want := []uint64{first.ID_user, second.ID_user}; !reflect.DeepEqual(inserted, want) {
+		return errors.Errorf("inserted %v, want %v", inserted, want)
+	}
+	if want := []uint64{taken.ID_user, first.ID_user}; !reflect.DeepEqual(conflicts, want) {
+		return errors.Errorf("conflicts %v, want %v", conflicts, want)
+	}
+
+	got, err := getUser(ctx, b, first.ID_user)
+	if err != nil {
+		return err
+	}
+	if got.Name != first.Name {
+		return errors.Errorf("repeated id overwrote the first one, name %q", got.Name)
+	}
+
+	return nil
+}
+
+func checkMerge(ctx context.Context, b backend, s *suite) error {
+	survivor := s.newUser("peggy", model.Labels{"tier": "gold"})
+	loser := s.newUser("peg", model.Labels{"region": "us"})
+	if err := s.create(ctx, b, &survivor, &loser); err != nil {
+		return err
+	}
+
+	err := b.Transactor.InTx(ctx, func(ctx context.Context) error {
+		users, err := b.Users.GetUserByIdForUpdateRequest(ctx, []uint64{loser.ID_user, survivor.ID_user})
+		if err != nil {
+			return err
+		}
+		if got := ids(users); !reflect.DeepEqual(got, []uint64{survivor.ID_user, loser.ID_user}) {
+			return errors.Errorf("locked users %v, want them in order of ids", got)
+		}
+
+		merged := users[0]
+		merged.Labels = model.Labels{"conformance/run": s.run, "tier": "gold", "region": "us"}
+		if _, err = b.Users.SaveMergedUserRequest(ctx, &merged); err != nil {
+			return err
+		}
+
+		n, err := b.Users.MarkMergedUserRequest(ctx, survivor.ID_user, []uint64{loser.ID_user})
+		if err != nil {
+			return err
+		}
+		if n != 1 {
+			return errors.Errorf("marked %d users, want 1", n)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	got, err := getUser(ctx, b, survivor.ID_user)
+	if err != nil {
+		return err
+	}
+	if err = equalLabels(got.Labels, model.Labels{"conformance/run": s.run, "tier": "gold", "region": "us"}); err != nil {
+		return err
+	}
+
+	if got, err = getUser(ctx, b, loser.ID_user); err != nil {
+		return err
+	}
+	if !got.DeletedAt.Valid || !got.MergedInto.Valid || uint64(got.MergedInto.Int64) != survivor.ID_user {
+		return errors.Errorf("loser has deleted_at %v merged_into %v", got.DeletedAt, got.MergedInto)
+	}
+
+	return nil
+}
+
+func checkGroupMembers(ctx context.Context, b backend, s *suite) error {
+	member := s.newUser("quinn", nil)
+	other := s.newUser("rupert", nil)
+	removed := s.newUser("sybil", nil)
+	if err := s.create(ctx, b, &member, &other, &removed); err != nil {
+		return err
+	}
+	if _, err := b.Users.RemoveUserRequest(ctx, []uint64{removed.ID_user}); err != nil {
+		return err
+	}
+
+	groupID, err := b.Groups.CreateGroup(ctx, &model.Group{
+		Name:      "conformance " + s.run,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if groupID == 0 {
+		return errors.New("create group returned id 0")
+	}
+
+	if _, err = b.Groups.CreateGroup(ctx, &model.Group{Name: "conformance " + s.run, CreatedAt: time.Now()}); err == nil {
+		return errors.New("second group with the same name was created")
+	}
+
+	// users are checked by services, repository adds any ids
+	added, err := b.Groups.AddMembers(ctx, groupID, []uint64{member.ID_user, removed.ID_user, member.ID_user})
+	if err != nil {
+		return err
+	}
+	if want := []uint64{member.ID_user, removed.ID_user}; !reflect.DeepEqual(added, want) {
+		return errors.Errorf("added %v, want %v", added, want)
+	}
+	if _, err = b.Groups.RemoveUserMemberships(ctx, []uint64{removed.ID_user}); err != nil {
+		return err
+	}
+
+	if added, err = b.Groups.AddMembers(ctx, groupID, []uint64{member.ID_user}); err != nil {
+		return err
+	}
+	if len(added) != 0 {
+		return errors.Errorf("existing member was added again: %v", added)
+	}
+
+	if err = b.Groups.MoveUserMemberships(ctx, []uint64{member.ID_user}, other.ID_user); err != nil {
+		return err
+	}
+
+	members, err := b.Groups.ListMembers(ctx, groupID, 100, 0)
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(members, []uint64{other.ID_user}) {
+		return errors.Errorf("members %v after move, want %v", members, []uint64{other.ID_user})
+	}
+
+	groups, err := b.Groups.ListUserGroups(ctx, other.ID_user)
+	if err != nil {
+		return err
+	}
+	if len(groups) != 1 || groups[0].ID_group != groupID {
+		return errors.Errorf("user groups %v, want group %d", groups, groupID)
+	}
+
+	return nil
+}
+
+func checkEvents(ctx context.Context, b backend, s *suite) error {
+	u := s.newUser("trent", nil)
+
+	return b.Events.Add(ctx, []model.UserEvent{
+		{UserID: u.ID_user, Type: model.UserMerged, Payload: model.EventPayload{"into": u.ID_user}},
+		{UserID: u.ID_user, Type: model.UserMerged, Payload: nil},
+	})
+}
+
+func checkErase(ctx context.Context, b backend, s *suite) error {
+	survivor := s.newUser("uma", model.Labels{"tier": "gold"})
+	loser := s.newUser("umma", nil)
+	if err := s.create(ctx, b, &survivor, &loser); err != nil {
+		return err
+	}
+	if _, err := b.Users.MarkMergedUserRequest(ctx, survivor.ID_user, []uint64{loser.ID_user}); err != nil {
+		return err
+	}
+	err := b.Events.Add(ctx, []model.UserEvent{
+		{UserID: loser.ID_user, Type: model.UserMerged, Payload: model.EventPayload{"email": loser.Email, "into": survivor.ID_user}},
+		{UserID: survivor.ID_user, Type: model.UserMerged, Payload: model.EventPayload{"merged": []uint64{loser.ID_user}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = b.Transactor.InTx(ctx, func(ctx context.Context) error {
+		merged, err := b.Users.ListMergedIntoRequest(ctx, []uint64{survivor.ID_user})
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(merged, []uint64{loser.ID_user}) {
+			return errors.Errorf("merged into survivor %v, want %v", merged, []uint64{loser.ID_user})
+		}
+
+		erasedIDs := []uint64{survivor.ID_user, loser.ID_user}
+		n, err := b.Users.EraseUserRequest(ctx, erasedIDs)
+		if err != nil {
+			return err
+		}
+		if n != 2 {
+			return errors.Errorf("erased %d users, want 2", n)
+		}
+
+		if n, err = b.Events.ScrubPayloads(ctx, erasedIDs, model.PersonalPayloadKeys); err != nil {
+			return err
+		}
+		if n != 1 {
+			return errors.Errorf("scrubbed %d events, want 1", n)
+		}
+
+		if _, err = b.Erasures.RemoveDuplicateCandidates(ctx, erasedIDs); err != nil {
+			return err
+		}
+
+		receiptID, err := b.Erasures.AddReceipt(ctx, &model.ErasureReceipt{
+			UserID:  survivor.ID_user,
+			Reason:  "conformance",
+			Summary: model.ErasureSummary{Users: erasedIDs, Rows: map[string]int64{"users": 2}},
+		})
+		if err != nil {
+			return err
+		}
+		if receiptID == 0 {
+			return errors.New("add receipt returned id 0")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, id := range []uint64{survivor.ID_user, loser.ID_user} {
+		got, err := getUser(ctx, b, id)
+		if err != nil {
+			return err
+		}
+		if got.Name != "" || got.Email != "" || got.EmailBlindIndex.Valid || len(got.Labels) != 0 || len(got.Profile) != 0 {
+			return errors.Errorf("user %d keeps personal data after erasure: %+v", id, got)
+		}
+		if !got.ErasedAt.Valid || !got.DeletedAt.Valid {
+			return errors.Errorf("user %d has erased_at %v deleted_at %v", id, got.ErasedAt, got.DeletedAt)
+		}
+	}
+
+	n, err := b.Users.EraseUserRequest(ctx, []uint64{survivor.ID_user})
+	if err != nil {
+		return err
+	}
+	if n != 0 {
+		return errors.Errorf("erased user was erased again")
+	}
+
+	if n, err = b.Events.ScrubPayloads(ctx, []uint64{loser.ID_user}, model.PersonalPayloadKeys); err != nil {
+		return err
+	}
+	if n != 0 {
+		return errors.Errorf("scrubbed event was scrubbed again")
+	}
+
+	return nil
+}
+
+func ids(users []model.UserRequest) []uint64 {
+	result := make([]uint64, 0, len(users))
+	for _, u := range users {
+		result = append(result, u.ID_user)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+
+	return result
+}
+
+func equalLabels(got, want model.Labels) error {
+	if len(got) == 0 && len(want) == 0 {
+		return nil
+	}
+	if !reflect.DeepEqual(got, want) {
+		return errors.Errorf("labels %v, want %v", got, want)
+	}
+
+	return nil
+}
+
+// equalJSON - compare values by their JSON, numbers of decoded documents are float64 on every backend
+func equalJSON(what string, got, want interface{}) error {
+	gotJSON, err := json.Marshal(got)
+	if err != nil {
+		return err
+	}
+	wantJSON, err := json.Marshal(want)
+	if err != nil {
+		return err
+	}
+	if string(gotJSON) != string(wantJSON) {
+		return errors.Errorf("%s %s, want %s", what, gotJSON, wantJSON)
+	}
+
+	return nil
+}
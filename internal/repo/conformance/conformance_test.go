@@ -0,0 +1,79 @@
+// Package conformance contains checks of behaviour every storage backend of repositories must share,
+// they run against in-memory storage, SQLite and Postgres of dbtest.PostgresDSNEnv.
+// Checks write users with ids from a range derived from current time and leave them in storage,
+// so Postgres must be a disposable database.
+package conformance
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/database/dbtest"
+	"cmd/main.go/internal/pkg/envelope"
+	"cmd/main.go/internal/repo"
+	erasurerepo "cmd/main.go/internal/repo/erasure"
+	eventrepo "cmd/main.go/internal/repo/event"
+	grouprepo "cmd/main.go/internal/repo/group"
+	"cmd/main.go/internal/repo/memory"
+	"cmd/main.go/internal/repo/pii"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func TestConformance(t *testing.T) {
+	backends := []struct {
+		name string
+		open func(t *testing.T) backend
+	}{
+		{name: "memory", open: openMemory},
+		{name: "sqlite", open: openSQL(dbtest.SQLite, nil)},
+		{name: "sqlite encrypted", open: openSQL(dbtest.SQLite, testFields(t))},
+		{name: "postgres", open: openSQL(dbtest.Postgres, nil)},
+		{name: "postgres encrypted", open: openSQL(dbtest.Postgres, testFields(t))},
+	}
+
+	for _, tt := range backends {
+		t.Run(tt.name, func(t *testing.T) {
+			// ids of runs against the same database do not overlap
+			runChecks(t, tt.open(t), uint64(time.Now().UnixMilli())*1000)
+		})
+	}
+}
+
+func openMemory(*testing.T) backend {
+	store := memory.NewStore()
+
+	return backend{
+		Transactor: store.Transactor(),
+		Users:      memory.NewUserRequestRepo(store),
+		Groups:     memory.NewGroupRepo(store),
+		Events:     memory.NewEventRepo(store),
+		Erasures:   memory.NewErasureRepo(store),
+	}
+}
+
+// openSQL returns opener of repositories on database of open, emails are sealed by fields when it is not nil
+func openSQL(open func(tb testing.TB) *sqlx.DB, fields *pii.Fields) func(t *testing.T) backend {
+	return func(t *testing.T) backend {
+		db := open(t)
+
+		return backend{
+			Transactor: database.NewTransactor(db),
+			Users:      repo.NewUserRequestRepo(dbtest.Cluster(t, db), 0, fields),
+			Groups:     grouprepo.NewRepo(db),
+			Events:     eventrepo.NewRepo(db),
+			Erasures:   erasurerepo.NewRepo(db),
+		}
+	}
+}
+
+func testFields(t *testing.T) *pii.Fields {
+	keyring, err := envelope.New(1, map[uint32][]byte{1: bytes.Repeat([]byte{1}, envelope.KeySize)}, bytes.Repeat([]byte{2}, envelope.MinIndexKeySize))
+	if err != nil {
+		t.Fatalf("envelope.New(): %v", err)
+	}
+
+	return pii.New(keyring)
+}
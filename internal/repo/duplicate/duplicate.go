@@ -0,0 +1,283 @@
+package duplicate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+const (
+	candidateTable            = "users_duplicate_candidates"
+	candidateUserAColumn      = "id_user_a"
+	candidateUserBColumn      = "id_user_b"
+	candidateScoreColumn      = "score"
+	candidateSignalsColumn    = "signals"
+	candidateDetectedAtColumn = "detected_at"
+
+	// analyzerLockKey is a key of advisory lock that allows only one running scan
+	analyzerLockKey = 7203001
+
+	// normalizedEmail and emailLocalPart must match index expressions from migrations
+	// and normalization of blind index of encrypted emails (pii.NormalizeEmail)
+	normalizedEmail = `regexp_replace(lower(btrim(%[1]s.email)), '\+[^@]*@', '@')`
+	emailLocalPart  = `split_part(lower(btrim(%[1]s.email)), '@', 1)`
+)
+
+// Repo is DAO for duplicate user candidates
+type Repo interface {
+	FindPairs(ctx context.Context, afterID uint64, batchSize uint64, similarityThreshold float64) ([]model.DuplicatePair, uint64, error)
+	SaveCandidates(ctx context.Context, candidates []model.DuplicateCandidate) error
+	PruneCandidates(ctx context.Context, detectedBefore time.Time) (int64, error)
+	ListCandidates(ctx context.Context, minScore float64, limit uint64, offset uint64) ([]model.DuplicateCandidate, error)
+	TryLock(ctx context.Context) (unlock func(), locked bool, err error)
+}
+
+type repo struct {
+	db              *sqlx.DB
+	encryptedEmails bool
+}
+
+// NewRepo returns Repo interface, encryptedEmails is set when emails are sealed with envelope encryption,
+// then only their blind indexes are compared
+func NewRepo(db *sqlx.DB, encryptedEmails bool) Repo {
+	return &repo{db: db, encryptedEmails: encryptedEmails}
+}
+
+// FindPairs - find likely duplicates of next batch of users with id greater than afterID,
+// returns pairs and the last id of the batch (0 when there are no more users).
+// Candidates are searched with trigram "%" operator so GIN indexes are used,
+// the operator threshold is set for the current transaction only
+func (r *repo) FindPairs(ctx context.Context, afterID uint64, batchSize uint64, similarityThreshold float64) ([]model.DuplicatePair, uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.FindDuplicatePairs")
+	defer span.Finish()
+	defer database.QueryTimer("repo.FindDuplicatePairs").ObserveDuration()
+
+	tx := database.TxFromContext(ctx)
+	if tx == nil {
+		return nil, 0, errors.New("duplicate pairs must be searched in transaction")
+	}
+
+	if _, err := tx.ExecContext(ctx, "SELECT set_config('pg_trgm.similarity_threshold', $1, true)", fmt.Sprintf("%f", similarityThreshold)); err != nil {
+		return nil, 0, errors.Wrap(err, "tx.ExecContext()")
+	}
+
+	var lastID uint64
+	batch := database.StatementBuilder.
+		Select("COALESCE(MAX(id_user), 0)").
+		FromSelect(database.StatementBuilder.
+			Select("id_user").
+			From("users").
+			Where(sq.And{
+				sq.Gt{"id_user": afterID},
+				sq.Eq{"deleted_at": nil}}).
+			OrderBy("id_user").
+			Limit(batchSize), "batch")
+
+	query, args, err := batch.ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err = tx.QueryRowxContext(ctx, query, args...).Scan(&lastID); err != nil {
+		return nil, 0, errors.Wrap(err, "tx.QueryRowxContext()")
+	}
+
+	if lastID == 0 {
+		return nil, 0, nil
+	}
+
+	// users without email, erased ones included, have empty emails and no blind index,
+	// they must not match each other by email
+	bothEmails := "a.email <> '' AND b.email <> ''"
+	bidxEqual := "(a.email_bidx IS NOT NULL AND a.email_bidx = b.email_bidx)"
+	plainEmailEqual := "(" + bothEmails + " AND " + fmt.Sprintf(normalizedEmail, "a") + " = " + fmt.Sprintf(normalizedEmail, "b") + ")"
+
+	emailEqual := bidxEqual + " OR " + plainEmailEqual
+	emailLocalSimilarity := "CASE WHEN a.email_bidx IS NOT NULL OR b.email_bidx IS NOT NULL OR a.email = '' OR b.email = '' THEN 0 ELSE similarity(" +
+		fmt.Sprintf(emailLocalPart, "a") + ", " + fmt.Sprintf(emailLocalPart, "b") + ") END"
+	matches := sq.Or{
+		sq.Expr(bidxEqual),
+		sq.Expr(plainEmailEqual),
+		sq.Expr(bothEmails + " AND " + fmt.Sprintf(emailLocalPart, "a") + " % " + fmt.Sprintf(emailLocalPart, "b")),
+		sq.Expr("a.name % b.name"),
+	}
+	// local parts of sealed emails are ciphertext, trigrams of them match at random,
+	// so encrypted emails are compared by blind indexes only
+	if r.encryptedEmails {
+		emailEqual = bidxEqual
+		emailLocalSimilarity = "0"
+		matches = sq.Or{
+			sq.Expr(bidxEqual),
+			sq.Expr("a.name % b.name"),
+		}
+	}
+
+	sb := database.StatementBuilder.
+		Select(
+			"a.id_user AS id_user_a",
+			"b.id_user AS id_user_b",
+			"("+emailEqual+") AS email_equal",
+			emailLocalSimilarity+" AS email_local_similarity",
+			"CASE WHEN a.name = '' OR b.name = '' THEN 0 ELSE similarity(a.name, b.name) END AS name_similarity").
+		From("users a").
+		Join("users b ON b.id_user > a.id_user AND b.deleted_at IS NULL").
+		Where(sq.And{
+			sq.Gt{"a.id_user": afterID},
+			sq.LtOrEq{"a.id_user": lastID},
+			sq.Eq{"a.deleted_at": nil},
+			matches}).
+		OrderBy("a.id_user", "b.id_user")
+
+	query, args, err = sb.ToSql()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var pairs []model.DuplicatePair
+	if err = tx.SelectContext(ctx, &pairs, query, args...); err != nil {
+		return nil, 0, errors.Wrap(err, "tx.SelectContext()")
+	}
+
+	return pairs, lastID, nil
+}
+
+// SaveCandidates - upsert candidates, detected_at of existing pairs is refreshed
+func (r *repo) SaveCandidates(ctx context.Context, candidates []model.DuplicateCandidate) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.SaveDuplicateCandidates")
+	defer span.Finish()
+	defer database.QueryTimer("repo.SaveDuplicateCandidates").ObserveDuration()
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	sb := database.StatementBuilder.
+		Insert(candidateTable).
+		Columns(
+			candidateUserAColumn,
+			candidateUserBColumn,
+			candidateScoreColumn,
+			candidateSignalsColumn,
+			candidateDetectedAtColumn)
+
+	for _, c := range candidates {
+		sb = sb.Values(c.UserA, c.UserB, c.Score, c.Signals, c.DetectedAt)
+	}
+
+	sb = sb.Suffix("ON CONFLICT (" + candidateUserAColumn + ", " + candidateUserBColumn + ") DO UPDATE SET " +
+		candidateScoreColumn + " = EXCLUDED." + candidateScoreColumn + ", " +
+		candidateSignalsColumn + " = EXCLUDED." + candidateSignalsColumn + ", " +
+		candidateDetectedAtColumn + " = EXCLUDED." + candidateDetectedAtColumn)
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return err
+	}
+
+	execer := database.Queryer(ctx, r.db)
+
+	if _, err = execer.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, "db.ExecContext()")
+	}
+
+	return nil
+}
+
+// PruneCandidates - remove candidates that were not confirmed by the last full scan
+func (r *repo) PruneCandidates(ctx context.Context, detectedBefore time.Time) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.PruneDuplicateCandidates")
+	defer span.Finish()
+	defer database.QueryTimer("repo.PruneDuplicateCandidates").ObserveDuration()
+
+	sb := database.StatementBuilder.
+		Delete(candidateTable).
+		Where(sq.Lt{candidateDetectedAtColumn: detectedBefore})
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := database.Queryer(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "db.ExecContext()")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "repo.RowsAffected()")
+	}
+
+	return affected, nil
+}
+
+func (r *repo) ListCandidates(ctx context.Context, minScore float64, limit uint64, offset uint64) ([]model.DuplicateCandidate, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.ListDuplicateCandidates")
+	defer span.Finish()
+	defer database.QueryTimer("repo.ListDuplicateCandidates").ObserveDuration()
+
+	sb := database.StatementBuilder.
+		Select(
+			candidateUserAColumn,
+			candidateUserBColumn,
+			candidateScoreColumn,
+			candidateSignalsColumn,
+			candidateDetectedAtColumn).
+		From(candidateTable).
+		Where(sq.GtOrEq{candidateScoreColumn: minScore}).
+		OrderBy(candidateScoreColumn+" DESC", candidateUserAColumn, candidateUserBColumn).
+		Limit(limit).
+		Offset(offset)
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []model.DuplicateCandidate
+	if err = sqlx.SelectContext(ctx, database.Queryer(ctx, r.db), &candidates, query, args...); err != nil {
+		return nil, errors.Wrap(err, "db.SelectContext()")
+	}
+
+	return candidates, nil
+}
+
+// TryLock - take session advisory lock on dedicated connection, so only one instance scans at a time
+func (r *repo) TryLock(ctx context.Context) (func(), bool, error) {
+	conn, err := r.db.Connx(ctx)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "db.Connx()")
+	}
+
+	var locked bool
+	if err = conn.QueryRowxContext(ctx, "SELECT pg_try_advisory_lock($1)", analyzerLockKey).Scan(&locked); err != nil {
+		//nolint
+		conn.Close()
+
+		return nil, false, errors.Wrap(err, "conn.QueryRowxContext()")
+	}
+
+	if !locked {
+		//nolint
+		conn.Close()
+
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		//nolint
+		conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", analyzerLockKey)
+		//nolint
+		conn.Close()
+	}
+
+	return unlock, true, nil
+}
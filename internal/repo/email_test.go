@@ -0,0 +1,120 @@
+package repo
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"cmd/main.go/internal/database/dbtest"
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/pkg/envelope"
+	"cmd/main.go/internal/repo/pii"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func TestEncryptedEmails(t *testing.T) {
+	backends := []struct {
+		name string
+		open func(tb testing.TB) *sqlx.DB
+	}{
+		{name: "sqlite", open: dbtest.SQLite},
+		{name: "postgres", open: dbtest.Postgres},
+	}
+
+	keyring, err := envelope.New(1, map[uint32][]byte{1: bytes.Repeat([]byte{1}, envelope.KeySize)}, bytes.Repeat([]byte{2}, envelope.MinIndexKeySize))
+	if err != nil {
+		t.Fatalf("envelope.New(): %v", err)
+	}
+	fields := pii.New(keyring)
+
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			db := backend.open(t)
+			r := NewUserRequestRepo(dbtest.Cluster(t, db), 0, fields)
+			ctx := context.Background()
+
+			fromID := freeIDs(t, db, 4)
+			// emails are unique across runs against the same database
+			email := func(name string) string {
+				return name + "." + time.Now().Format("150405.000000000") + "@example.com"
+			}
+			create := func(id uint64, email string) error {
+				_, err := r.CreateUserRequest(ctx, &model.UserRequest{ID_user: id, Name: "user", Email: email, CreatedAt: time.Now()})
+
+				return err
+			}
+			stored := func(id uint64) (string, sql.NullString) {
+				t.Helper()
+
+				var row model.UserRequest
+				query := db.Rebind("SELECT * FROM " + userRequestTable + " WHERE " + userRequestIDColumn + " = ?")
+				if err := db.GetContext(ctx, &row, query, id); err != nil {
+					t.Fatalf("select user %d: %v", id, err)
+				}
+
+				return row.Email, row.EmailBlindIndex
+			}
+
+			taken := email("taken")
+			if err := create(fromID, taken); err != nil {
+				t.Fatalf("CreateUserRequest() error = %v", err)
+			}
+
+			t.Run("equal emails share blind index", func(t *testing.T) {
+				// duplicate users are consolidated by merge, so their emails are not rejected
+				if err := create(fromID+1, " "+strings.ToUpper(taken)); err != nil {
+					t.Fatalf("CreateUserRequest() error = %v", err)
+				}
+				value, index := stored(fromID + 1)
+				if _, takenIndex := stored(fromID); !index.Valid || index != takenIndex {
+					t.Errorf("index = %v, want index %v of normalized email", index, takenIndex)
+				}
+				if value == taken {
+					t.Errorf("email = %q, want sealed email", value)
+				}
+			})
+
+			t.Run("empty emails are not sealed", func(t *testing.T) {
+				for _, id := range []uint64{fromID + 2, fromID + 3} {
+					if err := create(id, ""); err != nil {
+						t.Fatalf("CreateUserRequest() error = %v", err)
+					}
+					if value, index := stored(id); value != "" || index.Valid {
+						t.Errorf("user %d email = %q, index = %v, want empty email without index", id, value, index)
+					}
+				}
+			})
+
+			t.Run("rotation unseals empty emails", func(t *testing.T) {
+				// erased email sealed by rotation before empty emails were skipped
+				sealed, err := keyring.Seal(nil, []byte("users.email:"+strconv.FormatUint(fromID+2, 10)))
+				if err != nil {
+					t.Fatalf("Seal() error = %v", err)
+				}
+				query := db.Rebind("UPDATE " + userRequestTable + " SET " + userRequestEmailColumn + " = ?, " +
+					userRequestEmailIndexColumn + " = ? WHERE " + userRequestIDColumn + " = ?")
+				if _, err = db.ExecContext(ctx, query, sealed, keyring.BlindIndex(nil), fromID+2); err != nil {
+					t.Fatalf("update user: %v", err)
+				}
+
+				for afterID := fromID + 1; afterID != 0 && afterID < fromID+4; {
+					if afterID, _, err = r.RotateEmailKeys(ctx, afterID, 1); err != nil {
+						t.Fatalf("RotateEmailKeys() error = %v", err)
+					}
+				}
+
+				if value, index := stored(fromID + 2); value != "" || index.Valid {
+					t.Errorf("email = %q, index = %v, want empty email without index", value, index)
+				}
+				if value, index := stored(fromID + 3); value != "" || index.Valid {
+					t.Errorf("email = %q, index = %v, empty email must stay unsealed", value, index)
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package erasure
+
+import (
+	"context"
+	"time"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+const (
+	receiptTable          = "users_erasure_receipts"
+	receiptIDColumn       = "id"
+	receiptUserIDColumn   = "id_user"
+	receiptReasonColumn   = "reason"
+	receiptSummaryColumn  = "summary"
+	receiptErasedAtColumn = "erased_at"
+	candidateTable        = "users_duplicate_candidates"
+	candidateUserAColumn  = "id_user_a"
+	candidateUserBColumn  = "id_user_b"
+)
+
+// Repo is DAO for erasure receipts and data derived from personal data of users
+type Repo interface {
+	AddReceipt(ctx context.Context, receipt *model.ErasureReceipt) (uint64, error)
+	RemoveDuplicateCandidates(ctx context.Context, userIDs []uint64) (int64, error)
+}
+
+type repo struct {
+	db      *sqlx.DB
+	dialect *database.Dialect
+}
+
+// NewRepo returns Repo interface
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db:      db,
+		dialect: database.DialectOf(db),
+	}
+}
+
+func (r *repo) AddReceipt(ctx context.Context, receipt *model.ErasureReceipt) (uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.AddErasureReceipt")
+	defer span.Finish()
+	defer database.QueryTimer("repo.AddErasureReceipt").ObserveDuration()
+
+	erasedAt := receipt.ErasedAt
+	if erasedAt.IsZero() {
+		erasedAt = time.Now()
+	}
+
+	sb := r.dialect.Builder().
+		Insert(receiptTable).
+		Columns(
+			receiptUserIDColumn,
+			receiptReasonColumn,
+			receiptSummaryColumn,
+			receiptErasedAtColumn).
+		Values(
+			receipt.UserID,
+			receipt.Reason,
+			r.dialect.JSON(receipt.Summary),
+			erasedAt,
+		).Suffix("RETURNING " + receiptIDColumn)
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	queryer := database.Queryer(ctx, r.db)
+
+	var id uint64
+	if err = queryer.QueryRowxContext(ctx, query, args...).Scan(&id); err != nil {
+		return 0, errors.Wrap(err, "db.QueryRowxContext()")
+	}
+
+	return id, nil
+}
+
+// RemoveDuplicateCandidates - remove pairs of likely duplicates with any of users,
+// they were scored by personal data
+func (r *repo) RemoveDuplicateCandidates(ctx context.Context, userIDs []uint64) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.RemoveDuplicateCandidates")
+	defer span.Finish()
+	defer database.QueryTimer("repo.RemoveDuplicateCandidates").ObserveDuration()
+
+	sb := r.dialect.Builder().
+		Delete(candidateTable).
+		Where(sq.Or{
+			sq.Eq{candidateUserAColumn: userIDs},
+			sq.Eq{candidateUserBColumn: userIDs}})
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := database.Queryer(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "db.ExecContext()")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "repo.RowsAffected()")
+	}
+
+	return affected, nil
+}
@@ -0,0 +1,124 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+const (
+	eventTable           = "users_events"
+	eventUserIDColumn    = "id_user"
+	eventTypeColumn      = "type"
+	eventPayloadColumn   = "payload"
+	eventCreatedAtColumn = "created_at"
+)
+
+// Repo is DAO for user events, events are written in the same transaction as the change they describe
+type Repo interface {
+	Add(ctx context.Context, events []model.UserEvent) error
+	ScrubPayloads(ctx context.Context, userIDs []uint64, keys []string) (int64, error)
+}
+
+type repo struct {
+	db      *sqlx.DB
+	dialect *database.Dialect
+}
+
+// NewRepo returns Repo interface
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db:      db,
+		dialect: database.DialectOf(db),
+	}
+}
+
+func (r *repo) Add(ctx context.Context, events []model.UserEvent) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.AddEvents")
+	defer span.Finish()
+	defer database.QueryTimer("repo.AddEvents").ObserveDuration()
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	sb := r.dialect.Builder().
+		Insert(eventTable).
+		Columns(
+			eventUserIDColumn,
+			eventTypeColumn,
+			eventPayloadColumn,
+			eventCreatedAtColumn)
+
+	now := time.Now()
+	for _, e := range events {
+		createdAt := e.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = now
+		}
+		sb = sb.Values(e.UserID, string(e.Type), e.Payload, createdAt)
+	}
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return err
+	}
+
+	execer := database.Queryer(ctx, r.db)
+
+	if _, err = execer.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, "db.ExecContext()")
+	}
+
+	return nil
+}
+
+// ScrubPayloads - remove keys from payloads of events of users, returns number of changed events.
+// Events of other users refer to these users by id only, see model.UserMerged
+func (r *repo) ScrubPayloads(ctx context.Context, userIDs []uint64, keys []string) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.ScrubEventPayloads")
+	defer span.Finish()
+	defer database.QueryTimer("repo.ScrubEventPayloads").ObserveDuration()
+
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	hasKey := sq.Or{}
+	for _, key := range keys {
+		hasKey = append(hasKey, r.dialect.JSONHasKey(eventPayloadColumn, key))
+	}
+
+	sb := r.dialect.Builder().
+		Update(eventTable).
+		Set(eventPayloadColumn, r.dialect.JSONMergeKeys(eventPayloadColumn, model.EventPayload{}, keys)).
+		Where(sq.And{
+			sq.Eq{eventUserIDColumn: userIDs},
+			hasKey})
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	execer := database.Queryer(ctx, r.db)
+
+	result, err := execer.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "db.ExecContext()")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "repo.RowsAffected()")
+	}
+
+	return affected, nil
+}
@@ -0,0 +1,70 @@
+// Package export reads every user of a database for offline dumps
+package export
+
+import (
+	"context"
+	"database/sql"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/repo/pii"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+const (
+	userTable    = "users"
+	userIDColumn = "id_user"
+)
+
+// Users - call fn for every user of db in order of id and return number of users,
+// batches are read by id keyset in one read only repeatable read transaction, so they form one snapshot.
+// Emails are opened by fields
+func Users(ctx context.Context, db *sqlx.DB, fields *pii.Fields, batchSize uint64, fn func(u model.UserRequest) error) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "export.Users")
+	defer span.Finish()
+
+	dialect := database.DialectOf(db)
+
+	return database.WithTx(ctx, database.NewTransactor(db), func(ctx context.Context) (int64, error) {
+		var (
+			count   int64
+			afterID uint64
+		)
+		for {
+			query, args, err := dialect.Builder().
+				Select("*").
+				From(userTable).
+				Where(sq.Gt{userIDColumn: afterID}).
+				OrderBy(userIDColumn).
+				Limit(batchSize).
+				ToSql()
+			if err != nil {
+				return 0, err
+			}
+
+			var users []model.UserRequest
+			if err = sqlx.SelectContext(ctx, database.Queryer(ctx, db), &users, query, args...); err != nil {
+				return 0, errors.Wrap(err, "db.SelectContext()")
+			}
+			if len(users) == 0 {
+				return count, nil
+			}
+
+			if err = fields.OpenUsers(users); err != nil {
+				return 0, err
+			}
+
+			for _, u := range users {
+				if err = fn(u); err != nil {
+					return 0, err
+				}
+			}
+			count += int64(len(users))
+			afterID = users[len(users)-1].ID_user
+		}
+	}, database.WithIsolation(sql.LevelRepeatableRead), database.ReadOnly(), database.NoRetries())
+}
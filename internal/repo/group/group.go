@@ -0,0 +1,399 @@
+package group
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"time"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+const (
+	groupTable             = "groups"
+	groupIDColumn          = "id_group"
+	groupNameColumn        = "name"
+	groupDescriptionColumn = "description"
+	groupCreatedAtColumn   = "created_at"
+	groupUpdatedAtColumn   = "updated_at"
+	groupDeletedAtColumn   = "deleted_at"
+
+	memberTable         = "group_members"
+	memberGroupIDColumn = "id_group"
+	memberUserIDColumn  = "id_user"
+)
+
+// Repo is DAO for groups and their members, users are kept by repo.UserRequestRepo and may live on shards,
+// so members are ids and callers check that users exist
+type Repo interface {
+	CreateGroup(ctx context.Context, group *model.Group) (uint64, error)
+	GetGroupById(ctx context.Context, IDs []uint64) ([]model.Group, error)
+	ListGroups(ctx context.Context, limit uint64, offset uint64) ([]model.Group, error)
+	UpdateGroup(ctx context.Context, groupID uint64, name, description string) (bool, error)
+	RemoveGroups(ctx context.Context, IDs []uint64) (bool, error)
+	AddMembers(ctx context.Context, groupID uint64, userIDs []uint64) ([]uint64, error)
+	RemoveMembers(ctx context.Context, groupID uint64, userIDs []uint64) (bool, error)
+	RemoveUserMemberships(ctx context.Context, userIDs []uint64) (int64, error)
+	MoveUserMemberships(ctx context.Context, fromUserIDs []uint64, toUserID uint64) error
+	ListMembers(ctx context.Context, groupID uint64, limit uint64, offset uint64) ([]uint64, error)
+	ListUserGroups(ctx context.Context, userID uint64) ([]model.Group, error)
+}
+
+type repo struct {
+	db      *sqlx.DB
+	dialect *database.Dialect
+}
+
+// NewRepo returns Repo interface
+func NewRepo(db *sqlx.DB) Repo {
+	return &repo{
+		db:      db,
+		dialect: database.DialectOf(db),
+	}
+}
+
+func (r *repo) CreateGroup(ctx context.Context, group *model.Group) (uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.CreateGroup")
+	defer span.Finish()
+	defer database.QueryTimer("repo.CreateGroup").ObserveDuration()
+
+	sb := r.dialect.Builder().
+		Insert(groupTable).
+		Columns(
+			groupNameColumn,
+			groupDescriptionColumn,
+			groupCreatedAtColumn).
+		Values(
+			group.Name,
+			group.Description,
+			group.CreatedAt,
+		).Suffix("RETURNING " + groupIDColumn)
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var id uint64
+	err = database.Queryer(ctx, r.db).QueryRowxContext(ctx, query, args...).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+
+		return 0, errors.Wrap(err, "db.QueryRowxContext()")
+	}
+
+	return id, nil
+}
+
+func (r *repo) GetGroupById(ctx context.Context, IDs []uint64) ([]model.Group, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.GetGroupById")
+	defer span.Finish()
+	defer database.QueryTimer("repo.GetGroupById").ObserveDuration()
+	sb := r.dialect.Builder().
+		Select("*").
+		From(groupTable).
+		Where(sq.Eq{groupIDColumn: IDs})
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []model.Group
+	err = sqlx.SelectContext(ctx, database.Queryer(ctx, r.db), &groups, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.SelectContext()")
+	}
+
+	return groups, nil
+}
+
+func (r *repo) ListGroups(ctx context.Context, limit uint64, offset uint64) ([]model.Group, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.ListGroups")
+	defer span.Finish()
+	defer database.QueryTimer("repo.ListGroups").ObserveDuration()
+	sb := r.dialect.Builder().
+		Select("*").
+		From(groupTable).
+		Where(sq.Eq{groupDeletedAtColumn: nil}).
+		OrderBy(groupIDColumn).
+		Limit(limit).
+		Offset(offset)
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []model.Group
+	err = sqlx.SelectContext(ctx, database.Queryer(ctx, r.db), &groups, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.SelectContext()")
+	}
+
+	return groups, nil
+}
+
+func (r *repo) UpdateGroup(ctx context.Context, groupID uint64, name, description string) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.UpdateGroup")
+	defer span.Finish()
+	defer database.QueryTimer("repo.UpdateGroup").ObserveDuration()
+	sb := r.dialect.Builder().
+		Update(groupTable).
+		Set(groupUpdatedAtColumn, time.Now()).
+		Set(groupNameColumn, name).
+		Set(groupDescriptionColumn, description).
+		Where(sq.And{
+			sq.Eq{groupIDColumn: groupID},
+			sq.Eq{groupDeletedAtColumn: nil}})
+
+	return r.exec(ctx, sb)
+}
+
+// RemoveGroups - soft delete groups, memberships of removed groups are dropped
+func (r *repo) RemoveGroups(ctx context.Context, IDs []uint64) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.RemoveGroups")
+	defer span.Finish()
+	defer database.QueryTimer("repo.RemoveGroups").ObserveDuration()
+	sb := r.dialect.Builder().
+		Update(groupTable).
+		Set(groupDeletedAtColumn, time.Now()).
+		Where(sq.And{
+			sq.Eq{groupIDColumn: IDs},
+			sq.Eq{groupDeletedAtColumn: nil}})
+
+	removed, err := r.exec(ctx, sb)
+	if err != nil || !removed {
+		return removed, err
+	}
+
+	deleteMembers := r.dialect.Builder().
+		Delete(memberTable).
+		Where(sq.Eq{memberGroupIDColumn: IDs})
+
+	if _, err = r.exec(ctx, deleteMembers); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// AddMembers - add users to group, returns ids of new members, removed groups and existing memberships
+// are skipped. Users are not checked, callers pass ids of existing users
+func (r *repo) AddMembers(ctx context.Context, groupID uint64, userIDs []uint64) ([]uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.AddMembers")
+	defer span.Finish()
+	defer database.QueryTimer("repo.AddMembers").ObserveDuration()
+
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	candidates := sq.
+		Select("g."+groupIDColumn, "u."+memberUserIDColumn).
+		From(groupTable + " g").
+		JoinClause(sq.ConcatExpr("CROSS JOIN ", r.userIDs(userIDs), " u")).
+		Where(sq.And{
+			sq.Eq{"g." + groupIDColumn: groupID},
+			sq.Eq{"g." + groupDeletedAtColumn: nil}})
+
+	sb := r.dialect.Builder().
+		Insert(memberTable).
+		Columns(memberGroupIDColumn, memberUserIDColumn).
+		Select(candidates).
+		Suffix("ON CONFLICT DO NOTHING RETURNING " + memberUserIDColumn)
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var added []uint64
+	rows, err := database.Queryer(ctx, r.db).QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.QueryxContext()")
+	}
+	//nolint
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uint64
+		if err = rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(err, "rows.Scan()")
+		}
+		added = append(added, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows.Err()")
+	}
+	sort.Slice(added, func(i, j int) bool { return added[i] < added[j] })
+
+	return added, nil
+}
+
+func (r *repo) RemoveMembers(ctx context.Context, groupID uint64, userIDs []uint64) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.RemoveMembers")
+	defer span.Finish()
+	defer database.QueryTimer("repo.RemoveMembers").ObserveDuration()
+	sb := r.dialect.Builder().
+		Delete(memberTable).
+		Where(sq.And{
+			sq.Eq{memberGroupIDColumn: groupID},
+			sq.Eq{memberUserIDColumn: userIDs}})
+
+	return r.exec(ctx, sb)
+}
+
+// RemoveUserMemberships - drop all memberships of users, used when users are removed
+func (r *repo) RemoveUserMemberships(ctx context.Context, userIDs []uint64) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.RemoveUserMemberships")
+	defer span.Finish()
+	defer database.QueryTimer("repo.RemoveUserMemberships").ObserveDuration()
+	sb := r.dialect.Builder().
+		Delete(memberTable).
+		Where(sq.Eq{memberUserIDColumn: userIDs})
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := database.Queryer(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "db.ExecContext()")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "repo.RowsAffected()")
+	}
+
+	return affected, nil
+}
+
+// MoveUserMemberships - copy memberships of users to another user and drop the original ones
+func (r *repo) MoveUserMemberships(ctx context.Context, fromUserIDs []uint64, toUserID uint64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.MoveUserMemberships")
+	defer span.Finish()
+	defer database.QueryTimer("repo.MoveUserMemberships").ObserveDuration()
+
+	memberships := sq.
+		Select().
+		Column("DISTINCT " + memberGroupIDColumn).
+		Column(r.dialect.BigInt(toUserID)).
+		From(memberTable).
+		Where(sq.Eq{memberUserIDColumn: fromUserIDs})
+
+	sb := r.dialect.Builder().
+		Insert(memberTable).
+		Columns(memberGroupIDColumn, memberUserIDColumn).
+		Select(memberships).
+		Suffix("ON CONFLICT DO NOTHING")
+
+	if _, err := r.exec(ctx, sb); err != nil {
+		return err
+	}
+
+	if _, err := r.RemoveUserMemberships(ctx, fromUserIDs); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListMembers - ids of members of group in ascending order
+func (r *repo) ListMembers(ctx context.Context, groupID uint64, limit uint64, offset uint64) ([]uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.ListMembers")
+	defer span.Finish()
+	defer database.QueryTimer("repo.ListMembers").ObserveDuration()
+	sb := r.dialect.Builder().
+		Select(memberUserIDColumn).
+		From(memberTable).
+		Where(sq.Eq{memberGroupIDColumn: groupID}).
+		OrderBy(memberUserIDColumn).
+		Limit(limit).
+		Offset(offset)
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var userIDs []uint64
+	err = sqlx.SelectContext(ctx, database.Queryer(ctx, r.db), &userIDs, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.SelectContext()")
+	}
+
+	return userIDs, nil
+}
+
+func (r *repo) ListUserGroups(ctx context.Context, userID uint64) ([]model.Group, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.ListUserGroups")
+	defer span.Finish()
+	defer database.QueryTimer("repo.ListUserGroups").ObserveDuration()
+	sb := r.dialect.Builder().
+		Select("g.*").
+		From(groupTable + " g").
+		Join(memberTable + " m ON m." + memberGroupIDColumn + " = g." + groupIDColumn).
+		Where(sq.And{
+			sq.Eq{"m." + memberUserIDColumn: userID},
+			sq.Eq{"g." + groupDeletedAtColumn: nil}}).
+		OrderBy("g." + groupIDColumn)
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []model.Group
+	err = sqlx.SelectContext(ctx, database.Queryer(ctx, r.db), &groups, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.SelectContext()")
+	}
+
+	return groups, nil
+}
+
+// userIDs - derived table of ids with column id_user
+func (r *repo) userIDs(IDs []uint64) sq.Sqlizer {
+	selects := make([]string, 0, len(IDs))
+	args := make([]interface{}, 0, len(IDs))
+	for _, id := range IDs {
+		// BigInt is a plain expression, it has no building errors
+		param, paramArgs, _ := r.dialect.BigInt(id).ToSql()
+		selects = append(selects, "SELECT "+param+" AS "+memberUserIDColumn)
+		args = append(args, paramArgs...)
+	}
+
+	return sq.Expr("("+strings.Join(selects, " UNION ALL ")+")", args...)
+}
+
+func (r *repo) exec(ctx context.Context, sb sq.Sqlizer) (bool, error) {
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	result, err := database.Queryer(ctx, r.db).ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, errors.Wrap(err, "db.ExecContext()")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "repo.RowsAffected()")
+	}
+
+	return affected > 0, nil
+}
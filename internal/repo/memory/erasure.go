@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"cmd/main.go/internal/model"
+	erasurerepo "cmd/main.go/internal/repo/erasure"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+type erasureRepo struct {
+	store *Store
+}
+
+// NewErasureRepo returns in-memory erasurerepo.Repo
+func NewErasureRepo(store *Store) erasurerepo.Repo {
+	return &erasureRepo{store: store}
+}
+
+func (r *erasureRepo) AddReceipt(ctx context.Context, receipt *model.ErasureReceipt) (uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.AddErasureReceipt")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	stored := *receipt
+	stored.ID = uint64(len(r.store.receipts)) + 1
+	if stored.ErasedAt.IsZero() {
+		stored.ErasedAt = time.Now()
+	}
+
+	size := len(r.store.receipts)
+	r.store.receipts = append(r.store.receipts, stored)
+	r.store.onRollback(ctx, func() { r.store.receipts = r.store.receipts[:size] })
+
+	return stored.ID, nil
+}
+
+// RemoveDuplicateCandidates - duplicate analysis does not run on in-memory storage, so there are no candidates
+func (r *erasureRepo) RemoveDuplicateCandidates(_ context.Context, _ []uint64) (int64, error) {
+	return 0, nil
+}
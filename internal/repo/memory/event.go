@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"cmd/main.go/internal/model"
+	eventrepo "cmd/main.go/internal/repo/event"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+type eventRepo struct {
+	store *Store
+}
+
+// NewEventRepo returns in-memory eventrepo.Repo, events are appended in the same transaction as the change
+func NewEventRepo(store *Store) eventrepo.Repo {
+	return &eventRepo{store: store}
+}
+
+func (r *eventRepo) Add(ctx context.Context, events []model.UserEvent) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.AddEvents")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	size := len(r.store.events)
+	now := time.Now()
+	for _, e := range events {
+		r.store.nextEventID++
+		e.ID = r.store.nextEventID
+		if e.CreatedAt.IsZero() {
+			e.CreatedAt = now
+		}
+		r.store.events = append(r.store.events, e)
+	}
+	r.store.onRollback(ctx, func() { r.store.events = r.store.events[:size] })
+
+	return nil
+}
+
+func (r *eventRepo) ScrubPayloads(ctx context.Context, userIDs []uint64, keys []string) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.ScrubEventPayloads")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	users := idSet(userIDs)
+
+	var affected int64
+	for i := range r.store.events {
+		i := i
+		e := r.store.events[i]
+		if _, ok := users[e.UserID]; !ok {
+			continue
+		}
+
+		payload := make(model.EventPayload, len(e.Payload))
+		for key, value := range e.Payload {
+			payload[key] = value
+		}
+		for _, key := range keys {
+			delete(payload, key)
+		}
+		if len(payload) == len(e.Payload) {
+			continue
+		}
+
+		prev := e.Payload
+		r.store.events[i].Payload = payload
+		r.store.onRollback(ctx, func() { r.store.events[i].Payload = prev })
+		affected++
+	}
+
+	return affected, nil
+}
@@ -0,0 +1,298 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"cmd/main.go/internal/model"
+	grouprepo "cmd/main.go/internal/repo/group"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+// ErrDuplicateGroupName is a "group with this name already exists" error, Postgres enforces it with unique index
+var ErrDuplicateGroupName = errors.New("group with this name already exists")
+
+type groupRepo struct {
+	store *Store
+}
+
+// NewGroupRepo returns in-memory grouprepo.Repo sharing users with repo from the same store
+func NewGroupRepo(store *Store) grouprepo.Repo {
+	return &groupRepo{store: store}
+}
+
+func (r *groupRepo) CreateGroup(ctx context.Context, group *model.Group) (uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.CreateGroup")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	if r.nameTaken(group.Name, 0) {
+		return 0, errors.Wrapf(ErrDuplicateGroupName, "name %q", group.Name)
+	}
+
+	// like a sequence, the counter is not reverted on rollback
+	r.store.nextGroupID++
+	id := r.store.nextGroupID
+
+	r.store.groups[id] = model.Group{
+		ID_group:    id,
+		Name:        group.Name,
+		Description: group.Description,
+		CreatedAt:   group.CreatedAt,
+	}
+	r.store.onRollback(ctx, func() { delete(r.store.groups, id) })
+
+	return id, nil
+}
+
+func (r *groupRepo) GetGroupById(ctx context.Context, IDs []uint64) ([]model.Group, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.GetGroupById")
+	defer span.Finish()
+	defer r.store.rlock(ctx)()
+
+	var groups []model.Group
+	for id := range idSet(IDs) {
+		if g, ok := r.store.groups[id]; ok {
+			groups = append(groups, g)
+		}
+	}
+	sortGroups(groups)
+
+	return groups, nil
+}
+
+func (r *groupRepo) ListGroups(ctx context.Context, limit uint64, offset uint64) ([]model.Group, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.ListGroups")
+	defer span.Finish()
+	defer r.store.rlock(ctx)()
+
+	var groups []model.Group
+	for _, g := range r.store.groups {
+		if !g.DeletedAt.Valid {
+			groups = append(groups, g)
+		}
+	}
+	sortGroups(groups)
+
+	return page(groups, limit, offset), nil
+}
+
+func (r *groupRepo) UpdateGroup(ctx context.Context, groupID uint64, name, description string) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.UpdateGroup")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	prev, ok := r.store.groups[groupID]
+	if !ok || prev.DeletedAt.Valid {
+		return false, nil
+	}
+
+	if r.nameTaken(name, groupID) {
+		return false, errors.Wrapf(ErrDuplicateGroupName, "name %q", name)
+	}
+
+	g := prev
+	g.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	g.Name = name
+	g.Description = description
+	r.store.groups[groupID] = g
+	r.store.onRollback(ctx, func() { r.store.groups[groupID] = prev })
+
+	return true, nil
+}
+
+// RemoveGroups - soft delete groups, memberships of removed groups are dropped
+func (r *groupRepo) RemoveGroups(ctx context.Context, IDs []uint64) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.RemoveGroups")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	now := time.Now()
+	var removed bool
+	for id := range idSet(IDs) {
+		id := id
+		prev, ok := r.store.groups[id]
+		if !ok || prev.DeletedAt.Valid {
+			continue
+		}
+
+		g := prev
+		g.DeletedAt = sql.NullTime{Time: now, Valid: true}
+		r.store.groups[id] = g
+		r.store.onRollback(ctx, func() { r.store.groups[id] = prev })
+		removed = true
+	}
+
+	if !removed {
+		return false, nil
+	}
+
+	for id := range idSet(IDs) {
+		for userID := range r.store.members[id] {
+			r.removeMember(ctx, id, userID)
+		}
+	}
+
+	return true, nil
+}
+
+// AddMembers - add users to group, returns ids of new members, removed groups and existing memberships
+// are skipped. Users are not checked like in SQL repository
+func (r *groupRepo) AddMembers(ctx context.Context, groupID uint64, userIDs []uint64) ([]uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.AddMembers")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	if g, ok := r.store.groups[groupID]; !ok || g.DeletedAt.Valid {
+		return nil, nil
+	}
+
+	var added []uint64
+	for userID := range idSet(userIDs) {
+		if r.addMember(ctx, groupID, userID) {
+			added = append(added, userID)
+		}
+	}
+	sort.Slice(added, func(i, j int) bool { return added[i] < added[j] })
+
+	return added, nil
+}
+
+func (r *groupRepo) RemoveMembers(ctx context.Context, groupID uint64, userIDs []uint64) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.RemoveMembers")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	var removed bool
+	for userID := range idSet(userIDs) {
+		if r.removeMember(ctx, groupID, userID) {
+			removed = true
+		}
+	}
+
+	return removed, nil
+}
+
+// RemoveUserMemberships - drop all memberships of users, used when users are removed
+func (r *groupRepo) RemoveUserMemberships(ctx context.Context, userIDs []uint64) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.RemoveUserMemberships")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	var affected int64
+	for groupID := range r.store.members {
+		for userID := range idSet(userIDs) {
+			if r.removeMember(ctx, groupID, userID) {
+				affected++
+			}
+		}
+	}
+
+	return affected, nil
+}
+
+// MoveUserMemberships - copy memberships of users to another user and drop the original ones
+func (r *groupRepo) MoveUserMemberships(ctx context.Context, fromUserIDs []uint64, toUserID uint64) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.MoveUserMemberships")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	from := idSet(fromUserIDs)
+	for groupID, userIDs := range r.store.members {
+		for userID := range userIDs {
+			if _, ok := from[userID]; !ok {
+				continue
+			}
+
+			r.removeMember(ctx, groupID, userID)
+			r.addMember(ctx, groupID, toUserID)
+		}
+	}
+
+	return nil
+}
+
+func (r *groupRepo) ListMembers(ctx context.Context, groupID uint64, limit uint64, offset uint64) ([]uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.ListMembers")
+	defer span.Finish()
+	defer r.store.rlock(ctx)()
+
+	userIDs := make([]uint64, 0, len(r.store.members[groupID]))
+	for userID := range r.store.members[groupID] {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Slice(userIDs, func(i, j int) bool { return userIDs[i] < userIDs[j] })
+
+	return page(userIDs, limit, offset), nil
+}
+
+func (r *groupRepo) ListUserGroups(ctx context.Context, userID uint64) ([]model.Group, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.ListUserGroups")
+	defer span.Finish()
+	defer r.store.rlock(ctx)()
+
+	var groups []model.Group
+	for groupID, userIDs := range r.store.members {
+		if _, ok := userIDs[userID]; !ok {
+			continue
+		}
+		if g, ok := r.store.groups[groupID]; ok && !g.DeletedAt.Valid {
+			groups = append(groups, g)
+		}
+	}
+	sortGroups(groups)
+
+	return groups, nil
+}
+
+// nameTaken - check unique name among not removed groups, caller holds the lock
+func (r *groupRepo) nameTaken(name string, exceptID uint64) bool {
+	for id, g := range r.store.groups {
+		if id != exceptID && !g.DeletedAt.Valid && g.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addMember - caller holds the write lock
+func (r *groupRepo) addMember(ctx context.Context, groupID, userID uint64) bool {
+	userIDs, ok := r.store.members[groupID]
+	if !ok {
+		userIDs = make(map[uint64]struct{})
+		r.store.members[groupID] = userIDs
+	}
+
+	if _, ok = userIDs[userID]; ok {
+		return false
+	}
+
+	userIDs[userID] = struct{}{}
+	r.store.onRollback(ctx, func() { delete(userIDs, userID) })
+
+	return true
+}
+
+// removeMember - caller holds the write lock
+func (r *groupRepo) removeMember(ctx context.Context, groupID, userID uint64) bool {
+	userIDs := r.store.members[groupID]
+	if _, ok := userIDs[userID]; !ok {
+		return false
+	}
+
+	delete(userIDs, userID)
+	r.store.onRollback(ctx, func() { userIDs[userID] = struct{}{} })
+
+	return true
+}
+
+func sortGroups(groups []model.Group) {
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].ID_group < groups[j].ID_group
+	})
+}
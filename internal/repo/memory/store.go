@@ -0,0 +1,136 @@
+// Package memory contains in-memory implementations of repositories,
+// it is selected with `database.driver: memory` and lets the service run without Postgres
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+)
+
+// Driver is a value of database.driver that selects in-memory storage
+const Driver = "memory"
+
+// Store keeps all tables of in-memory storage, repositories created from one Store share data and transactions
+type Store struct {
+	mu sync.RWMutex
+
+	users       map[uint64]model.UserRequest
+	groups      map[uint64]model.Group
+	members     map[uint64]map[uint64]struct{}
+	events      []model.UserEvent
+	receipts    []model.ErasureReceipt
+	nextGroupID uint64
+	nextEventID uint64
+}
+
+// NewStore returns empty Store
+func NewStore() *Store {
+	return &Store{
+		users:   make(map[uint64]model.UserRequest),
+		groups:  make(map[uint64]model.Group),
+		members: make(map[uint64]map[uint64]struct{}),
+	}
+}
+
+type txKey struct{}
+
+// memTx holds store lock for the whole transaction and undo log that is replayed on rollback
+type memTx struct {
+	store *Store
+	undo  []func()
+}
+
+func (s *Store) tx(ctx context.Context) *memTx {
+	tx, ok := ctx.Value(txKey{}).(*memTx)
+	if !ok || tx.store != s {
+		return nil
+	}
+
+	return tx
+}
+
+// lock - take write lock unless it is already held by transaction from ctx
+func (s *Store) lock(ctx context.Context) func() {
+	if s.tx(ctx) != nil {
+		return func() {}
+	}
+	s.mu.Lock()
+
+	return s.mu.Unlock
+}
+
+// rlock - take read lock unless write lock is already held by transaction from ctx
+func (s *Store) rlock(ctx context.Context) func() {
+	if s.tx(ctx) != nil {
+		return func() {}
+	}
+	s.mu.RLock()
+
+	return s.mu.RUnlock
+}
+
+// onRollback - remember how to revert a change made in transaction from ctx,
+// changes made outside of transaction are applied immediately like autocommit statements
+func (s *Store) onRollback(ctx context.Context, undo func()) {
+	if tx := s.tx(ctx); tx != nil {
+		tx.undo = append(tx.undo, undo)
+	}
+}
+
+// Transactor returns database.Transactor for the store, transactions are serialized
+func (s *Store) Transactor() database.Transactor {
+	return transactor{store: s}
+}
+
+type transactor struct {
+	store *Store
+}
+
+// InTx - transactions hold the store lock, so they are serializable and options are not needed
+func (t transactor) InTx(ctx context.Context, fn func(ctx context.Context) error, _ ...database.TxOption) error {
+	if t.store.tx(ctx) != nil {
+		return fn(ctx)
+	}
+
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	tx := &memTx{store: t.store}
+	ctx, hooks := database.WithCommitHooks(ctx)
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		for i := len(tx.undo) - 1; i >= 0; i-- {
+			tx.undo[i]()
+		}
+
+		return err
+	}
+	hooks.Run()
+
+	return nil
+}
+
+func idSet(IDs []uint64) map[uint64]struct{} {
+	set := make(map[uint64]struct{}, len(IDs))
+	for _, id := range IDs {
+		set[id] = struct{}{}
+	}
+
+	return set
+}
+
+// page - apply limit and offset to sorted rows
+func page[T any](rows []T, limit uint64, offset uint64) []T {
+	if offset >= uint64(len(rows)) {
+		return nil
+	}
+	rows = rows[offset:]
+
+	if limit < uint64(len(rows)) {
+		rows = rows[:limit]
+	}
+
+	return rows
+}
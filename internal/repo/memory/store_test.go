@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+)
+
+func TestTransactorRollback(t *testing.T) {
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	store := NewStore()
+	users := NewUserRequestRepo(store)
+	groups := NewGroupRepo(store)
+
+	for id := uint64(1); id <= 3; id++ {
+		if _, err := users.CreateUserRequest(ctx, &model.UserRequest{ID_user: id, Name: "before", CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("CreateUserRequest() error = %v", err)
+		}
+	}
+	groupID, err := groups.CreateGroup(ctx, &model.Group{Name: "before"})
+	if err != nil {
+		t.Fatalf("CreateGroup() error = %v", err)
+	}
+	if _, err = groups.AddMembers(ctx, groupID, []uint64{1}); err != nil {
+		t.Fatalf("AddMembers() error = %v", err)
+	}
+
+	var committed bool
+	err = store.Transactor().InTx(ctx, func(ctx context.Context) error {
+		database.AfterCommit(ctx, func() { committed = true })
+
+		steps := []func() error{
+			func() error {
+				_, err := users.CreateUserRequest(ctx, &model.UserRequest{ID_user: 4, Name: "created", CreatedAt: time.Now()})
+				return err
+			},
+			func() error {
+				_, err := users.UpdateUserByIdRequest(ctx, 1, "updated", "updated@example.com")
+				return err
+			},
+			func() error {
+				_, err := users.UpdateUserLabelsRequest(ctx, 2, model.Labels{"team": "a"}, nil)
+				return err
+			},
+			func() error {
+				_, err := users.RemoveUserRequest(ctx, []uint64{3})
+				return err
+			},
+			func() error {
+				_, err := groups.UpdateGroup(ctx, groupID, "updated", "")
+				return err
+			},
+			func() error {
+				_, err := groups.AddMembers(ctx, groupID, []uint64{2, 4})
+				return err
+			},
+			func() error {
+				_, err := groups.RemoveMembers(ctx, groupID, []uint64{1})
+				return err
+			},
+		}
+		for _, step := range steps {
+			if err := step(); err != nil {
+				return err
+			}
+		}
+
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("InTx() error = %v, want error of fn", err)
+	}
+	if committed {
+		t.Error("AfterCommit hook ran for rolled back transaction")
+	}
+
+	got, err := users.GetUserByIdRequest(ctx, []uint64{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("GetUserByIdRequest() error = %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("users = %v, want 3 users that existed before", got)
+	}
+	for _, u := range got {
+		if u.Name != "before" || u.Email != "" || len(u.Labels) != 0 || u.DeletedAt.Valid {
+			t.Errorf("user %d = %+v, want it unchanged", u.ID_user, u)
+		}
+	}
+
+	group, err := groups.GetGroupById(ctx, []uint64{groupID})
+	if err != nil || len(group) != 1 || group[0].Name != "before" {
+		t.Errorf("GetGroupById() = %v, %v, want group unchanged", group, err)
+	}
+	members, err := groups.ListMembers(ctx, groupID, 10, 0)
+	if err != nil {
+		t.Fatalf("ListMembers() error = %v", err)
+	}
+	if want := []uint64{1}; !reflect.DeepEqual(members, want) {
+		t.Errorf("members = %v, want %v", members, want)
+	}
+}
+
+func TestTransactorCommit(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	users := NewUserRequestRepo(store)
+
+	var committed bool
+	err := store.Transactor().InTx(ctx, func(ctx context.Context) error {
+		database.AfterCommit(ctx, func() { committed = true })
+
+		// nested transaction joins the outer one
+		return store.Transactor().InTx(ctx, func(ctx context.Context) error {
+			_, err := users.CreateUserRequest(ctx, &model.UserRequest{ID_user: 1, Name: "created", CreatedAt: time.Now()})
+			return err
+		})
+	})
+	if err != nil {
+		t.Fatalf("InTx() error = %v", err)
+	}
+	if !committed {
+		t.Error("AfterCommit hook did not run")
+	}
+
+	if ok, err := users.Exists(ctx, 1); err != nil || !ok {
+		t.Errorf("Exists() = %v, %v, want committed user", ok, err)
+	}
+}
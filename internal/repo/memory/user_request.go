@@ -0,0 +1,348 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/pkg/labels"
+	"cmd/main.go/internal/repo"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+// ErrDuplicateUserID is a "user with this id already exists" error, the same case is a unique violation in Postgres
+var ErrDuplicateUserID = errors.New("user with this id already exists")
+
+type userRequestRepo struct {
+	store *Store
+}
+
+// NewUserRequestRepo returns in-memory repo.UserRequestRepo, tx arguments are ignored,
+// transaction is taken from context filled by Store.Transactor
+func NewUserRequestRepo(store *Store) repo.UserRequestRepo {
+	return &userRequestRepo{store: store}
+}
+
+func (r *userRequestRepo) CreateUserRequest(ctx context.Context, userRequest *model.UserRequest) (uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.CreateUserRequest")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	return r.createUserRequest(ctx, userRequest)
+}
+
+// createUserRequest - caller holds the write lock
+func (r *userRequestRepo) createUserRequest(ctx context.Context, userRequest *model.UserRequest) (uint64, error) {
+	id := userRequest.ID_user
+	if _, ok := r.store.users[id]; ok {
+		return 0, errors.Wrapf(ErrDuplicateUserID, "id_user %d", id)
+	}
+
+	user := userRequest.Clone()
+	if user.Labels == nil {
+		user.Labels = model.Labels{}
+	}
+	if user.Profile == nil {
+		user.Profile = model.Profile{}
+	}
+
+	r.store.users[id] = user
+	r.store.onRollback(ctx, func() { delete(r.store.users, id) })
+
+	return id, nil
+}
+
+// BulkCreateUserRequest - insert users that do not exist yet, ids that are taken,
+// including repeated ids of the batch, are returned as conflicts
+func (r *userRequestRepo) BulkCreateUserRequest(ctx context.Context, userRequests []model.UserRequest) ([]uint64, []uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.BulkCreateUserRequest")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	var inserted, conflicts []uint64
+	for i := range userRequests {
+		id, err := r.createUserRequest(ctx, &userRequests[i])
+		if err != nil {
+			conflicts = append(conflicts, userRequests[i].ID_user)
+			continue
+		}
+		inserted = append(inserted, id)
+	}
+	sort.Slice(inserted, func(i, j int) bool { return inserted[i] < inserted[j] })
+
+	return inserted, conflicts, nil
+}
+
+func (r *userRequestRepo) GetUserByIdRequest(ctx context.Context, IDs []uint64) ([]model.UserRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.GetUserByIdRequest")
+	defer span.Finish()
+	defer r.store.rlock(ctx)()
+
+	return r.byIDs(IDs), nil
+}
+
+func (r *userRequestRepo) ListUserRequest(ctx context.Context, limit uint64, offset uint64, selector labels.Selector) ([]model.UserRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.ListUserRequest")
+	defer span.Finish()
+	defer r.store.rlock(ctx)()
+
+	var userRequests []model.UserRequest
+	for _, u := range r.store.users {
+		if !u.DeletedAt.Valid && selector.Matches(u.Labels) {
+			userRequests = append(userRequests, u)
+		}
+	}
+	sortUsers(userRequests)
+
+	userRequests = page(userRequests, limit, offset)
+	for i := range userRequests {
+		userRequests[i] = userRequests[i].Clone()
+	}
+
+	return userRequests, nil
+}
+
+func (r *userRequestRepo) RemoveUserRequest(ctx context.Context, IDs []uint64) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.RemoveUserRequest")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	now := time.Now()
+	affected := r.update(ctx, IDs, func(u *model.UserRequest) {
+		u.DeletedAt = sql.NullTime{Time: now, Valid: true}
+	})
+
+	return affected > 0, nil
+}
+
+// RestoreUserRequest - clear DeletedAt of removed users that are neither merged nor erased
+func (r *userRequestRepo) RestoreUserRequest(ctx context.Context, IDs []uint64) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.RestoreUserRequest")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	now := sql.NullTime{Time: time.Now(), Valid: true}
+
+	var affected int64
+	for id := range idSet(IDs) {
+		id := id
+		prev, ok := r.store.users[id]
+		if !ok || !prev.DeletedAt.Valid || prev.MergedInto.Valid || prev.ErasedAt.Valid {
+			continue
+		}
+
+		u := prev.Clone()
+		u.DeletedAt = sql.NullTime{}
+		u.UpdatedAt = now
+		r.store.users[id] = u
+		r.store.onRollback(ctx, func() { r.store.users[id] = prev })
+		affected++
+	}
+
+	return affected, nil
+}
+
+func (r *userRequestRepo) Exists(ctx context.Context, userRequestID uint64) (bool, error) {
+	defer r.store.rlock(ctx)()
+
+	u, ok := r.store.users[userRequestID]
+
+	return ok && !u.DeletedAt.Valid, nil
+}
+
+func (r *userRequestRepo) UpdateUserByIdRequest(ctx context.Context, userRequestID uint64, name, email string) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.UpdateUserByIdRequest")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	now := time.Now()
+	affected := r.update(ctx, []uint64{userRequestID}, func(u *model.UserRequest) {
+		u.UpdatedAt = sql.NullTime{Time: now, Valid: true}
+		u.Name = name
+		u.Email = email
+	})
+
+	return affected > 0, nil
+}
+
+func (r *userRequestRepo) UpdateUserLabelsRequest(ctx context.Context, userRequestID uint64, set model.Labels, removeKeys []string) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.UpdateUserLabelsRequest")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	now := time.Now()
+	affected := r.update(ctx, []uint64{userRequestID}, func(u *model.UserRequest) {
+		u.UpdatedAt = sql.NullTime{Time: now, Valid: true}
+		if u.Labels == nil {
+			u.Labels = model.Labels{}
+		}
+		for _, key := range removeKeys {
+			delete(u.Labels, key)
+		}
+		for key, value := range set {
+			u.Labels[key] = value
+		}
+	})
+
+	return affected > 0, nil
+}
+
+func (r *userRequestRepo) UpdateUserProfileRequest(ctx context.Context, userRequestID uint64, profile model.Profile) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.UpdateUserProfileRequest")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	now := time.Now()
+	affected := r.update(ctx, []uint64{userRequestID}, func(u *model.UserRequest) {
+		u.UpdatedAt = sql.NullTime{Time: now, Valid: true}
+		u.Profile = profile.Clone()
+		if u.Profile == nil {
+			u.Profile = model.Profile{}
+		}
+	})
+
+	return affected > 0, nil
+}
+
+// GetUserByIdForUpdateRequest - rows are locked by the transaction holding the whole store
+func (r *userRequestRepo) GetUserByIdForUpdateRequest(ctx context.Context, IDs []uint64) ([]model.UserRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.GetUserByIdForUpdateRequest")
+	defer span.Finish()
+	defer r.store.rlock(ctx)()
+
+	return r.byIDs(IDs), nil
+}
+
+// SaveMergedUserRequest - overwrite mergeable fields of user
+func (r *userRequestRepo) SaveMergedUserRequest(ctx context.Context, userRequest *model.UserRequest) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.SaveMergedUserRequest")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	now := time.Now()
+	merged := userRequest.Clone()
+	affected := r.update(ctx, []uint64{userRequest.ID_user}, func(u *model.UserRequest) {
+		u.UpdatedAt = sql.NullTime{Time: now, Valid: true}
+		u.Name = merged.Name
+		u.Email = merged.Email
+		u.Labels = merged.Labels
+		u.Profile = merged.Profile
+	})
+
+	return affected > 0, nil
+}
+
+// MarkMergedUserRequest - soft delete losers and point them to survivor
+func (r *userRequestRepo) MarkMergedUserRequest(ctx context.Context, survivorID uint64, loserIDs []uint64) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.MarkMergedUserRequest")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	now := time.Now()
+	affected := r.update(ctx, loserIDs, func(u *model.UserRequest) {
+		u.UpdatedAt = sql.NullTime{Time: now, Valid: true}
+		u.DeletedAt = sql.NullTime{Time: now, Valid: true}
+		u.MergedInto = sql.NullInt64{Int64: int64(survivorID), Valid: true}
+	})
+
+	return affected, nil
+}
+
+// ListMergedIntoRequest returns ids of users merged into any of survivors
+func (r *userRequestRepo) ListMergedIntoRequest(ctx context.Context, survivorIDs []uint64) ([]uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.ListMergedIntoRequest")
+	defer span.Finish()
+	defer r.store.rlock(ctx)()
+
+	survivors := idSet(survivorIDs)
+
+	var IDs []uint64
+	for _, u := range r.store.users {
+		if _, ok := survivors[uint64(u.MergedInto.Int64)]; ok && u.MergedInto.Valid {
+			IDs = append(IDs, u.ID_user)
+		}
+	}
+	sort.Slice(IDs, func(i, j int) bool { return IDs[i] < IDs[j] })
+
+	return IDs, nil
+}
+
+// EraseUserRequest - replace personal data of users with tombstones, removed users are erased as well,
+// already erased users are skipped
+func (r *userRequestRepo) EraseUserRequest(ctx context.Context, IDs []uint64) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.EraseUserRequest")
+	defer span.Finish()
+	defer r.store.lock(ctx)()
+
+	now := sql.NullTime{Time: time.Now(), Valid: true}
+
+	var affected int64
+	for id := range idSet(IDs) {
+		id := id
+		prev, ok := r.store.users[id]
+		if !ok || prev.ErasedAt.Valid {
+			continue
+		}
+
+		u := prev.Clone()
+		u.Name = ""
+		u.Email = ""
+		u.EmailBlindIndex = sql.NullString{}
+		u.Labels = model.Labels{}
+		u.Profile = model.Profile{}
+		u.UpdatedAt = now
+		if !u.DeletedAt.Valid {
+			u.DeletedAt = now
+		}
+		u.ErasedAt = now
+		r.store.users[id] = u
+		r.store.onRollback(ctx, func() { r.store.users[id] = prev })
+		affected++
+	}
+
+	return affected, nil
+}
+
+// byIDs - copies of users with given ids including removed ones, ordered by id, caller holds the lock
+func (r *userRequestRepo) byIDs(IDs []uint64) []model.UserRequest {
+	var userRequests []model.UserRequest
+	for id := range idSet(IDs) {
+		if u, ok := r.store.users[id]; ok {
+			userRequests = append(userRequests, u.Clone())
+		}
+	}
+	sortUsers(userRequests)
+
+	return userRequests
+}
+
+// update - apply fn to not removed users with given ids and return number of changed rows,
+// caller holds the write lock
+func (r *userRequestRepo) update(ctx context.Context, IDs []uint64, fn func(u *model.UserRequest)) int64 {
+	var affected int64
+	for id := range idSet(IDs) {
+		id := id
+		prev, ok := r.store.users[id]
+		if !ok || prev.DeletedAt.Valid {
+			continue
+		}
+
+		u := prev.Clone()
+		fn(&u)
+		r.store.users[id] = u
+		r.store.onRollback(ctx, func() { r.store.users[id] = prev })
+		affected++
+	}
+
+	return affected
+}
+
+func sortUsers(users []model.UserRequest) {
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID_user < users[j].ID_user
+	})
+}
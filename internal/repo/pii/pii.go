@@ -0,0 +1,178 @@
+// Package pii seals personal fields of users before repositories write them and opens them after reads.
+// Email is stored sealed by envelope encryption together with a blind index of its normalized form,
+// so equal emails are still found without decrypting them
+package pii
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/pkg/envelope"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrNoKeyring is a "sealed value is read while encryption is disabled" error
+var ErrNoKeyring = errors.New("email is encrypted but encryption is disabled")
+
+// emailTagRegexp - "+tag" of local part, see normalizedEmail of duplicate analyzer
+var emailTagRegexp = regexp.MustCompile(`\+[^@]*@`)
+
+// Fields seals personal fields of users, nil Fields keeps them in plaintext
+type Fields struct {
+	keyring *envelope.Keyring
+}
+
+// New returns Fields sealing with keyring
+func New(keyring *envelope.Keyring) *Fields {
+	return &Fields{keyring: keyring}
+}
+
+// keyFile - content of config.Encryption.KeyFile
+type keyFile struct {
+	CurrentKey    uint32            `yaml:"currentKey"`
+	Keys          map[uint32]string `yaml:"keys"`
+	BlindIndexKey string            `yaml:"blindIndexKey"`
+}
+
+// Load returns Fields with keys of cfg, nil when encryption is disabled
+func Load(cfg config.Encryption) (*Fields, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	keys := keyFile{
+		CurrentKey:    cfg.CurrentKey,
+		Keys:          cfg.Keys,
+		BlindIndexKey: cfg.BlindIndexKey,
+	}
+	if cfg.KeyFile != "" {
+		data, err := os.ReadFile(filepath.Clean(cfg.KeyFile))
+		if err != nil {
+			return nil, errors.Wrap(err, "os.ReadFile")
+		}
+
+		keys = keyFile{}
+		if err = yaml.Unmarshal(data, &keys); err != nil {
+			return nil, errors.Wrapf(err, "key file %s", cfg.KeyFile)
+		}
+	}
+
+	decoded := make(map[uint32][]byte, len(keys.Keys))
+	for version, key := range keys.Keys {
+		raw, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "key %d", version)
+		}
+		decoded[version] = raw
+	}
+
+	indexKey, err := base64.StdEncoding.DecodeString(keys.BlindIndexKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "blind index key")
+	}
+
+	keyring, err := envelope.New(keys.CurrentKey, decoded, indexKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return New(keyring), nil
+}
+
+// SealEmail returns value of email column and its blind index for user,
+// empty email of users without one or erased ones stays empty and is not indexed
+func (f *Fields) SealEmail(userID uint64, email string) (string, sql.NullString, error) {
+	if f == nil || email == "" {
+		return email, sql.NullString{}, nil
+	}
+
+	sealed, err := f.keyring.Seal([]byte(email), emailAAD(userID))
+	if err != nil {
+		return "", sql.NullString{}, errors.Wrap(err, "seal email")
+	}
+
+	return sealed, f.EmailIndex(email), nil
+}
+
+// EmailIndex returns blind index of normalized email, it is null for empty email or when encryption is disabled
+func (f *Fields) EmailIndex(email string) sql.NullString {
+	if f == nil || email == "" {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: f.keyring.BlindIndex([]byte(NormalizeEmail(email))), Valid: true}
+}
+
+// OpenEmail returns plaintext of email column of user, plaintext written before encryption is returned as is
+func (f *Fields) OpenEmail(userID uint64, value string) (string, error) {
+	if !envelope.IsSealed(value) {
+		return value, nil
+	}
+	if f == nil {
+		return "", ErrNoKeyring
+	}
+
+	email, err := f.keyring.Open(value, emailAAD(userID))
+	if err != nil {
+		return "", errors.Wrapf(err, "open email of user %d", userID)
+	}
+
+	return string(email), nil
+}
+
+// OpenUsers - replace sealed emails of users with plaintext
+func (f *Fields) OpenUsers(users []model.UserRequest) error {
+	for i := range users {
+		email, err := f.OpenEmail(users[i].ID_user, users[i].Email)
+		if err != nil {
+			return err
+		}
+		users[i].Email = email
+	}
+
+	return nil
+}
+
+// NeedsRotation - check if email column of user is plaintext or sealed by other key than the current one,
+// or its blind index was computed with other index key. Empty emails need it only when they were sealed
+// or indexed
+func (f *Fields) NeedsRotation(user model.UserRequest, email string) bool {
+	if email == "" {
+		return user.Email != "" || user.EmailBlindIndex.Valid
+	}
+	if !envelope.IsSealed(user.Email) {
+		return true
+	}
+
+	version, err := envelope.Version(user.Email)
+	if err != nil || version != f.keyring.Current() {
+		return true
+	}
+
+	return user.EmailBlindIndex != f.EmailIndex(email)
+}
+
+// NormalizeEmail - trimmed lower case email without "+tag" of local part,
+// it must match normalizedEmail of duplicate analyzer
+func NormalizeEmail(email string) string {
+	email = strings.ToLower(strings.Trim(email, " "))
+	if loc := emailTagRegexp.FindStringIndex(email); loc != nil {
+		email = email[:loc[0]] + "@" + email[loc[1]:]
+	}
+
+	return email
+}
+
+// emailAAD - sealed email is bound to its user, so it can not be copied to another row
+func emailAAD(userID uint64) []byte {
+	return []byte("users.email:" + strconv.FormatUint(userID, 10))
+}
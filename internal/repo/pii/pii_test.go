@@ -0,0 +1,206 @@
+package pii
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/pkg/envelope"
+)
+
+var testIndexKey = bytes.Repeat([]byte{9}, envelope.MinIndexKeySize)
+
+func testFields(t *testing.T, current uint32) *Fields {
+	t.Helper()
+
+	keys := map[uint32][]byte{
+		1: bytes.Repeat([]byte{1}, envelope.KeySize),
+		2: bytes.Repeat([]byte{2}, envelope.KeySize),
+	}
+	keyring, err := envelope.New(current, keys, testIndexKey)
+	if err != nil {
+		t.Fatalf("envelope.New() error = %v", err)
+	}
+
+	return New(keyring)
+}
+
+func TestSealOpenEmail(t *testing.T) {
+	f := testFields(t, 1)
+
+	sealed, index, err := f.SealEmail(7, "Bob+news@Example.com")
+	if err != nil {
+		t.Fatalf("SealEmail() error = %v", err)
+	}
+	if !envelope.IsSealed(sealed) {
+		t.Errorf("SealEmail() = %q, want sealed value", sealed)
+	}
+	if want := f.EmailIndex("bob@example.com"); index != want || !index.Valid {
+		t.Errorf("SealEmail() index = %v, want index of normalized email %v", index, want)
+	}
+
+	email, err := f.OpenEmail(7, sealed)
+	if err != nil {
+		t.Fatalf("OpenEmail() error = %v", err)
+	}
+	if email != "Bob+news@Example.com" {
+		t.Errorf("OpenEmail() = %q, want original email", email)
+	}
+
+	if _, err = f.OpenEmail(8, sealed); err == nil {
+		t.Error("OpenEmail() of email copied to other user succeeded")
+	}
+}
+
+func TestSealEmptyEmail(t *testing.T) {
+	for name, f := range map[string]*Fields{"encrypted": testFields(t, 1), "plaintext": nil} {
+		sealed, index, err := f.SealEmail(7, "")
+		if err != nil {
+			t.Fatalf("%s: SealEmail() error = %v", name, err)
+		}
+		if sealed != "" || index.Valid {
+			t.Errorf("%s: SealEmail(empty) = %q, %v, want empty and not indexed", name, sealed, index)
+		}
+	}
+}
+
+func TestPlaintextFields(t *testing.T) {
+	var f *Fields
+
+	sealed, index, err := f.SealEmail(7, "bob@example.com")
+	if err != nil || sealed != "bob@example.com" || index.Valid {
+		t.Errorf("SealEmail() = %q, %v, %v, want plaintext without index", sealed, index, err)
+	}
+
+	email, err := f.OpenEmail(7, "bob@example.com")
+	if err != nil || email != "bob@example.com" {
+		t.Errorf("OpenEmail(plaintext) = %q, %v", email, err)
+	}
+
+	sealed, _, err = testFields(t, 1).SealEmail(7, "bob@example.com")
+	if err != nil {
+		t.Fatalf("SealEmail() error = %v", err)
+	}
+	if _, err = f.OpenEmail(7, sealed); !errors.Is(err, ErrNoKeyring) {
+		t.Errorf("OpenEmail(sealed) error = %v, want %v", err, ErrNoKeyring)
+	}
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := map[string]string{
+		"bob@example.com":        "bob@example.com",
+		"  Bob@Example.COM ":     "bob@example.com",
+		"bob+news@example.com":   "bob@example.com",
+		"bob+a+b@example.com":    "bob@example.com",
+		"bob@sub+domain.example": "bob@sub+domain.example",
+		"":                       "",
+		"no-at-sign+tag":         "no-at-sign+tag",
+		"first.last@example.com": "first.last@example.com",
+	}
+
+	for email, want := range tests {
+		if got := NormalizeEmail(email); got != want {
+			t.Errorf("NormalizeEmail(%q) = %q, want %q", email, got, want)
+		}
+	}
+}
+
+func TestNeedsRotation(t *testing.T) {
+	old, current := testFields(t, 1), testFields(t, 2)
+	const email = "bob@example.com"
+
+	sealedOld, indexOld, err := old.SealEmail(7, email)
+	if err != nil {
+		t.Fatalf("SealEmail() error = %v", err)
+	}
+	sealedCurrent, indexCurrent, err := current.SealEmail(7, email)
+	if err != nil {
+		t.Fatalf("SealEmail() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		user  model.UserRequest
+		email string
+		want  bool
+	}{
+		{
+			name:  "plaintext",
+			user:  model.UserRequest{Email: email},
+			email: email,
+			want:  true,
+		},
+		{
+			name:  "sealed by old key",
+			user:  model.UserRequest{Email: sealedOld, EmailBlindIndex: indexOld},
+			email: email,
+			want:  true,
+		},
+		{
+			name:  "sealed by current key",
+			user:  model.UserRequest{Email: sealedCurrent, EmailBlindIndex: indexCurrent},
+			email: email,
+		},
+		{
+			name:  "missing index",
+			user:  model.UserRequest{Email: sealedCurrent},
+			email: email,
+			want:  true,
+		},
+		{
+			name: "empty email",
+		},
+		{
+			name: "indexed empty email",
+			user: model.UserRequest{EmailBlindIndex: sql.NullString{String: "stale", Valid: true}},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := current.NeedsRotation(tt.user, tt.email); got != tt.want {
+				t.Errorf("NeedsRotation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, envelope.KeySize))
+	indexKey := base64.StdEncoding.EncodeToString(testIndexKey)
+
+	f, err := Load(config.Encryption{})
+	if err != nil || f != nil {
+		t.Errorf("Load(disabled) = %v, %v, want nil Fields", f, err)
+	}
+
+	f, err = Load(config.Encryption{Enabled: true, CurrentKey: 1, Keys: map[uint32]string{1: key}, BlindIndexKey: indexKey})
+	if err != nil || f == nil {
+		t.Fatalf("Load() = %v, %v", f, err)
+	}
+
+	keyFile := filepath.Join(t.TempDir(), "keys.yml")
+	content := "currentKey: 3\nkeys:\n  3: " + key + "\nblindIndexKey: " + indexKey + "\n"
+	if err = os.WriteFile(keyFile, []byte(content), 0o600); err != nil {
+		t.Fatalf("write key file: %v", err)
+	}
+	// keys of key file replace the ones of config
+	f, err = Load(config.Encryption{Enabled: true, KeyFile: keyFile, CurrentKey: 1, Keys: map[uint32]string{1: key}})
+	if err != nil {
+		t.Fatalf("Load(key file) error = %v", err)
+	}
+	if got := f.keyring.Current(); got != 3 {
+		t.Errorf("Load(key file) current key = %d, want 3", got)
+	}
+
+	if _, err = Load(config.Encryption{Enabled: true, CurrentKey: 1, Keys: map[uint32]string{1: "not base64"}, BlindIndexKey: indexKey}); err == nil {
+		t.Error("Load() with malformed key succeeded")
+	}
+}
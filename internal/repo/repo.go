@@ -11,6 +11,8 @@ import (
 	"github.com/pkg/errors"
 
 	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/pkg/labels"
+	"cmd/main.go/internal/repo/pii"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
@@ -25,36 +27,75 @@ const (
 	userRequestCreatedAtColumn   = "created_at"
 	userRequestDoneAtColumn      = "done_at"
 	userRequestDeletedAtAtColumn = "deleted_at"
+	userRequestLabelsColumn      = "labels"
+	userRequestProfileColumn     = "profile"
+	userRequestMergedIntoColumn  = "merged_into"
+	userRequestEmailIndexColumn  = "email_bidx"
+	userRequestErasedAtColumn    = "erased_at"
 )
 
 // EuserRequestRepo is DAO for Euser Request
 type UserRequestRepo interface {
-	CreateUserRequest(ctx context.Context, userRequest *model.UserRequest, tx *sqlx.Tx) (uint64, error)
+	CreateUserRequest(ctx context.Context, userRequest *model.UserRequest) (uint64, error)
+	BulkCreateUserRequest(ctx context.Context, userRequests []model.UserRequest) ([]uint64, []uint64, error)
 	GetUserByIdRequest(ctx context.Context, IDs []uint64) ([]model.UserRequest, error)
-	ListUserRequest(ctx context.Context, limit uint64, offset uint64) ([]model.UserRequest, error)
-	RemoveUserRequest(ctx context.Context, IDs []uint64, tx *sqlx.Tx) (bool, error)
+	ListUserRequest(ctx context.Context, limit uint64, offset uint64, selector labels.Selector) ([]model.UserRequest, error)
+	RemoveUserRequest(ctx context.Context, IDs []uint64) (bool, error)
+	RestoreUserRequest(ctx context.Context, IDs []uint64) (int64, error)
 	Exists(ctx context.Context, userRequestID uint64) (bool, error)
-	UpdateUserByIdRequest(ctx context.Context, uerRequestID uint64, name, email string, tx *sqlx.Tx) (bool, error)
+	UpdateUserByIdRequest(ctx context.Context, uerRequestID uint64, name, email string) (bool, error)
+	UpdateUserLabelsRequest(ctx context.Context, userRequestID uint64, set model.Labels, removeKeys []string) (bool, error)
+	UpdateUserProfileRequest(ctx context.Context, userRequestID uint64, profile model.Profile) (bool, error)
+	GetUserByIdForUpdateRequest(ctx context.Context, IDs []uint64) ([]model.UserRequest, error)
+	SaveMergedUserRequest(ctx context.Context, userRequest *model.UserRequest) (bool, error)
+	MarkMergedUserRequest(ctx context.Context, survivorID uint64, loserIDs []uint64) (int64, error)
+	ListMergedIntoRequest(ctx context.Context, survivorIDs []uint64) ([]uint64, error)
+	EraseUserRequest(ctx context.Context, IDs []uint64) (int64, error)
+}
+
+// TxJoiner is a repository on database joined to transaction of other one,
+// JoinTx begins its transaction before first statement, see database.NewTransactor
+type TxJoiner interface {
+	JoinTx(ctx context.Context) error
 }
 
 type userRequestRepo struct {
 	db        *sqlx.DB
+	dialect   *database.Dialect
+	cluster   *database.Cluster
+	fields    *pii.Fields
 	batchSize uint
 }
 
-// NewEuserRequestRepo returns Repo interface
-func NewUserRequestRepo(db *sqlx.DB, batchSize uint) *userRequestRepo {
+// NewEuserRequestRepo returns Repo interface, writes go to primary,
+// GetUserByIdRequest, ListUserRequest and Exists are served by replicas of cluster.
+// Emails are sealed by fields, nil fields keeps them in plaintext
+func NewUserRequestRepo(cluster *database.Cluster, batchSize uint, fields *pii.Fields) *userRequestRepo {
 	return &userRequestRepo{
-		db:        db,
+		db:        cluster.Primary(),
+		dialect:   database.DialectOf(cluster.Primary()),
+		cluster:   cluster,
+		fields:    fields,
 		batchSize: batchSize,
 	}
 }
 
-func (r *userRequestRepo) CreateUserRequest(ctx context.Context, userRequest *model.UserRequest, tx *sqlx.Tx) (uint64, error) {
+// JoinTx - begin transaction of primary when it is joined to transaction from ctx
+func (r *userRequestRepo) JoinTx(ctx context.Context) error {
+	return database.JoinTx(ctx, r.db)
+}
+
+func (r *userRequestRepo) CreateUserRequest(ctx context.Context, userRequest *model.UserRequest) (uint64, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.CreateUserRequest")
 	defer span.Finish()
+	defer database.QueryTimer("repo.CreateUserRequest").ObserveDuration()
 
-	sb := database.StatementBuilder.
+	email, emailIndex, err := r.fields.SealEmail(userRequest.ID_user, userRequest.Email)
+	if err != nil {
+		return 0, err
+	}
+
+	sb := r.dialect.Builder().
 		Insert(userRequestTable).
 		Columns(
 			userRequestIDColumn,
@@ -63,15 +104,21 @@ func (r *userRequestRepo) CreateUserRequest(ctx context.Context, userRequest *mo
 			userRequestCreatedAtColumn,
 			userRequestUpdatedAtColumn,
 			userRequestDeletedAtAtColumn,
-			userRequestDoneAtColumn).
+			userRequestDoneAtColumn,
+			userRequestLabelsColumn,
+			userRequestProfileColumn,
+			userRequestEmailIndexColumn).
 		Values(
 			userRequest.ID_user,
 			userRequest.Name,
-			userRequest.Email,
+			email,
 			userRequest.CreatedAt,
 			userRequest.UpdatedAt,
 			userRequest.DeletedAt,
 			userRequest.DoneAt,
+			userRequest.Labels,
+			userRequest.Profile,
+			emailIndex,
 		).Suffix("RETURNING " + userRequestIDColumn)
 
 	query, args, err := sb.ToSql()
@@ -79,12 +126,7 @@ func (r *userRequestRepo) CreateUserRequest(ctx context.Context, userRequest *mo
 		return 0, err
 	}
 
-	var queryer sqlx.QueryerContext
-	if tx == nil {
-		queryer = r.db
-	} else {
-		queryer = tx
-	}
+	queryer := database.Queryer(ctx, r.db)
 
 	var id uint64
 	err = queryer.QueryRowxContext(ctx, query, args...).Scan(&id)
@@ -103,7 +145,8 @@ func (r *userRequestRepo) CreateUserRequest(ctx context.Context, userRequest *mo
 func (r *userRequestRepo) GetUserByIdRequest(ctx context.Context, IDs []uint64) ([]model.UserRequest, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.GetUserByIdRequest")
 	defer span.Finish()
-	sb := database.StatementBuilder.
+	defer database.QueryTimer("repo.GetUserByIdRequest").ObserveDuration()
+	sb := r.dialect.Builder().
 		Select("*").
 		From(userRequestTable).
 		Where(sq.Eq{userRequestIDColumn: IDs})
@@ -113,21 +156,27 @@ func (r *userRequestRepo) GetUserByIdRequest(ctx context.Context, IDs []uint64)
 		return nil, err
 	}
 	var userRequests []model.UserRequest
-	err = r.db.SelectContext(ctx, &userRequests, query, args...)
+	err = sqlx.SelectContext(ctx, r.cluster.Reader(ctx), &userRequests, query, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "db.SelectContext()")
 	}
 
+	if err = r.fields.OpenUsers(userRequests); err != nil {
+		return nil, err
+	}
+
 	return userRequests, nil
 }
 
-func (r *userRequestRepo) ListUserRequest(ctx context.Context, limit uint64, offset uint64) ([]model.UserRequest, error) {
+func (r *userRequestRepo) ListUserRequest(ctx context.Context, limit uint64, offset uint64, selector labels.Selector) ([]model.UserRequest, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.ListUserRequest")
 	defer span.Finish()
-	sb := database.StatementBuilder.
+	defer database.QueryTimer("repo.ListUserRequest").ObserveDuration()
+	sb := r.dialect.Builder().
 		Select("*").
 		From(userRequestTable).
 		Where(sq.Eq{userRequestDeletedAtAtColumn: nil}).
+		Where(labelSelectorToSql(r.dialect, selector)).
 		OrderBy(userRequestIDColumn).
 		Limit(limit).
 		Offset(offset)
@@ -138,18 +187,23 @@ func (r *userRequestRepo) ListUserRequest(ctx context.Context, limit uint64, off
 	}
 
 	var userRequests []model.UserRequest
-	err = r.db.SelectContext(ctx, &userRequests, query, args...)
+	err = sqlx.SelectContext(ctx, r.cluster.Reader(ctx), &userRequests, query, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "db.SelectContext()")
 	}
 
+	if err = r.fields.OpenUsers(userRequests); err != nil {
+		return nil, err
+	}
+
 	return userRequests, nil
 }
 
-func (r *userRequestRepo) RemoveUserRequest(ctx context.Context, IDs []uint64, tx *sqlx.Tx) (bool, error) {
+func (r *userRequestRepo) RemoveUserRequest(ctx context.Context, IDs []uint64) (bool, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.RemoveUserRequest")
 	defer span.Finish()
-	sb := database.StatementBuilder.
+	defer database.QueryTimer("repo.RemoveUserRequest").ObserveDuration()
+	sb := r.dialect.Builder().
 		Update(userRequestTable).
 		Set(userRequestDeletedAtAtColumn, time.Now()).
 		Where(sq.And{
@@ -161,12 +215,7 @@ func (r *userRequestRepo) RemoveUserRequest(ctx context.Context, IDs []uint64, t
 		return false, err
 	}
 
-	var queryer sqlx.ExecerContext
-	if tx == nil {
-		queryer = r.db
-	} else {
-		queryer = tx
-	}
+	queryer := database.Queryer(ctx, r.db)
 
 	var result sql.Result
 	result, err = queryer.ExecContext(ctx, query, args...)
@@ -187,9 +236,46 @@ func (r *userRequestRepo) RemoveUserRequest(ctx context.Context, IDs []uint64, t
 	return true, nil
 }
 
+// RestoreUserRequest - clear deleted_at of removed users and return number of restored users.
+// Merged users are removed in favor of their survivor and erased users have no data left, so both stay removed
+func (r *userRequestRepo) RestoreUserRequest(ctx context.Context, IDs []uint64) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.RestoreUserRequest")
+	defer span.Finish()
+	defer database.QueryTimer("repo.RestoreUserRequest").ObserveDuration()
+	sb := r.dialect.Builder().
+		Update(userRequestTable).
+		Set(userRequestDeletedAtAtColumn, nil).
+		Set(userRequestUpdatedAtColumn, time.Now()).
+		Where(sq.And{
+			sq.Eq{userRequestIDColumn: IDs},
+			sq.NotEq{userRequestDeletedAtAtColumn: nil},
+			sq.Eq{userRequestMergedIntoColumn: nil},
+			sq.Eq{userRequestErasedAtColumn: nil}})
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	queryer := database.Queryer(ctx, r.db)
+
+	result, err := queryer.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "db.ExecContext()")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "repo.RowsAffected()")
+	}
+
+	return affected, nil
+}
+
 func (r *userRequestRepo) Exists(ctx context.Context, userRequestID uint64) (bool, error) {
+	defer database.QueryTimer("repo.Exists").ObserveDuration()
 
-	sb := database.StatementBuilder.
+	sb := r.dialect.Builder().
 		Select("1").
 		Prefix("SELECT EXISTS (").
 		From(userRequestTable).
@@ -204,7 +290,7 @@ func (r *userRequestRepo) Exists(ctx context.Context, userRequestID uint64) (boo
 	}
 
 	var exists bool
-	err = r.db.QueryRowxContext(ctx, query, args...).Scan(&exists)
+	err = r.cluster.Reader(ctx).QueryRowxContext(ctx, query, args...).Scan(&exists)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -217,14 +303,22 @@ func (r *userRequestRepo) Exists(ctx context.Context, userRequestID uint64) (boo
 }
 
 // // nolint:dupl
-func (r *userRequestRepo) UpdateUserByIdRequest(ctx context.Context, userRequestID uint64, name, email string, tx *sqlx.Tx) (bool, error) {
+func (r *userRequestRepo) UpdateUserByIdRequest(ctx context.Context, userRequestID uint64, name, email string) (bool, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.UpdateUserByIdRequest")
 	defer span.Finish()
-	sb := database.StatementBuilder.
+	defer database.QueryTimer("repo.UpdateUserByIdRequest").ObserveDuration()
+
+	email, emailIndex, err := r.fields.SealEmail(userRequestID, email)
+	if err != nil {
+		return false, err
+	}
+
+	sb := r.dialect.Builder().
 		Update(userRequestTable).
 		Set(userRequestUpdatedAtColumn, time.Now()).
 		Set(userRequestNameColumn, name).
 		Set(userRequestEmailColumn, email).
+		Set(userRequestEmailIndexColumn, emailIndex).
 		Where(sq.And{
 			sq.Eq{userRequestIDColumn: userRequestID},
 			sq.Eq{userRequestDeletedAtAtColumn: nil}})
@@ -234,12 +328,7 @@ func (r *userRequestRepo) UpdateUserByIdRequest(ctx context.Context, userRequest
 		return false, err
 	}
 
-	var queryer sqlx.ExecerContext
-	if tx == nil {
-		queryer = r.db
-	} else {
-		queryer = tx
-	}
+	queryer := database.Queryer(ctx, r.db)
 
 	var result sql.Result
 	result, err = queryer.ExecContext(ctx, query, args...)
@@ -259,3 +348,264 @@ func (r *userRequestRepo) UpdateUserByIdRequest(ctx context.Context, userRequest
 
 	return true, nil
 }
+
+func (r *userRequestRepo) UpdateUserLabelsRequest(ctx context.Context, userRequestID uint64, set model.Labels, removeKeys []string) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.UpdateUserLabelsRequest")
+	defer span.Finish()
+	defer database.QueryTimer("repo.UpdateUserLabelsRequest").ObserveDuration()
+	sb := r.dialect.Builder().
+		Update(userRequestTable).
+		Set(userRequestUpdatedAtColumn, time.Now()).
+		Set(userRequestLabelsColumn, r.dialect.JSONMergeKeys(userRequestLabelsColumn, set, removeKeys)).
+		Where(sq.And{
+			sq.Eq{userRequestIDColumn: userRequestID},
+			sq.Eq{userRequestDeletedAtAtColumn: nil}})
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	queryer := database.Queryer(ctx, r.db)
+
+	result, err := queryer.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, errors.Wrap(err, "db.ExecContext()")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "repo.RowsAffected()")
+	}
+
+	return affected > 0, nil
+}
+
+func (r *userRequestRepo) UpdateUserProfileRequest(ctx context.Context, userRequestID uint64, profile model.Profile) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.UpdateUserProfileRequest")
+	defer span.Finish()
+	defer database.QueryTimer("repo.UpdateUserProfileRequest").ObserveDuration()
+	sb := r.dialect.Builder().
+		Update(userRequestTable).
+		Set(userRequestUpdatedAtColumn, time.Now()).
+		Set(userRequestProfileColumn, r.dialect.JSON(profile)).
+		Where(sq.And{
+			sq.Eq{userRequestIDColumn: userRequestID},
+			sq.Eq{userRequestDeletedAtAtColumn: nil}})
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	queryer := database.Queryer(ctx, r.db)
+
+	result, err := queryer.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, errors.Wrap(err, "db.ExecContext()")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "repo.RowsAffected()")
+	}
+
+	return affected > 0, nil
+}
+
+// GetUserByIdForUpdateRequest - select users and lock their rows until the end of transaction
+func (r *userRequestRepo) GetUserByIdForUpdateRequest(ctx context.Context, IDs []uint64) ([]model.UserRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.GetUserByIdForUpdateRequest")
+	defer span.Finish()
+	defer database.QueryTimer("repo.GetUserByIdForUpdateRequest").ObserveDuration()
+	sb := r.dialect.LockForUpdate(r.dialect.Builder().
+		Select("*").
+		From(userRequestTable).
+		Where(sq.Eq{userRequestIDColumn: IDs}).
+		OrderBy(userRequestIDColumn))
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	queryer := database.Queryer(ctx, r.db)
+
+	var userRequests []model.UserRequest
+	err = sqlx.SelectContext(ctx, queryer, &userRequests, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "db.SelectContext()")
+	}
+
+	if err = r.fields.OpenUsers(userRequests); err != nil {
+		return nil, err
+	}
+
+	return userRequests, nil
+}
+
+// SaveMergedUserRequest - overwrite mergeable fields of user
+func (r *userRequestRepo) SaveMergedUserRequest(ctx context.Context, userRequest *model.UserRequest) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.SaveMergedUserRequest")
+	defer span.Finish()
+	defer database.QueryTimer("repo.SaveMergedUserRequest").ObserveDuration()
+
+	email, emailIndex, err := r.fields.SealEmail(userRequest.ID_user, userRequest.Email)
+	if err != nil {
+		return false, err
+	}
+
+	sb := r.dialect.Builder().
+		Update(userRequestTable).
+		Set(userRequestUpdatedAtColumn, time.Now()).
+		Set(userRequestNameColumn, userRequest.Name).
+		Set(userRequestEmailColumn, email).
+		Set(userRequestEmailIndexColumn, emailIndex).
+		Set(userRequestLabelsColumn, r.dialect.JSON(userRequest.Labels)).
+		Set(userRequestProfileColumn, r.dialect.JSON(userRequest.Profile)).
+		Where(sq.And{
+			sq.Eq{userRequestIDColumn: userRequest.ID_user},
+			sq.Eq{userRequestDeletedAtAtColumn: nil}})
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	queryer := database.Queryer(ctx, r.db)
+
+	result, err := queryer.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, errors.Wrap(err, "db.ExecContext()")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "repo.RowsAffected()")
+	}
+
+	return affected > 0, nil
+}
+
+// MarkMergedUserRequest - soft delete losers and point them to survivor
+func (r *userRequestRepo) MarkMergedUserRequest(ctx context.Context, survivorID uint64, loserIDs []uint64) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.MarkMergedUserRequest")
+	defer span.Finish()
+	defer database.QueryTimer("repo.MarkMergedUserRequest").ObserveDuration()
+	now := time.Now()
+	sb := r.dialect.Builder().
+		Update(userRequestTable).
+		Set(userRequestUpdatedAtColumn, now).
+		Set(userRequestDeletedAtAtColumn, now).
+		Set(userRequestMergedIntoColumn, survivorID).
+		Where(sq.And{
+			sq.Eq{userRequestIDColumn: loserIDs},
+			sq.Eq{userRequestDeletedAtAtColumn: nil}})
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	queryer := database.Queryer(ctx, r.db)
+
+	result, err := queryer.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "db.ExecContext()")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "repo.RowsAffected()")
+	}
+
+	return affected, nil
+}
+
+// ListMergedIntoRequest returns ids of users merged into any of survivors
+func (r *userRequestRepo) ListMergedIntoRequest(ctx context.Context, survivorIDs []uint64) ([]uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.ListMergedIntoRequest")
+	defer span.Finish()
+	defer database.QueryTimer("repo.ListMergedIntoRequest").ObserveDuration()
+	sb := r.dialect.Builder().
+		Select(userRequestIDColumn).
+		From(userRequestTable).
+		Where(sq.Eq{userRequestMergedIntoColumn: survivorIDs}).
+		OrderBy(userRequestIDColumn)
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	queryer := database.Queryer(ctx, r.db)
+
+	var IDs []uint64
+	if err = sqlx.SelectContext(ctx, queryer, &IDs, query, args...); err != nil {
+		return nil, errors.Wrap(err, "db.SelectContext()")
+	}
+
+	return IDs, nil
+}
+
+// EraseUserRequest - replace personal data of users with tombstones: empty name, email, labels and profile.
+// Rows stay soft deleted, so ids referenced by groups, events and merged users remain valid.
+// Already erased users are skipped
+func (r *userRequestRepo) EraseUserRequest(ctx context.Context, IDs []uint64) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.EraseUserRequest")
+	defer span.Finish()
+	defer database.QueryTimer("repo.EraseUserRequest").ObserveDuration()
+	now := time.Now()
+	sb := r.dialect.Builder().
+		Update(userRequestTable).
+		Set(userRequestNameColumn, "").
+		Set(userRequestEmailColumn, "").
+		Set(userRequestEmailIndexColumn, nil).
+		Set(userRequestLabelsColumn, r.dialect.JSON(model.Labels{})).
+		Set(userRequestProfileColumn, r.dialect.JSON(model.Profile{})).
+		Set(userRequestUpdatedAtColumn, now).
+		Set(userRequestDeletedAtAtColumn, sq.Expr("COALESCE("+userRequestDeletedAtAtColumn+", ?)", now)).
+		Set(userRequestErasedAtColumn, now).
+		Where(sq.And{
+			sq.Eq{userRequestIDColumn: IDs},
+			sq.Eq{userRequestErasedAtColumn: nil}})
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	queryer := database.Queryer(ctx, r.db)
+
+	result, err := queryer.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "db.ExecContext()")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "repo.RowsAffected()")
+	}
+
+	return affected, nil
+}
+
+// labelSelectorToSql - build where condition for labels column, on Postgres
+// "=" and "!=" use containment (@>) and existence checks use "?" so both are served by GIN index
+func labelSelectorToSql(dialect *database.Dialect, selector labels.Selector) sq.Sqlizer {
+	conditions := sq.And{}
+	for _, r := range selector {
+		switch r.Operator {
+		case labels.Equals:
+			conditions = append(conditions, dialect.JSONKeyEquals(userRequestLabelsColumn, r.Key, r.Value))
+		case labels.NotEquals:
+			conditions = append(conditions, sq.Expr("NOT (?)", dialect.JSONKeyEquals(userRequestLabelsColumn, r.Key, r.Value)))
+		case labels.Exists:
+			conditions = append(conditions, dialect.JSONHasKey(userRequestLabelsColumn, r.Key))
+		case labels.DoesNotExist:
+			conditions = append(conditions, sq.Expr("NOT (?)", dialect.JSONHasKey(userRequestLabelsColumn, r.Key)))
+		}
+	}
+
+	return conditions
+}
@@ -0,0 +1,97 @@
+package repo
+
+import (
+	"context"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+// ErrEncryptionDisabled is a "keys are rotated by repo without encryption" error
+var ErrEncryptionDisabled = errors.New("email encryption is disabled")
+
+// EmailKeyRotator is a storage of users whose sealed emails can be rewritten under the current key
+type EmailKeyRotator interface {
+	RotateEmailKeys(ctx context.Context, afterID uint64, limit uint64) (uint64, int64, error)
+}
+
+// RotateEmailKeys - re-seal emails of next batch of users with id greater than afterID that are plaintext,
+// sealed by an old key or indexed by an old blind index key, empty emails are left unsealed and not indexed.
+// Returns the last id of the batch (0 when there are no more users) and the number of rewritten users.
+// A user changed after it was read is skipped, its email was sealed by the change
+func (r *userRequestRepo) RotateEmailKeys(ctx context.Context, afterID uint64, limit uint64) (uint64, int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "repo.RotateEmailKeys")
+	defer span.Finish()
+	defer database.QueryTimer("repo.RotateEmailKeys").ObserveDuration()
+
+	if r.fields == nil {
+		return 0, 0, ErrEncryptionDisabled
+	}
+
+	query, args, err := r.dialect.Builder().
+		Select("*").
+		From(userRequestTable).
+		Where(sq.Gt{userRequestIDColumn: afterID}).
+		OrderBy(userRequestIDColumn).
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var userRequests []model.UserRequest
+	if err = sqlx.SelectContext(ctx, r.db, &userRequests, query, args...); err != nil {
+		return 0, 0, errors.Wrap(err, "db.SelectContext()")
+	}
+
+	if len(userRequests) == 0 {
+		return 0, 0, nil
+	}
+
+	var rotated int64
+	for _, u := range userRequests {
+		email, err := r.fields.OpenEmail(u.ID_user, u.Email)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if !r.fields.NeedsRotation(u, email) {
+			continue
+		}
+
+		sealed, emailIndex, err := r.fields.SealEmail(u.ID_user, email)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		query, args, err := r.dialect.Builder().
+			Update(userRequestTable).
+			Set(userRequestEmailColumn, sealed).
+			Set(userRequestEmailIndexColumn, emailIndex).
+			Where(sq.Eq{
+				userRequestIDColumn:    u.ID_user,
+				userRequestEmailColumn: u.Email}).
+			ToSql()
+		if err != nil {
+			return 0, 0, err
+		}
+
+		result, err := r.db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "db.ExecContext()")
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "repo.RowsAffected()")
+		}
+		rotated += affected
+	}
+
+	return userRequests[len(userRequests)-1].ID_user, rotated, nil
+}
@@ -0,0 +1,186 @@
+package sharded
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/pkg/hashring"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+const (
+	userTable    = "users"
+	userIDColumn = "id_user"
+
+	// maxRebalanceBatchSize keeps bind parameters of one upsert below Postgres limit
+	maxRebalanceBatchSize = 5000
+)
+
+var userColumns = []string{
+	userIDColumn,
+	"name",
+	"email",
+	"created_at",
+	"updated_at",
+	"deleted_at",
+	"done_at",
+	"labels",
+	"profile",
+	"merged_into",
+	"email_bidx",
+	"erased_at",
+}
+
+// Move - number of users moved from one shard to another
+type Move struct {
+	From  string
+	To    string
+	Users int
+}
+
+// Rebalance - move users to shards that own them on ring. dbs must contain every node of ring
+// and may contain draining shards that are not on ring anymore, they are emptied.
+// It must run while the service is stopped. Users are copied to the owner before they are deleted
+// from the source, so an interrupted run can be repeated. With dryRun nothing is changed
+// and moves that would be done are returned
+func Rebalance(ctx context.Context, ring *hashring.Ring, dbs map[string]*sqlx.DB, batchSize uint64, dryRun bool) ([]Move, error) {
+	for _, node := range ring.Nodes() {
+		if _, ok := dbs[node]; !ok {
+			return nil, errors.Errorf("shard %s of ring is not opened", node)
+		}
+	}
+
+	if batchSize == 0 || batchSize > maxRebalanceBatchSize {
+		batchSize = maxRebalanceBatchSize
+	}
+
+	sources := make([]string, 0, len(dbs))
+	for name := range dbs {
+		sources = append(sources, name)
+	}
+	sort.Strings(sources)
+
+	moved := make(map[Move]int)
+	for _, source := range sources {
+		var lastID uint64
+		for {
+			users, err := selectUsers(ctx, dbs[source], lastID, batchSize)
+			if err != nil {
+				return nil, errors.Wrapf(err, "shard %s", source)
+			}
+			if len(users) == 0 {
+				break
+			}
+			lastID = users[len(users)-1].ID_user
+
+			misplaced := make(map[string][]model.UserRequest)
+			for _, u := range users {
+				if owner := ring.Locate(u.ID_user); owner != source {
+					misplaced[owner] = append(misplaced[owner], u)
+				}
+			}
+
+			for owner, part := range misplaced {
+				if !dryRun {
+					if err = upsertUsers(ctx, dbs[owner], part); err != nil {
+						return nil, errors.Wrapf(err, "copy to shard %s", owner)
+					}
+					if err = deleteUsers(ctx, dbs[source], part); err != nil {
+						return nil, errors.Wrapf(err, "delete from shard %s", source)
+					}
+				}
+				moved[Move{From: source, To: owner}] += len(part)
+			}
+		}
+	}
+
+	moves := make([]Move, 0, len(moved))
+	for m, n := range moved {
+		m.Users = n
+		moves = append(moves, m)
+	}
+	sort.Slice(moves, func(i, j int) bool {
+		if moves[i].From != moves[j].From {
+			return moves[i].From < moves[j].From
+		}
+
+		return moves[i].To < moves[j].To
+	})
+
+	return moves, nil
+}
+
+func selectUsers(ctx context.Context, db *sqlx.DB, afterID uint64, limit uint64) ([]model.UserRequest, error) {
+	query, args, err := database.StatementBuilder.
+		Select(userColumns...).
+		From(userTable).
+		Where(sq.Gt{userIDColumn: afterID}).
+		OrderBy(userIDColumn).
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	var users []model.UserRequest
+	if err = sqlx.SelectContext(ctx, db, &users, query, args...); err != nil {
+		return nil, errors.Wrap(err, "db.SelectContext()")
+	}
+
+	return users, nil
+}
+
+// upsertUsers - users that are already on the shard after an interrupted run are overwritten
+func upsertUsers(ctx context.Context, db *sqlx.DB, users []model.UserRequest) error {
+	sb := database.StatementBuilder.
+		Insert(userTable).
+		Columns(userColumns...)
+	for _, u := range users {
+		sb = sb.Values(u.ID_user, u.Name, u.Email, u.CreatedAt, u.UpdatedAt, u.DeletedAt, u.DoneAt, u.Labels, u.Profile, u.MergedInto, u.EmailBlindIndex, u.ErasedAt)
+	}
+
+	set := make([]string, 0, len(userColumns)-1)
+	for _, column := range userColumns[1:] {
+		set = append(set, fmt.Sprintf("%s = EXCLUDED.%s", column, column))
+	}
+	sb = sb.Suffix(fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s", userIDColumn, strings.Join(set, ", ")))
+
+	query, args, err := sb.ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, "db.ExecContext()")
+	}
+
+	return nil
+}
+
+func deleteUsers(ctx context.Context, db *sqlx.DB, users []model.UserRequest) error {
+	IDs := make([]uint64, 0, len(users))
+	for _, u := range users {
+		IDs = append(IDs, u.ID_user)
+	}
+
+	query, args, err := database.StatementBuilder.
+		Delete(userTable).
+		Where(sq.Eq{userIDColumn: IDs}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return errors.Wrap(err, "db.ExecContext()")
+	}
+
+	return nil
+}
@@ -0,0 +1,356 @@
+// Package sharded contains repository that spreads users across shards by consistent hash of id_user.
+// Statements for one user go to a single shard, batch lookups and listing fan out and merge results,
+// listing is limited to MaxListOffset
+// Groups stay on primary and refer to members by id, duplicate analysis is not available with shards.
+// In transaction of database.NewTransactor every touched shard joins it, commit across shards is not atomic
+package sharded
+
+import (
+	"context"
+	"sort"
+
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/pkg/hashring"
+	"cmd/main.go/internal/pkg/labels"
+	"cmd/main.go/internal/repo"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// MaxListOffset - the deepest offset of ListUserRequest. Every shard reads offset+limit users
+// for one page, so the cost of a page grows with offset times number of shards, API accepts offsets up to 200
+const MaxListOffset = 200
+
+// ErrListOffsetTooDeep is a "offset is over MaxListOffset" error
+var ErrListOffsetTooDeep = errors.New("offset of users list is too deep for sharded storage")
+
+type userRequestRepo struct {
+	ring   *hashring.Ring
+	shards map[string]repo.UserRequestRepo
+}
+
+// NewUserRequestRepo returns repo.UserRequestRepo over shards keyed by node names of ring
+func NewUserRequestRepo(ring *hashring.Ring, shards map[string]repo.UserRequestRepo) repo.UserRequestRepo {
+	return &userRequestRepo{
+		ring:   ring,
+		shards: shards,
+	}
+}
+
+// shard returns repository of shard of userRequestID joined to transaction from ctx
+func (r *userRequestRepo) shard(ctx context.Context, userRequestID uint64) (repo.UserRequestRepo, error) {
+	shard := r.shards[r.ring.Locate(userRequestID)]
+
+	return shard, join(ctx, shard)
+}
+
+func (r *userRequestRepo) CreateUserRequest(ctx context.Context, userRequest *model.UserRequest) (uint64, error) {
+	shard, err := r.shard(ctx, userRequest.ID_user)
+	if err != nil {
+		return 0, err
+	}
+
+	return shard.CreateUserRequest(ctx, userRequest)
+}
+
+// BulkCreateUserRequest - every shard inserts its part of the batch in its own transaction,
+// a failed shard does not undo parts inserted by others
+func (r *userRequestRepo) BulkCreateUserRequest(ctx context.Context, userRequests []model.UserRequest) ([]uint64, []uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "sharded.BulkCreateUserRequest")
+	defer span.Finish()
+
+	parts := make(map[string][]model.UserRequest)
+	for _, u := range userRequests {
+		name := r.ring.Locate(u.ID_user)
+		parts[name] = append(parts[name], u)
+	}
+
+	inserted, err := fanOut(ctx, r.shards, parts, func(ctx context.Context, shard repo.UserRequestRepo, part []model.UserRequest) ([]uint64, error) {
+		ids, _, err := shard.BulkCreateUserRequest(ctx, part)
+
+		return ids, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(inserted, func(i, j int) bool { return inserted[i] < inserted[j] })
+
+	// conflicts are reported in order of the batch like a single shard does
+	pending := make(map[uint64]struct{}, len(inserted))
+	for _, id := range inserted {
+		pending[id] = struct{}{}
+	}
+
+	var conflicts []uint64
+	for _, u := range userRequests {
+		if _, ok := pending[u.ID_user]; ok {
+			delete(pending, u.ID_user)
+			continue
+		}
+		conflicts = append(conflicts, u.ID_user)
+	}
+
+	return inserted, conflicts, nil
+}
+
+func (r *userRequestRepo) GetUserByIdRequest(ctx context.Context, IDs []uint64) ([]model.UserRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "sharded.GetUserByIdRequest")
+	defer span.Finish()
+
+	userRequests, err := fanOut(ctx, r.shards, r.ring.Group(IDs), func(ctx context.Context, shard repo.UserRequestRepo, IDs []uint64) ([]model.UserRequest, error) {
+		return shard.GetUserByIdRequest(ctx, IDs)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortUsers(userRequests)
+
+	return userRequests, nil
+}
+
+// ListUserRequest - every shard returns its first offset+limit users, the page is cut from their merge.
+// Offsets over MaxListOffset are refused
+func (r *userRequestRepo) ListUserRequest(ctx context.Context, limit uint64, offset uint64, selector labels.Selector) ([]model.UserRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "sharded.ListUserRequest")
+	defer span.Finish()
+
+	if offset > MaxListOffset {
+		return nil, errors.Wrapf(ErrListOffsetTooDeep, "offset %d", offset)
+	}
+
+	all := make(map[string]struct{}, len(r.shards))
+	for name := range r.shards {
+		all[name] = struct{}{}
+	}
+
+	userRequests, err := fanOut(ctx, r.shards, all, func(ctx context.Context, shard repo.UserRequestRepo, _ struct{}) ([]model.UserRequest, error) {
+		return shard.ListUserRequest(ctx, offset+limit, 0, selector)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortUsers(userRequests)
+
+	if offset >= uint64(len(userRequests)) {
+		return nil, nil
+	}
+	userRequests = userRequests[offset:]
+	if limit < uint64(len(userRequests)) {
+		userRequests = userRequests[:limit]
+	}
+
+	return userRequests, nil
+}
+
+func (r *userRequestRepo) RemoveUserRequest(ctx context.Context, IDs []uint64) (bool, error) {
+	var removed bool
+	for name, part := range r.ring.Group(IDs) {
+		if err := join(ctx, r.shards[name]); err != nil {
+			return false, err
+		}
+		ok, err := r.shards[name].RemoveUserRequest(ctx, part)
+		if err != nil {
+			return false, err
+		}
+		removed = removed || ok
+	}
+
+	return removed, nil
+}
+
+func (r *userRequestRepo) RestoreUserRequest(ctx context.Context, IDs []uint64) (int64, error) {
+	var restored int64
+	for name, part := range r.ring.Group(IDs) {
+		if err := join(ctx, r.shards[name]); err != nil {
+			return 0, err
+		}
+		n, err := r.shards[name].RestoreUserRequest(ctx, part)
+		if err != nil {
+			return 0, err
+		}
+		restored += n
+	}
+
+	return restored, nil
+}
+
+func (r *userRequestRepo) Exists(ctx context.Context, userRequestID uint64) (bool, error) {
+	shard, err := r.shard(ctx, userRequestID)
+	if err != nil {
+		return false, err
+	}
+
+	return shard.Exists(ctx, userRequestID)
+}
+
+func (r *userRequestRepo) UpdateUserByIdRequest(ctx context.Context, userRequestID uint64, name, email string) (bool, error) {
+	shard, err := r.shard(ctx, userRequestID)
+	if err != nil {
+		return false, err
+	}
+
+	return shard.UpdateUserByIdRequest(ctx, userRequestID, name, email)
+}
+
+func (r *userRequestRepo) UpdateUserLabelsRequest(ctx context.Context, userRequestID uint64, set model.Labels, removeKeys []string) (bool, error) {
+	shard, err := r.shard(ctx, userRequestID)
+	if err != nil {
+		return false, err
+	}
+
+	return shard.UpdateUserLabelsRequest(ctx, userRequestID, set, removeKeys)
+}
+
+func (r *userRequestRepo) UpdateUserProfileRequest(ctx context.Context, userRequestID uint64, profile model.Profile) (bool, error) {
+	shard, err := r.shard(ctx, userRequestID)
+	if err != nil {
+		return false, err
+	}
+
+	return shard.UpdateUserProfileRequest(ctx, userRequestID, profile)
+}
+
+// GetUserByIdForUpdateRequest - shards are locked one by one in order of ring nodes,
+// so concurrent transactions do not deadlock across shards
+func (r *userRequestRepo) GetUserByIdForUpdateRequest(ctx context.Context, IDs []uint64) ([]model.UserRequest, error) {
+	groups := r.ring.Group(IDs)
+
+	var userRequests []model.UserRequest
+	for _, name := range r.ring.Nodes() {
+		part, ok := groups[name]
+		if !ok {
+			continue
+		}
+
+		if err := join(ctx, r.shards[name]); err != nil {
+			return nil, err
+		}
+		users, err := r.shards[name].GetUserByIdForUpdateRequest(ctx, part)
+		if err != nil {
+			return nil, err
+		}
+		userRequests = append(userRequests, users...)
+	}
+	sortUsers(userRequests)
+
+	return userRequests, nil
+}
+
+func (r *userRequestRepo) SaveMergedUserRequest(ctx context.Context, userRequest *model.UserRequest) (bool, error) {
+	shard, err := r.shard(ctx, userRequest.ID_user)
+	if err != nil {
+		return false, err
+	}
+
+	return shard.SaveMergedUserRequest(ctx, userRequest)
+}
+
+func (r *userRequestRepo) MarkMergedUserRequest(ctx context.Context, survivorID uint64, loserIDs []uint64) (int64, error) {
+	var affected int64
+	for name, part := range r.ring.Group(loserIDs) {
+		if err := join(ctx, r.shards[name]); err != nil {
+			return 0, err
+		}
+		n, err := r.shards[name].MarkMergedUserRequest(ctx, survivorID, part)
+		if err != nil {
+			return 0, err
+		}
+		affected += n
+	}
+
+	return affected, nil
+}
+
+// ListMergedIntoRequest - losers may live on other shards than their survivor, so every shard is asked
+func (r *userRequestRepo) ListMergedIntoRequest(ctx context.Context, survivorIDs []uint64) ([]uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "sharded.ListMergedIntoRequest")
+	defer span.Finish()
+
+	parts := make(map[string][]uint64, len(r.shards))
+	for name := range r.shards {
+		parts[name] = survivorIDs
+	}
+
+	IDs, err := fanOut(ctx, r.shards, parts, func(ctx context.Context, shard repo.UserRequestRepo, part []uint64) ([]uint64, error) {
+		return shard.ListMergedIntoRequest(ctx, part)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(IDs, func(i, j int) bool { return IDs[i] < IDs[j] })
+
+	return IDs, nil
+}
+
+func (r *userRequestRepo) EraseUserRequest(ctx context.Context, IDs []uint64) (int64, error) {
+	var affected int64
+	for name, part := range r.ring.Group(IDs) {
+		if err := join(ctx, r.shards[name]); err != nil {
+			return 0, err
+		}
+		n, err := r.shards[name].EraseUserRequest(ctx, part)
+		if err != nil {
+			return 0, err
+		}
+		affected += n
+	}
+
+	return affected, nil
+}
+
+// fanOut - run fn for every shard of parts concurrently and concatenate results
+func fanOut[P any, T any](
+	ctx context.Context,
+	shards map[string]repo.UserRequestRepo,
+	parts map[string]P,
+	fn func(ctx context.Context, shard repo.UserRequestRepo, part P) ([]T, error),
+) ([]T, error) {
+	for name := range parts {
+		if err := join(ctx, shards[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([][]T, len(parts))
+
+	g, gctx := errgroup.WithContext(ctx)
+	i := 0
+	for name, part := range parts {
+		slot, shard, part := &results[i], shards[name], part
+		g.Go(func() error {
+			result, err := fn(gctx, shard, part)
+			*slot = result
+
+			return err
+		})
+		i++
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	var all []T
+	for _, result := range results {
+		all = append(all, result...)
+	}
+
+	return all, nil
+}
+
+// join - begin transaction of shard when ctx carries transaction the shard is joined to,
+// so statements of the shard fail instead of running outside of it when begin fails
+func join(ctx context.Context, shard repo.UserRequestRepo) error {
+	if joiner, ok := shard.(repo.TxJoiner); ok {
+		return joiner.JoinTx(ctx)
+	}
+
+	return nil
+}
+
+func sortUsers(userRequests []model.UserRequest) {
+	sort.Slice(userRequests, func(i, j int) bool {
+		return userRequests[i].ID_user < userRequests[j].ID_user
+	})
+}
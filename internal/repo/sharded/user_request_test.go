@@ -0,0 +1,238 @@
+package sharded
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/pkg/hashring"
+	"cmd/main.go/internal/pkg/labels"
+	"cmd/main.go/internal/repo"
+	"cmd/main.go/internal/repo/memory"
+)
+
+// testUsers - number of users created by newTestRepo, users with even ids have label parity=even
+const testUsers = 60
+
+var testShards = []string{"shard-a", "shard-b", "shard-c"}
+
+// newTestRepo returns sharded repo over in-memory shards with users 1..testUsers
+func newTestRepo(t *testing.T) (repo.UserRequestRepo, *hashring.Ring, map[string]repo.UserRequestRepo) {
+	t.Helper()
+
+	ring := hashring.New(testShards, 0)
+	shards := make(map[string]repo.UserRequestRepo, len(testShards))
+	for _, name := range testShards {
+		shards[name] = memory.NewUserRequestRepo(memory.NewStore())
+	}
+	r := NewUserRequestRepo(ring, shards)
+
+	for id := uint64(1); id <= testUsers; id++ {
+		user := &model.UserRequest{ID_user: id, Name: "user", Labels: model.Labels{}, CreatedAt: time.Now()}
+		if id%2 == 0 {
+			user.Labels["parity"] = "even"
+		}
+		if _, err := r.CreateUserRequest(context.Background(), user); err != nil {
+			t.Fatalf("CreateUserRequest() error = %v", err)
+		}
+	}
+
+	return r, ring, shards
+}
+
+func ids(users []model.UserRequest) []uint64 {
+	IDs := make([]uint64, 0, len(users))
+	for _, u := range users {
+		IDs = append(IDs, u.ID_user)
+	}
+
+	return IDs
+}
+
+func idRange(from, to uint64, step uint64) []uint64 {
+	var IDs []uint64
+	for id := from; id <= to; id += step {
+		IDs = append(IDs, id)
+	}
+
+	return IDs
+}
+
+func TestRouting(t *testing.T) {
+	ctx := context.Background()
+	_, ring, shards := newTestRepo(t)
+
+	all := idRange(1, testUsers, 1)
+	for name, shard := range shards {
+		users, err := shard.GetUserByIdRequest(ctx, all)
+		if err != nil {
+			t.Fatalf("GetUserByIdRequest() error = %v", err)
+		}
+		if len(users) == 0 {
+			t.Errorf("shard %s has no users", name)
+		}
+		for _, u := range users {
+			if got := ring.Locate(u.ID_user); got != name {
+				t.Errorf("user %d is stored on %s, ring locates it on %s", u.ID_user, name, got)
+			}
+		}
+	}
+}
+
+func TestGetUserByIdRequest(t *testing.T) {
+	r, _, _ := newTestRepo(t)
+
+	users, err := r.GetUserByIdRequest(context.Background(), []uint64{42, 7, 1000, 13, 1})
+	if err != nil {
+		t.Fatalf("GetUserByIdRequest() error = %v", err)
+	}
+	if got, want := ids(users), []uint64{1, 7, 13, 42}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetUserByIdRequest() ids = %v, want %v", got, want)
+	}
+}
+
+func TestListUserRequest(t *testing.T) {
+	even, err := labels.Parse("parity=even")
+	if err != nil {
+		t.Fatalf("labels.Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		limit    uint64
+		offset   uint64
+		selector labels.Selector
+		want     []uint64
+		wantErr  error
+	}{
+		{
+			name:  "first page",
+			limit: 7,
+			want:  idRange(1, 7, 1),
+		},
+		{
+			name:   "page in the middle",
+			limit:  7,
+			offset: 20,
+			want:   idRange(21, 27, 1),
+		},
+		{
+			name:   "last page is short",
+			limit:  7,
+			offset: 55,
+			want:   idRange(56, 60, 1),
+		},
+		{
+			name:   "past the end",
+			limit:  7,
+			offset: 100,
+		},
+		{
+			name:     "selector",
+			limit:    5,
+			offset:   5,
+			selector: even,
+			want:     idRange(12, 20, 2),
+		},
+		{
+			name:    "offset is too deep",
+			limit:   7,
+			offset:  MaxListOffset + 1,
+			wantErr: ErrListOffsetTooDeep,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, _, _ := newTestRepo(t)
+
+			users, err := r.ListUserRequest(context.Background(), tt.limit, tt.offset, tt.selector)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ListUserRequest() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := ids(users); len(got) != 0 || len(tt.want) != 0 {
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("ListUserRequest() ids = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBulkCreateUserRequest(t *testing.T) {
+	r, _, _ := newTestRepo(t)
+
+	batch := make([]model.UserRequest, 0, 6)
+	for _, id := range []uint64{70, 3, 61, 70, 5, 62} {
+		batch = append(batch, model.UserRequest{ID_user: id, CreatedAt: time.Now()})
+	}
+
+	inserted, conflicts, err := r.BulkCreateUserRequest(context.Background(), batch)
+	if err != nil {
+		t.Fatalf("BulkCreateUserRequest() error = %v", err)
+	}
+	if want := []uint64{61, 62, 70}; !reflect.DeepEqual(inserted, want) {
+		t.Errorf("BulkCreateUserRequest() inserted = %v, want %v", inserted, want)
+	}
+	// conflicts are in order of the batch
+	if want := []uint64{3, 70, 5}; !reflect.DeepEqual(conflicts, want) {
+		t.Errorf("BulkCreateUserRequest() conflicts = %v, want %v", conflicts, want)
+	}
+}
+
+func TestMergedAcrossShards(t *testing.T) {
+	ctx := context.Background()
+	r, ring, _ := newTestRepo(t)
+
+	// losers on other shards than survivor
+	survivor := uint64(1)
+	var losers []uint64
+	for id := uint64(2); id <= testUsers && len(losers) < 3; id++ {
+		if ring.Locate(id) != ring.Locate(survivor) {
+			losers = append(losers, id)
+		}
+	}
+
+	affected, err := r.MarkMergedUserRequest(ctx, survivor, losers)
+	if err != nil {
+		t.Fatalf("MarkMergedUserRequest() error = %v", err)
+	}
+	if affected != int64(len(losers)) {
+		t.Errorf("MarkMergedUserRequest() = %d, want %d", affected, len(losers))
+	}
+
+	merged, err := r.ListMergedIntoRequest(ctx, []uint64{survivor})
+	if err != nil {
+		t.Fatalf("ListMergedIntoRequest() error = %v", err)
+	}
+	if !reflect.DeepEqual(merged, losers) {
+		t.Errorf("ListMergedIntoRequest() = %v, want %v", merged, losers)
+	}
+}
+
+func TestRestoreUserRequest(t *testing.T) {
+	ctx := context.Background()
+	r, _, _ := newTestRepo(t)
+
+	removed := idRange(1, 30, 3)
+	if _, err := r.RemoveUserRequest(ctx, removed); err != nil {
+		t.Fatalf("RemoveUserRequest() error = %v", err)
+	}
+
+	n, err := r.RestoreUserRequest(ctx, idRange(1, 30, 1))
+	if err != nil {
+		t.Fatalf("RestoreUserRequest() error = %v", err)
+	}
+	if n != int64(len(removed)) {
+		t.Errorf("RestoreUserRequest() = %d, want %d", n, len(removed))
+	}
+
+	for _, id := range removed {
+		if exists, err := r.Exists(ctx, id); err != nil || !exists {
+			t.Errorf("Exists(%d) = %v, %v after restore", id, exists, err)
+		}
+	}
+}
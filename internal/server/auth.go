@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/logger"
+	"cmd/main.go/internal/pkg/auth"
+)
+
+const authLogTag = "Auth"
+
+// authorizationMetadataKey - metadata of bearer token, gateway forwards Authorization header into it
+const authorizationMetadataKey = "authorization"
+
+// defaultJWKSReloadInterval - interval of JWKS reloads when auth.reloadInterval is not set
+const defaultJWKSReloadInterval = 30 * time.Second
+
+// authenticator verifies bearer tokens of requests to methods that are not exempt
+type authenticator struct {
+	verifier       *auth.Verifier
+	exemptMethods  map[string]bool
+	exemptServices []string
+}
+
+func newAuthenticator(verifier *auth.Verifier, exempt []string) *authenticator {
+	a := &authenticator{verifier: verifier, exemptMethods: make(map[string]bool, len(exempt))}
+	for _, name := range exempt {
+		if strings.HasSuffix(name, "/") {
+			a.exemptServices = append(a.exemptServices, name)
+		} else {
+			a.exemptMethods[name] = true
+		}
+	}
+
+	return a
+}
+
+func (a *authenticator) exempt(fullMethod string) bool {
+	if a.exemptMethods[fullMethod] {
+		return true
+	}
+	for _, service := range a.exemptServices {
+		if strings.HasPrefix(fullMethod, service) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// authenticate returns ctx carrying verified claims, or Unauthenticated error
+func (a *authenticator) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	if a.exempt(fullMethod) {
+		return ctx, nil
+	}
+
+	claims, err := a.verify(ctx)
+	if err != nil {
+		logger.WarnKV(ctx, fmt.Sprintf("%s: request is not authenticated", authLogTag),
+			"method", fullMethod,
+			"err", err,
+		)
+
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return auth.WithClaims(ctx, claims), nil
+}
+
+func (a *authenticator) verify(ctx context.Context) (*auth.Claims, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(authorizationMetadataKey)
+	if len(values) == 0 {
+		return nil, auth.ErrMissingToken
+	}
+
+	token, err := auth.BearerToken(values[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return a.verifier.Verify(token)
+}
+
+// authInterceptor - reject unary requests without valid bearer token
+func authInterceptor(a *authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// authStreamInterceptor - reject streams without valid bearer token
+func authStreamInterceptor(a *authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		wrapped := grpc_middleware.WrapServerStream(stream)
+		wrapped.WrappedContext = ctx
+
+		return handler(srv, wrapped)
+	}
+}
+
+// createAuthenticator - load keys of JWKS file and reload them in background until ctx is done
+func createAuthenticator(ctx context.Context, cfg config.Auth) (*authenticator, error) {
+	keys, err := auth.LoadKeySet(cfg.JWKSFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load JWKS: %w", err)
+	}
+
+	interval := time.Duration(cfg.ReloadInterval) * time.Second
+	if interval <= 0 {
+		interval = defaultJWKSReloadInterval
+	}
+	go keys.Run(ctx, interval)
+
+	verifier := auth.NewVerifier(keys, cfg.Issuer, cfg.Audience, time.Duration(cfg.Leeway)*time.Second)
+
+	return newAuthenticator(verifier, cfg.ExemptMethods), nil
+}
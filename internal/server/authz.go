@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"cmd/main.go/internal/logger"
+	"cmd/main.go/internal/pkg/rbac"
+)
+
+const authzLogTag = "Authz"
+
+// authorize - decide whether caller of ctx may call method, decisions of methods that are not public
+// are logged for audit
+func authorize(ctx context.Context, policy *rbac.Engine, fullMethod string) error {
+	identity := rbac.IdentityFromContext(ctx)
+	decision := policy.Decide(identity, fullMethod)
+	if decision.Allowed && !decision.Public {
+		logger.InfoKV(ctx, fmt.Sprintf("%s: access granted", authzLogTag),
+			"method", fullMethod,
+			"subject", identity.Subject,
+			"roles", identity.Roles,
+			"scopes", identity.Scopes,
+			"reason", decision.Reason,
+		)
+	}
+	if !decision.Allowed {
+		logger.WarnKV(ctx, fmt.Sprintf("%s: access denied", authzLogTag),
+			"method", fullMethod,
+			"subject", identity.Subject,
+			"roles", identity.Roles,
+			"scopes", identity.Scopes,
+			"reason", decision.Reason,
+		)
+
+		return status.Error(codes.PermissionDenied, fmt.Sprintf("%s is not allowed: %s", fullMethod, decision.Reason))
+	}
+
+	return nil
+}
+
+// authzInterceptor - reject unary requests to methods that policy does not grant to caller
+func authzInterceptor(policy *rbac.Engine) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorize(ctx, policy, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// authzStreamInterceptor - reject streams of methods that policy does not grant to caller
+func authzStreamInterceptor(policy *rbac.Engine) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(stream.Context(), policy, info.FullMethod); err != nil {
+			return err
+		}
+
+		return handler(srv, stream)
+	}
+}
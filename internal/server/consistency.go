@@ -0,0 +1,41 @@
+package server
+
+import (
+	"context"
+	"net/textproto"
+	"strconv"
+
+	"cmd/main.go/internal/database"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// readYourWritesMetadataKey - clients set it on reads that follow their own mutation,
+// such reads are served by primary instead of possibly lagging replicas
+const readYourWritesMetadataKey = "x-read-your-writes"
+
+// readYourWritesInterceptor - mark ctx for primary reads when request metadata asks for it
+func readYourWritesInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(readYourWritesMetadataKey); len(values) > 0 {
+				if force, err := strconv.ParseBool(values[0]); err == nil && force {
+					ctx = database.WithReadYourWrites(ctx)
+				}
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// gatewayHeaderMatcher - pass read-your-writes header of REST requests to gRPC metadata
+func gatewayHeaderMatcher(key string) (string, bool) {
+	if textproto.CanonicalMIMEHeaderKey(key) == textproto.CanonicalMIMEHeaderKey(readYourWritesMetadataKey) {
+		return readYourWritesMetadataKey, true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
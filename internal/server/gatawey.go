@@ -2,18 +2,18 @@ package server
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	grpc_opentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/opentracing/opentracing-go"
-	"github.com/opentracing/opentracing-go/ext"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"google.golang.org/grpc"
 
+	"cmd/main.go/internal/config"
 	"cmd/main.go/internal/logger"
 	desc "cmd/main.go/pkg/my-api"
 )
@@ -27,7 +27,7 @@ var (
 	})
 )
 
-func createGatewayServer(ctx context.Context, grpcAddr, gatewayAddr string) *http.Server {
+func createGatewayServer(ctx context.Context, grpcAddr string, cfg config.Rest) *http.Server {
 	// Create a client connection to the gRPC Server we just started.
 	// This is where the gRPC-Gateway proxies the requests.
 	conn, err := grpc.DialContext(
@@ -46,37 +46,34 @@ func createGatewayServer(ctx context.Context, grpcAddr, gatewayAddr string) *htt
 		)
 	}
 
-	mux := runtime.NewServeMux()
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(gatewayHeaderMatcher),
+		runtime.WithMetadata(annotateRoute),
+	)
 	if err := desc.RegisterApiServiceHandler(ctx, mux, conn); err != nil {
 		logger.FatalKV(ctx, fmt.Sprintf("%s: pb.RegisterBssEquipmentRequestApiServiceHandler failed", createGatewayServerLogTag),
 			"err", err,
 		)
 	}
+	if err := desc.RegisterGroupServiceHandler(ctx, mux, conn); err != nil {
+		logger.FatalKV(ctx, fmt.Sprintf("%s: pb.RegisterGroupServiceHandler failed", createGatewayServerLogTag),
+			"err", err,
+		)
+	}
+	if err := desc.RegisterAdminServiceHandler(ctx, mux, conn); err != nil {
+		logger.FatalKV(ctx, fmt.Sprintf("%s: pb.RegisterAdminServiceHandler failed", createGatewayServerLogTag),
+			"err", err,
+		)
+	}
 
 	gatewayServer := &http.Server{
-		Addr: gatewayAddr,
+		Addr:              fmt.Sprintf("%s:%v", cfg.Host, cfg.Port),
+		Handler:           chain(mux, gatewayMiddlewares(cfg)...),
+		ReadTimeout:       time.Duration(cfg.ReadTimeout) * time.Second,
+		ReadHeaderTimeout: time.Duration(cfg.ReadHeaderTimeout) * time.Second,
+		WriteTimeout:      time.Duration(cfg.WriteTimeout) * time.Second,
+		IdleTimeout:       time.Duration(cfg.IdleTimeout) * time.Second,
 	}
 
 	return gatewayServer
 }
-
-var grpcGatewayTag = opentracing.Tag{Key: string(ext.Component), Value: "grpc-gateway"}
-
-func tracingWrapper(h http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		httpTotalRequests.Inc()
-		parentSpanContext, err := opentracing.GlobalTracer().Extract(
-			opentracing.HTTPHeaders,
-			opentracing.HTTPHeadersCarrier(r.Header))
-		if err == nil || errors.Is(err, opentracing.ErrSpanContextNotFound) {
-			serverSpan := opentracing.GlobalTracer().StartSpan(
-				"ServeHTTP",
-				ext.RPCServerOption(parentSpanContext),
-				grpcGatewayTag,
-			)
-			r = r.WithContext(opentracing.ContextWithSpan(r.Context(), serverSpan))
-			defer serverSpan.Finish()
-		}
-		h.ServeHTTP(w, r)
-	})
-}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"cmd/main.go/internal/logger"
+)
+
+const grpcHealthLogTag = "GrpcHealth"
+
+// defaultHealthCheckInterval - interval of grpc.health.v1 updates when grpc.healthCheckInterval is not set
+const defaultHealthCheckInterval = 5 * time.Second
+
+// runHealthUpdates - set statuses of services and of the server as a whole ("") by readiness of status
+// every interval until ctx is done. Watch streams get a message on every change of status
+func runHealthUpdates(ctx context.Context, status *serviceStatus, healthServer *grpchealth.Server, services []string, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := healthpb.HealthCheckResponse_UNKNOWN
+	for {
+		response, ok := status.ready(ctx)
+
+		serving := healthpb.HealthCheckResponse_NOT_SERVING
+		if ok {
+			serving = healthpb.HealthCheckResponse_SERVING
+		}
+		if serving != last {
+			logger.InfoKV(ctx, fmt.Sprintf("%s: serving status changed", grpcHealthLogTag),
+				"status", serving.String(),
+				"reason", response.Status,
+			)
+			last = serving
+		}
+
+		healthServer.SetServingStatus("", serving)
+		for _, service := range services {
+			healthServer.SetServingStatus(service, serving)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestRunHealthUpdates(t *testing.T) {
+	const service = "user.UserService"
+
+	tests := []struct {
+		name     string
+		started  bool
+		stopping bool
+		want     healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{name: "starting", want: healthpb.HealthCheckResponse_NOT_SERVING},
+		{name: "ready", started: true, want: healthpb.HealthCheckResponse_SERVING},
+		{name: "stopping", started: true, stopping: true, want: healthpb.HealthCheckResponse_NOT_SERVING},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := newServiceStatus(nil, 0)
+			status.started.Store(tt.started)
+			status.stopping.Store(tt.stopping)
+			healthServer := grpchealth.NewServer()
+
+			// updates run once and return, since ctx is already done
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			runHealthUpdates(ctx, status, healthServer, []string{service}, time.Hour)
+
+			for _, name := range []string{"", service} {
+				response, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: name})
+				if err != nil {
+					t.Fatalf("Check(%q) error = %v", name, err)
+				}
+				if response.GetStatus() != tt.want {
+					t.Errorf("Check(%q) = %v, want %v", name, response.GetStatus(), tt.want)
+				}
+			}
+		})
+	}
+}
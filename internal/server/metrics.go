@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"cmd/main.go/internal/config"
+)
+
+// defaultMetricsPath - path of metrics when metrics.path is not set
+const defaultMetricsPath = "/metrics"
+
+// unmatchedRoute - route label of gateway requests that match no gRPC method
+const unmatchedRoute = "unmatched"
+
+var (
+	grpcHandlingSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Duration of gRPC requests by method and status code",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"grpc_service", "grpc_method", "grpc_code"})
+	// streams such as health Watch live for hours, so they are kept apart from requests
+	grpcStreamSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_stream_duration_seconds",
+		Help:    "Duration of gRPC streams by method and status code",
+		Buckets: prometheus.ExponentialBuckets(1, 4, 8),
+	}, []string{"grpc_service", "grpc_method", "grpc_code"})
+	httpRequestSeconds = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Duration of gateway requests by route pattern, method and status code",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"route", "method", "code"})
+)
+
+// createMetricsServer - server of prometheus metrics registered by promauto and collectors of database pools
+func createMetricsServer(cfg config.Metrics) *http.Server {
+	path := cfg.Path
+	if path == "" {
+		path = defaultMetricsPath
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(path, promhttp.Handler())
+
+	return &http.Server{
+		Addr:              fmt.Sprintf("%s:%v", cfg.Host, cfg.Port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+// metricsInterceptor - observe duration and status code of every request, it runs outside of recovery,
+// so panics are counted as Internal
+func metricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		service, method := splitFullMethod(info.FullMethod)
+		grpcHandlingSeconds.WithLabelValues(service, method, status.Code(err).String()).
+			Observe(time.Since(start).Seconds())
+
+		return resp, err
+	}
+}
+
+// metricsStreamInterceptor - observe duration and status code of every stream, it runs outside of recovery,
+// so panics are counted as Internal
+func metricsStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+
+		service, method := splitFullMethod(info.FullMethod)
+		grpcStreamSeconds.WithLabelValues(service, method, status.Code(err).String()).
+			Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
+
+// splitFullMethod - split /package.Service/Method into service and method
+func splitFullMethod(fullMethod string) (string, string) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i], fullMethod[i+1:]
+	}
+
+	return "unknown", fullMethod
+}
+
+type routeKey struct{}
+
+// latencyWrapper - observe duration of gateway requests by route pattern, the pattern is known once
+// gateway matched the request, so annotateRoute reports it back through ctx
+func latencyWrapper(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		route := unmatchedRoute
+		rec := &statusRecorder{ResponseWriter: w}
+
+		defer func() {
+			code := rec.status
+			if code == 0 {
+				code = http.StatusOK
+			}
+			httpRequestSeconds.WithLabelValues(route, r.Method, fmt.Sprint(code)).
+				Observe(time.Since(start).Seconds())
+		}()
+
+		h.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), routeKey{}, &route)))
+	})
+}
+
+// annotateRoute - gateway metadata annotator that passes matched route pattern to latencyWrapper,
+// it adds no metadata
+func annotateRoute(ctx context.Context, _ *http.Request) metadata.MD {
+	route, ok := ctx.Value(routeKey{}).(*string)
+	if !ok {
+		return nil
+	}
+	if pattern, ok := runtime.HTTPPathPattern(ctx); ok {
+		*route = pattern
+	}
+
+	return nil
+}
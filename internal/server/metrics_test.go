@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sampleCount - number of observations of histogram with labels
+func sampleCount(t *testing.T, h *prometheus.HistogramVec, labels ...string) uint64 {
+	t.Helper()
+
+	var m dto.Metric
+	if err := h.WithLabelValues(labels...).(prometheus.Metric).Write(&m); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	return m.GetHistogram().GetSampleCount()
+}
+
+func TestSplitFullMethod(t *testing.T) {
+	tests := []struct {
+		fullMethod  string
+		wantService string
+		wantMethod  string
+	}{
+		{fullMethod: "/aperg.my_api.v1.ApiService/RestoreUsers", wantService: "aperg.my_api.v1.ApiService", wantMethod: "RestoreUsers"},
+		{fullMethod: "/grpc.health.v1.Health/Watch", wantService: "grpc.health.v1.Health", wantMethod: "Watch"},
+		{fullMethod: "Ping", wantService: "unknown", wantMethod: "Ping"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fullMethod, func(t *testing.T) {
+			service, method := splitFullMethod(tt.fullMethod)
+			if service != tt.wantService || method != tt.wantMethod {
+				t.Errorf("splitFullMethod() = %q, %q, want %q, %q", service, method, tt.wantService, tt.wantMethod)
+			}
+		})
+	}
+}
+
+func TestMetricsInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Unary"}
+	before := sampleCount(t, grpcHandlingSeconds, "test.Service", "Unary", codes.NotFound.String())
+
+	_, err := metricsInterceptor()(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("interceptor error = %v, want handler error", err)
+	}
+
+	if got := sampleCount(t, grpcHandlingSeconds, "test.Service", "Unary", codes.NotFound.String()) - before; got != 1 {
+		t.Errorf("observed %d requests, want 1", got)
+	}
+}
+
+func TestMetricsStreamInterceptor(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Watch", IsServerStream: true}
+	before := sampleCount(t, grpcStreamSeconds, "test.Service", "Watch", codes.Canceled.String())
+
+	err := metricsStreamInterceptor()(nil, nil, info, func(srv interface{}, stream grpc.ServerStream) error {
+		return status.Error(codes.Canceled, "client went away")
+	})
+	if status.Code(err) != codes.Canceled {
+		t.Fatalf("interceptor error = %v, want handler error", err)
+	}
+
+	if got := sampleCount(t, grpcStreamSeconds, "test.Service", "Watch", codes.Canceled.String()) - before; got != 1 {
+		t.Errorf("observed %d streams, want 1", got)
+	}
+	if got := sampleCount(t, grpcHandlingSeconds, "test.Service", "Watch", codes.Canceled.String()); got != 0 {
+		t.Errorf("stream observed as %d requests, want none", got)
+	}
+}
@@ -0,0 +1,240 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/logger"
+)
+
+const (
+	accessLogTag = "AccessLog"
+	recoveryTag  = "GatewayRecovery"
+)
+
+// internalErrorBody - body of recovered panics, shaped as errors of grpc-gateway with code Internal
+const internalErrorBody = `{"code":13,"message":"internal error","details":[]}`
+
+// middleware wraps gateway handler
+type middleware func(h http.Handler) http.Handler
+
+// chain - wrap h with middlewares, the first one sees the request first
+func chain(h http.Handler, middlewares ...middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+
+	return h
+}
+
+// gatewayMiddlewares - middlewares of gateway in order they see requests, recovery is inside latency
+// and access log, so recovered panics are observed and logged with status 500
+func gatewayMiddlewares(cfg config.Rest) []middleware {
+	middlewares := []middleware{tracingWrapper, countingWrapper, latencyWrapper}
+	if cfg.AccessLog {
+		middlewares = append(middlewares, accessLogWrapper)
+	}
+	middlewares = append(middlewares, recoveryWrapper, corsWrapper(cfg.Cors))
+	if cfg.MaxBodyBytes > 0 {
+		middlewares = append(middlewares, bodyLimitWrapper(cfg.MaxBodyBytes))
+	}
+
+	return middlewares
+}
+
+var grpcGatewayTag = opentracing.Tag{Key: string(ext.Component), Value: "grpc-gateway"}
+
+func tracingWrapper(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parentSpanContext, err := opentracing.GlobalTracer().Extract(
+			opentracing.HTTPHeaders,
+			opentracing.HTTPHeadersCarrier(r.Header))
+		if err == nil || errors.Is(err, opentracing.ErrSpanContextNotFound) {
+			serverSpan := opentracing.GlobalTracer().StartSpan(
+				"ServeHTTP",
+				ext.RPCServerOption(parentSpanContext),
+				grpcGatewayTag,
+			)
+			r = r.WithContext(opentracing.ContextWithSpan(r.Context(), serverSpan))
+			defer serverSpan.Finish()
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func countingWrapper(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httpTotalRequests.Inc()
+		h.ServeHTTP(w, r)
+	})
+}
+
+// recoveryWrapper - answer 500 instead of dropping connection when handler panics
+func recoveryWrapper(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			p := recover()
+			if p == nil {
+				return
+			}
+			// ErrAbortHandler aborts response on purpose, net/http handles it
+			if p == http.ErrAbortHandler { //nolint:errorlint
+				panic(p)
+			}
+
+			logger.ErrorKV(r.Context(), fmt.Sprintf("%s: handler panicked", recoveryTag),
+				"panic", p,
+				"method", r.Method,
+				"path", r.URL.Path,
+				"stack", string(debug.Stack()),
+			)
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			//nolint
+			w.Write([]byte(internalErrorBody))
+		}()
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// corsWrapper - answer preflight requests of allowed origins and mark their responses,
+// requests of other origins get no CORS headers and are refused by browsers
+func corsWrapper(cfg config.Cors) middleware {
+	allowAny := false
+	origins := make(map[string]bool, len(cfg.AllowedOrigins))
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			allowAny = true
+		}
+		origins[origin] = true
+	}
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+
+	return func(h http.Handler) http.Handler {
+		if len(origins) == 0 {
+			return h
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Origin")
+			if !allowAny && !origins[origin] {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			// credentials are not sent to wildcard origin, so the origin is echoed instead
+			if allowAny && !cfg.AllowCredentials {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+			if cfg.AllowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			w.Header().Set("Access-Control-Allow-Methods", methods)
+			if headers != "" {
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+			}
+			if cfg.MaxAge > 0 {
+				w.Header().Set("Access-Control-Max-Age", strconv.FormatInt(cfg.MaxAge, 10))
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// bodyLimitWrapper - refuse bodies larger than limit, declared sizes are checked before the body is read
+func bodyLimitWrapper(limit int64) middleware {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// statusRecorder keeps status and size of response for access log
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *statusRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	n, err := rec.ResponseWriter.Write(b)
+	rec.size += n
+
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach flusher and deadlines of the connection
+func (rec *statusRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
+
+func accessLogWrapper(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+
+		defer func() {
+			status := rec.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			logger.InfoKV(r.Context(), fmt.Sprintf("%s: request served", accessLogTag),
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", status,
+				"bytes", rec.size,
+				"duration", time.Since(start),
+				"remote", r.RemoteAddr,
+				"userAgent", r.UserAgent(),
+			)
+		}()
+
+		h.ServeHTTP(rec, r)
+	})
+}
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"cmd/main.go/internal/config"
+)
+
+func TestChain(t *testing.T) {
+	var order []string
+	mark := func(name string) middleware {
+		return func(h http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				h.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	h := chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, "handler")
+	}), mark("first"), mark("second"))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if want := []string{"first", "second", "handler"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("chain() order = %v, want %v", order, want)
+	}
+}
+
+func TestRecoveryWrapper(t *testing.T) {
+	h := chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}), gatewayMiddlewares(config.Rest{AccessLog: true})...)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/users", nil))
+
+	if rec.Code != http.StatusInternalServerError || rec.Body.String() != internalErrorBody {
+		t.Errorf("recovered response = %d %q, want %d %q", rec.Code, rec.Body.String(), http.StatusInternalServerError, internalErrorBody)
+	}
+}
+
+func TestRecoveryWrapperAbortHandler(t *testing.T) {
+	h := recoveryWrapper(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if p := recover(); p != http.ErrAbortHandler { //nolint:errorlint
+			t.Errorf("recovered %v, want http.ErrAbortHandler to be panicked again", p)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestCorsWrapper(t *testing.T) {
+	cors := config.Cors{
+		AllowedOrigins: []string{"https://app.example.com"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Authorization"},
+		MaxAge:         600,
+	}
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name       string
+		cfg        config.Cors
+		method     string
+		origin     string
+		preflight  bool
+		wantCode   int
+		wantOrigin string
+		wantHeader map[string]string
+	}{
+		{name: "no origin", cfg: cors, method: http.MethodGet, wantCode: http.StatusOK},
+		{name: "allowed origin", cfg: cors, method: http.MethodGet, origin: "https://app.example.com",
+			wantCode: http.StatusOK, wantOrigin: "https://app.example.com"},
+		{name: "other origin", cfg: cors, method: http.MethodGet, origin: "https://evil.example.com", wantCode: http.StatusOK},
+		{name: "preflight", cfg: cors, method: http.MethodOptions, origin: "https://app.example.com", preflight: true,
+			wantCode: http.StatusNoContent, wantOrigin: "https://app.example.com",
+			wantHeader: map[string]string{
+				"Access-Control-Allow-Methods": "GET, POST",
+				"Access-Control-Allow-Headers": "Authorization",
+				"Access-Control-Max-Age":       "600",
+			}},
+		{name: "preflight of other origin", cfg: cors, method: http.MethodOptions, origin: "https://evil.example.com", preflight: true,
+			wantCode: http.StatusOK},
+		{name: "wildcard", cfg: config.Cors{AllowedOrigins: []string{"*"}}, method: http.MethodGet, origin: "https://any.example.com",
+			wantCode: http.StatusOK, wantOrigin: "*"},
+		{name: "wildcard with credentials", cfg: config.Cors{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+			method: http.MethodGet, origin: "https://any.example.com",
+			wantCode: http.StatusOK, wantOrigin: "https://any.example.com",
+			wantHeader: map[string]string{"Access-Control-Allow-Credentials": "true"}},
+		{name: "no allowed origins", method: http.MethodGet, origin: "https://app.example.com", wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/v1/users", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if tt.preflight {
+				r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+
+			rec := httptest.NewRecorder()
+			corsWrapper(tt.cfg)(ok).ServeHTTP(rec, r)
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("code = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+			for key, want := range tt.wantHeader {
+				if got := rec.Header().Get(key); got != want {
+					t.Errorf("%s = %q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBodyLimitWrapper(t *testing.T) {
+	h := bodyLimitWrapper(8)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name          string
+		body          string
+		contentLength int64
+		want          int
+	}{
+		{name: "small body", body: "{}", contentLength: 2, want: http.StatusOK},
+		{name: "declared large body", body: strings.Repeat("a", 16), contentLength: 16, want: http.StatusRequestEntityTooLarge},
+		{name: "undeclared large body", body: strings.Repeat("a", 16), contentLength: -1, want: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/v1/users", strings.NewReader(tt.body))
+			r.ContentLength = tt.contentLength
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, r)
+			if rec.Code != tt.want {
+				t.Errorf("code = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusRecorder(t *testing.T) {
+	rec := &statusRecorder{ResponseWriter: httptest.NewRecorder()}
+	//nolint
+	rec.Write([]byte("hello"))
+	rec.WriteHeader(http.StatusTeapot)
+
+	if rec.status != http.StatusOK || rec.size != 5 {
+		t.Errorf("recorded status = %d, size = %d, want %d, 5", rec.status, rec.size, http.StatusOK)
+	}
+	if _, ok := http.ResponseWriter(rec).(interface{ Unwrap() http.ResponseWriter }); !ok {
+		t.Error("statusRecorder does not unwrap to ResponseWriter")
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
-	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -21,11 +20,19 @@ import (
 	grpc_opentracing "github.com/grpc-ecosystem/go-grpc-middleware/tracing/opentracing"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 
 	"cmd/main.go/internal/api"
+	adminapi "cmd/main.go/internal/api/admin"
+	groupapi "cmd/main.go/internal/api/group"
 	"cmd/main.go/internal/logger"
+	"cmd/main.go/internal/pkg/health"
+	"cmd/main.go/internal/pkg/rbac"
+	"cmd/main.go/internal/service/duplicate"
+	"cmd/main.go/internal/service/group"
 	"cmd/main.go/internal/service/user_request"
 	desc "cmd/main.go/pkg/my-api"
 
@@ -34,14 +41,26 @@ import (
 
 const grpcServerStartLogTag = "GrpcServer.Start()"
 
+// defaultShutdownTimeout - time given to requests in flight when grpc.shutdownTimeout is not set
+const defaultShutdownTimeout = 10 * time.Second
+
 // GrpcServer is gRPC server
 type GrpcServer struct {
 	userRequestService user_request.ServiceInterface
+	groupService       group.ServiceInterface
+	duplicateService   duplicate.ServiceInterface
+	checks             []health.Check
 }
 
-// NewGrpcServer returns gRPC server with supporting of batch listing
-func NewGrpcServer(userRequestService user_request.ServiceInterface) *GrpcServer {
-	return &GrpcServer{userRequestService: userRequestService}
+// NewGrpcServer returns gRPC server with supporting of batch listing,
+// checks are dependencies of readiness served by status server
+func NewGrpcServer(userRequestService user_request.ServiceInterface, groupService group.ServiceInterface, duplicateService duplicate.ServiceInterface, checks []health.Check) *GrpcServer {
+	return &GrpcServer{
+		userRequestService: userRequestService,
+		groupService:       groupService,
+		duplicateService:   duplicateService,
+		checks:             checks,
+	}
 }
 
 // Start method runs server
@@ -49,15 +68,37 @@ func (s *GrpcServer) Start(ctx context.Context, cfg *config.Config) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	gatewayAddr := fmt.Sprintf("%s:%v", cfg.Rest.Host, cfg.Rest.Port)
 	grpcAddr := fmt.Sprintf("%s:%v", cfg.Grpc.Host, cfg.Grpc.Port)
 
-	gatewayServer := createGatewayServer(ctx, grpcAddr, gatewayAddr)
+	status := newServiceStatus(s.checks, time.Duration(cfg.Status.CheckTimeout)*time.Second)
+	statusServer := createStatusServer(cfg.Status, status)
+
+	go func() {
+		logger.InfoKV(ctx, fmt.Sprintf("%s: status server is running on", grpcServerStartLogTag),
+			"address", statusServer.Addr)
+		if err := statusServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Err(err).Msg("Failed running status server")
+			cancel()
+		}
+	}()
+
+	metricsServer := createMetricsServer(cfg.Metrics)
+
+	go func() {
+		logger.InfoKV(ctx, fmt.Sprintf("%s: metrics server is running on", grpcServerStartLogTag),
+			"address", metricsServer.Addr)
+		if err := metricsServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error().Err(err).Msg("Failed running metrics server")
+			cancel()
+		}
+	}()
+
+	gatewayServer := createGatewayServer(ctx, grpcAddr, cfg.Rest)
 
 	go func() {
 
 		logger.InfoKV(ctx, fmt.Sprintf("%s: gateway server is running on", grpcServerStartLogTag),
-			"address", grpcAddr)
+			"address", gatewayServer.Addr)
 		if err := gatewayServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Error().Err(err).Msg("Failed running gateway server")
 			cancel()
@@ -65,9 +106,6 @@ func (s *GrpcServer) Start(ctx context.Context, cfg *config.Config) error {
 		}
 	}()
 
-	isReady := &atomic.Value{}
-	isReady.Store(false)
-
 	l, err := net.Listen("tcp", grpcAddr)
 	if err != nil {
 		return fmt.Errorf("failed to listen: %w", err)
@@ -75,6 +113,41 @@ func (s *GrpcServer) Start(ctx context.Context, cfg *config.Config) error {
 	//nolint
 	defer l.Close()
 
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		grpc_ctxtags.UnaryServerInterceptor(),
+		grpc_opentracing.UnaryServerInterceptor(),
+		metricsInterceptor(),
+		grpcrecovery.UnaryServerInterceptor(),
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		grpc_ctxtags.StreamServerInterceptor(),
+		grpc_opentracing.StreamServerInterceptor(),
+		metricsStreamInterceptor(),
+		grpcrecovery.StreamServerInterceptor(),
+	}
+	if cfg.Auth.Enabled {
+		authn, err := createAuthenticator(ctx, cfg.Auth)
+		if err != nil {
+			return err
+		}
+		// payloads of requests that are not authenticated are not logged
+		unaryInterceptors = append(unaryInterceptors, authInterceptor(authn))
+		streamInterceptors = append(streamInterceptors, authStreamInterceptor(authn))
+	}
+	var policy *rbac.Engine
+	if cfg.Authz.Enabled {
+		if policy, err = rbac.Load(cfg.Authz.PolicyFile); err != nil {
+			return fmt.Errorf("failed to load access policy: %w", err)
+		}
+		unaryInterceptors = append(unaryInterceptors, authzInterceptor(policy))
+		streamInterceptors = append(streamInterceptors, authzStreamInterceptor(policy))
+	}
+	unaryInterceptors = append(unaryInterceptors,
+		grpc_zap.PayloadUnaryServerInterceptor(logger.Clone(ctx), grps_logger.ServerPayloadLoggingDecider()),
+		grps_logger.UnaryServerInterceptor(),
+		readYourWritesInterceptor(),
+	)
+
 	grpcServer := grpc.NewServer(
 		grpc.KeepaliveParams(keepalive.ServerParameters{
 			MaxConnectionIdle: time.Duration(cfg.Grpc.MaxConnectionIdle) * time.Minute,
@@ -82,16 +155,25 @@ func (s *GrpcServer) Start(ctx context.Context, cfg *config.Config) error {
 			MaxConnectionAge:  time.Duration(cfg.Grpc.MaxConnectionAge) * time.Minute,
 			Time:              time.Duration(cfg.Grpc.Timeout) * time.Minute,
 		}),
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
-			grpc_ctxtags.UnaryServerInterceptor(),
-			grpc_opentracing.UnaryServerInterceptor(),
-			grpcrecovery.UnaryServerInterceptor(),
-			grpc_zap.PayloadUnaryServerInterceptor(logger.Clone(ctx), grps_logger.ServerPayloadLoggingDecider()),
-			grps_logger.UnaryServerInterceptor(),
-		)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
 	)
 
-	desc.RegisterApiServiceServer(grpcServer, api.NewApiService(s.userRequestService))
+	desc.RegisterApiServiceServer(grpcServer, api.NewApiService(s.userRequestService, s.duplicateService))
+	desc.RegisterGroupServiceServer(grpcServer, groupapi.NewGroupService(s.groupService))
+	desc.RegisterAdminServiceServer(grpcServer, adminapi.NewAdminService(policy))
+
+	// statuses are NOT_SERVING until the first readiness check passes
+	services := make([]string, 0, 2)
+	for service := range grpcServer.GetServiceInfo() {
+		services = append(services, service)
+	}
+	healthServer := grpchealth.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	for _, service := range services {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
 
 	go func() {
 		logger.InfoKV(ctx, fmt.Sprintf("%s: GRPC server is listening on", grpcServerStartLogTag),
@@ -103,16 +185,19 @@ func (s *GrpcServer) Start(ctx context.Context, cfg *config.Config) error {
 
 	}()
 
-	go func() {
-		time.Sleep(2 * time.Second)
-		isReady.Store(true)
-		log.Info().Msg("The service is ready to accept requests")
-	}()
-
 	if cfg.Project.Debug {
 		reflection.Register(grpcServer)
 	}
 
+	// gRPC listener is bound and gateway dials it lazily, so both accept requests from now on
+	status.started.Store(true)
+	log.Info().Msg("The service is started")
+
+	healthCtx, stopHealthUpdates := context.WithCancel(ctx)
+	defer stopHealthUpdates()
+
+	go runHealthUpdates(healthCtx, status, healthServer, services, time.Duration(cfg.Grpc.HealthCheckInterval)*time.Second)
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	select {
@@ -122,7 +207,11 @@ func (s *GrpcServer) Start(ctx context.Context, cfg *config.Config) error {
 		logger.InfoKV(ctx, fmt.Sprintf("%s: ctx.Done", grpcServerStartLogTag), "done", done)
 	}
 
-	isReady.Store(false)
+	// probes see shutdown before servers stop accepting requests,
+	// Shutdown of health server sets every service NOT_SERVING and ignores later updates
+	status.stopping.Store(true)
+	stopHealthUpdates()
+	healthServer.Shutdown()
 
 	if err := gatewayServer.Shutdown(ctx); err != nil {
 		logger.ErrorKV(ctx, fmt.Sprintf("%s: gatewayServer.Shutdown failed", grpcServerStartLogTag), "err", err)
@@ -130,8 +219,39 @@ func (s *GrpcServer) Start(ctx context.Context, cfg *config.Config) error {
 		logger.Info(ctx, fmt.Sprintf("%s: gatewayServer shut down correctly", grpcServerStartLogTag))
 	}
 
-	grpcServer.GracefulStop()
-	logger.Info(ctx, fmt.Sprintf("%s: grpcServer shut down correctly", grpcServerStartLogTag))
+	gracefulStop(ctx, grpcServer, time.Duration(cfg.Grpc.ShutdownTimeout)*time.Second)
+
+	if err := metricsServer.Shutdown(ctx); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: metricsServer.Shutdown failed", grpcServerStartLogTag), "err", err)
+	}
+
+	if err := statusServer.Shutdown(ctx); err != nil {
+		logger.ErrorKV(ctx, fmt.Sprintf("%s: statusServer.Shutdown failed", grpcServerStartLogTag), "err", err)
+	}
 
 	return nil
 }
+
+// gracefulStop - wait for requests in flight up to timeout, then cancel the rest,
+// Watch streams of health never end on their own
+func gracefulStop(ctx context.Context, grpcServer *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+
+	select {
+	case <-stopped:
+		logger.Info(ctx, fmt.Sprintf("%s: grpcServer shut down correctly", grpcServerStartLogTag))
+	case <-time.After(timeout):
+		grpcServer.Stop()
+		logger.WarnKV(ctx, fmt.Sprintf("%s: grpcServer stopped, requests in flight are cancelled", grpcServerStartLogTag),
+			"timeout", timeout.String(),
+		)
+	}
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/logger"
+	"cmd/main.go/internal/pkg/buildinfo"
+	"cmd/main.go/internal/pkg/health"
+)
+
+const statusServerLogTag = "StatusServer"
+
+// defaultCheckTimeout - timeout of readiness checks when status.checkTimeout is not set
+const defaultCheckTimeout = 3 * time.Second
+
+// serviceStatus - state of service reported by status server: started once servers accept requests,
+// stopping from the start of shutdown
+type serviceStatus struct {
+	checks       []health.Check
+	checkTimeout time.Duration
+	started      atomic.Bool
+	stopping     atomic.Bool
+}
+
+// probeResponse - body of probes, checks are listed by readiness only
+type probeResponse struct {
+	Status string          `json:"status"`
+	Checks []health.Result `json:"checks,omitempty"`
+}
+
+func newServiceStatus(checks []health.Check, checkTimeout time.Duration) *serviceStatus {
+	if checkTimeout <= 0 {
+		checkTimeout = defaultCheckTimeout
+	}
+
+	return &serviceStatus{
+		checks:       checks,
+		checkTimeout: checkTimeout,
+	}
+}
+
+// ready - service is started, not stopping and its dependencies pass checks
+func (s *serviceStatus) ready(ctx context.Context) (probeResponse, bool) {
+	switch {
+	case s.stopping.Load():
+		return probeResponse{Status: "stopping"}, false
+	case !s.started.Load():
+		return probeResponse{Status: "starting"}, false
+	}
+
+	results, healthy := health.Run(ctx, s.checkTimeout, s.checks)
+	if !healthy {
+		return probeResponse{Status: "unavailable", Checks: results}, false
+	}
+
+	return probeResponse{Status: "ok", Checks: results}, true
+}
+
+// createStatusServer - server of probes and build info:
+// /live answers while process serves HTTP, /startup once gRPC and REST servers are started,
+// /ready while service is started, not shutting down and its dependencies are healthy, /version with build info
+func createStatusServer(cfg config.Status, status *serviceStatus) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/live", func(w http.ResponseWriter, r *http.Request) {
+		writeProbe(w, probeResponse{Status: "ok"}, true)
+	})
+	mux.HandleFunc("/startup", func(w http.ResponseWriter, r *http.Request) {
+		if !status.started.Load() {
+			writeProbe(w, probeResponse{Status: "starting"}, false)
+			return
+		}
+		writeProbe(w, probeResponse{Status: "ok"}, true)
+	})
+	mux.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+		response, ok := status.ready(r.Context())
+		if !ok {
+			logger.WarnKV(r.Context(), fmt.Sprintf("%s: service is not ready", statusServerLogTag),
+				"status", response.Status,
+				"checks", response.Checks,
+			)
+		}
+		writeProbe(w, response, ok)
+	})
+	mux.HandleFunc("/version", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, buildinfo.Get())
+	})
+
+	return &http.Server{
+		Addr:              fmt.Sprintf("%s:%v", cfg.Host, cfg.Port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
+
+func writeProbe(w http.ResponseWriter, response probeResponse, ok bool) {
+	code := http.StatusOK
+	if !ok {
+		code = http.StatusServiceUnavailable
+	}
+	writeJSON(w, code, response)
+}
+
+func writeJSON(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	//nolint
+	json.NewEncoder(w).Encode(body)
+}
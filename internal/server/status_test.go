@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/pkg/buildinfo"
+	"cmd/main.go/internal/pkg/health"
+)
+
+func probe(t *testing.T, h http.Handler, path string) (int, probeResponse) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var response probeResponse
+	if err := json.NewDecoder(rec.Body).Decode(&response); err != nil {
+		t.Fatalf("decode %s: %v", path, err)
+	}
+
+	return rec.Code, response
+}
+
+func TestStatusServer(t *testing.T) {
+	var dbErr error
+	status := newServiceStatus([]health.Check{
+		{Name: "database primary", Check: func(ctx context.Context) error { return dbErr }},
+	}, 0)
+	h := createStatusServer(config.Status{}, status).Handler
+
+	steps := []struct {
+		name  string
+		setup func()
+		path  string
+		want  int
+		state string
+	}{
+		{name: "live while starting", path: "/live", want: http.StatusOK, state: "ok"},
+		{name: "startup while starting", path: "/startup", want: http.StatusServiceUnavailable, state: "starting"},
+		{name: "ready while starting", path: "/ready", want: http.StatusServiceUnavailable, state: "starting"},
+		{name: "started", setup: func() { status.started.Store(true) }, path: "/startup", want: http.StatusOK, state: "ok"},
+		{name: "ready", path: "/ready", want: http.StatusOK, state: "ok"},
+		{name: "database down", setup: func() { dbErr = errors.New("down") }, path: "/ready", want: http.StatusServiceUnavailable, state: "unavailable"},
+		{name: "stopping", setup: func() { dbErr = nil; status.stopping.Store(true) }, path: "/ready", want: http.StatusServiceUnavailable, state: "stopping"},
+		{name: "live while stopping", path: "/live", want: http.StatusOK, state: "ok"},
+	}
+
+	for _, step := range steps {
+		if step.setup != nil {
+			step.setup()
+		}
+
+		code, response := probe(t, h, step.path)
+		if code != step.want || response.Status != step.state {
+			t.Errorf("%s: %s = %d %q, want %d %q", step.name, step.path, code, response.Status, step.want, step.state)
+		}
+		if step.state == "unavailable" && (len(response.Checks) != 1 || response.Checks[0].Error != "down") {
+			t.Errorf("%s: checks = %v, want failed database check", step.name, response.Checks)
+		}
+	}
+}
+
+func TestStatusServerVersion(t *testing.T) {
+	rec := httptest.NewRecorder()
+	createStatusServer(config.Status{}, newServiceStatus(nil, 0)).Handler.
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	var info buildinfo.Info
+	if err := json.NewDecoder(rec.Body).Decode(&info); err != nil {
+		t.Fatalf("decode /version: %v", err)
+	}
+	if rec.Code != http.StatusOK || info.Version != buildinfo.Version || info.GoVersion == "" {
+		t.Errorf("/version = %d %+v", rec.Code, info)
+	}
+}
@@ -0,0 +1,192 @@
+package duplicate
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/logger"
+	"cmd/main.go/internal/model"
+	duplicaterepo "cmd/main.go/internal/repo/duplicate"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+const analyzerRunLogTag = "DuplicateAnalyzer.Run()"
+
+// defaultAnalyzerInterval - interval of scans when duplicates.interval is not set
+const defaultAnalyzerInterval = time.Hour
+
+const (
+	nameWeight       = 0.6
+	emailLocalWeight = 0.4
+)
+
+// ServiceInterface is a interface for duplicate users service
+type ServiceInterface interface {
+	ListDuplicateCandidates(ctx context.Context, minScore float64, limit uint64, offset uint64) ([]model.DuplicateCandidate, error)
+	Scan(ctx context.Context) error
+}
+
+type service struct {
+	transactor          database.Transactor
+	duplicateRepository duplicaterepo.Repo
+	cfg                 config.Duplicates
+}
+
+// New is a function to create a new service
+func New(transactor database.Transactor, duplicateRepository duplicaterepo.Repo, cfg config.Duplicates) ServiceInterface {
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 500
+	}
+
+	return service{
+		transactor:          transactor,
+		duplicateRepository: duplicateRepository,
+		cfg:                 cfg,
+	}
+}
+
+func (s service) ListDuplicateCandidates(ctx context.Context, minScore float64, limit uint64, offset uint64) ([]model.DuplicateCandidate, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.ListDuplicateCandidates")
+	defer span.Finish()
+
+	candidates, err := s.duplicateRepository.ListCandidates(ctx, minScore, limit, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "duplicateRepository.ListCandidates")
+	}
+
+	return candidates, nil
+}
+
+// Scan - walk over all users in batches and store scored duplicate candidates,
+// pairs that were not found again are pruned after the full pass
+func (s service) Scan(ctx context.Context) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.ScanDuplicates")
+	defer span.Finish()
+
+	unlock, locked, err := s.duplicateRepository.TryLock(ctx)
+	if err != nil {
+		return errors.Wrap(err, "duplicateRepository.TryLock")
+	}
+	if !locked {
+		logger.Info(ctx, fmt.Sprintf("%s: scan is already running on other instance", analyzerRunLogTag))
+		return nil
+	}
+	defer unlock()
+
+	startedAt := time.Now()
+	threshold := math.Min(s.cfg.NameThreshold, s.cfg.EmailLocalThreshold)
+
+	var afterID uint64
+	var found int
+	for {
+		var lastID uint64
+		var saved int
+		_, err := database.WithTx(ctx, s.transactor, func(ctx context.Context) (bool, error) {
+			pairs, last, err := s.duplicateRepository.FindPairs(ctx, afterID, s.cfg.BatchSize, threshold)
+			if err != nil {
+				return false, errors.Wrap(err, "duplicateRepository.FindPairs")
+			}
+			lastID = last
+
+			candidates := s.score(pairs, time.Now())
+			saved = len(candidates)
+
+			if err = s.duplicateRepository.SaveCandidates(ctx, candidates); err != nil {
+				return false, errors.Wrap(err, "duplicateRepository.SaveCandidates")
+			}
+
+			return true, nil
+		}, database.RetryOnConflict())
+		if err != nil {
+			return err
+		}
+		found += saved
+
+		if lastID == 0 {
+			break
+		}
+		afterID = lastID
+	}
+
+	pruned, err := s.duplicateRepository.PruneCandidates(ctx, startedAt)
+	if err != nil {
+		return errors.Wrap(err, "duplicateRepository.PruneCandidates")
+	}
+
+	logger.InfoKV(ctx, fmt.Sprintf("%s: scan finished", analyzerRunLogTag),
+		"found", found,
+		"pruned", pruned,
+		"duration", time.Since(startedAt).String(),
+	)
+
+	return nil
+}
+
+// score - turn raw signals into candidates, equal normalized emails are a certain match,
+// otherwise score is a weighted sum of name and email local part similarities
+func (s service) score(pairs []model.DuplicatePair, detectedAt time.Time) []model.DuplicateCandidate {
+	candidates := make([]model.DuplicateCandidate, 0, len(pairs))
+	for _, p := range pairs {
+		var signals []string
+		if p.EmailEqual {
+			signals = append(signals, model.SignalEmailEqual)
+		}
+		if p.EmailLocalSimilarity >= s.cfg.EmailLocalThreshold {
+			signals = append(signals, model.SignalEmailLocalSimilar)
+		}
+		if p.NameSimilarity >= s.cfg.NameThreshold {
+			signals = append(signals, model.SignalNameSimilar)
+		}
+		if len(signals) == 0 {
+			continue
+		}
+
+		score := nameWeight*p.NameSimilarity + emailLocalWeight*p.EmailLocalSimilarity
+		if p.EmailEqual {
+			score = 1
+		}
+		if score < s.cfg.MinScore {
+			continue
+		}
+
+		candidates = append(candidates, model.DuplicateCandidate{
+			UserA:      p.UserA,
+			UserB:      p.UserB,
+			Score:      score,
+			Signals:    signals,
+			DetectedAt: detectedAt,
+		})
+	}
+
+	return candidates
+}
+
+// RunAnalyzer - run scans periodically until context is done
+func RunAnalyzer(ctx context.Context, s ServiceInterface, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultAnalyzerInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Scan(ctx); err != nil {
+			logger.ErrorKV(ctx, fmt.Sprintf("%s: scan failed", analyzerRunLogTag),
+				"err", err,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package duplicate
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/model"
+)
+
+func TestScore(t *testing.T) {
+	s := service{cfg: config.Duplicates{NameThreshold: 0.5, EmailLocalThreshold: 0.7, MinScore: 0.4}}
+	detectedAt := time.Now()
+	// scores are computed at run time like in score, constant expressions would be rounded differently
+	nameSimilarity, emailLocalSimilarity := 0.5, 0.75
+
+	tests := []struct {
+		name string
+		pair model.DuplicatePair
+		want []model.DuplicateCandidate
+	}{
+		{
+			name: "equal emails are a certain match",
+			pair: model.DuplicatePair{UserA: 1, UserB: 2, EmailEqual: true, NameSimilarity: 0.1},
+			want: []model.DuplicateCandidate{{
+				UserA: 1, UserB: 2, Score: 1,
+				Signals:    model.Signals{model.SignalEmailEqual},
+				DetectedAt: detectedAt,
+			}},
+		},
+		{
+			name: "weighted sum of similarities",
+			pair: model.DuplicatePair{UserA: 1, UserB: 3, NameSimilarity: nameSimilarity, EmailLocalSimilarity: emailLocalSimilarity},
+			want: []model.DuplicateCandidate{{
+				UserA: 1, UserB: 3, Score: nameWeight*nameSimilarity + emailLocalWeight*emailLocalSimilarity,
+				Signals:    model.Signals{model.SignalEmailLocalSimilar, model.SignalNameSimilar},
+				DetectedAt: detectedAt,
+			}},
+		},
+		{
+			name: "similar name only",
+			pair: model.DuplicatePair{UserA: 2, UserB: 3, NameSimilarity: 0.9},
+			want: []model.DuplicateCandidate{{
+				UserA: 2, UserB: 3, Score: nameWeight * 0.9,
+				Signals:    model.Signals{model.SignalNameSimilar},
+				DetectedAt: detectedAt,
+			}},
+		},
+		{
+			name: "no signal over thresholds",
+			pair: model.DuplicatePair{UserA: 1, UserB: 2, NameSimilarity: 0.49, EmailLocalSimilarity: 0.69},
+		},
+		{
+			name: "score under min score",
+			pair: model.DuplicatePair{UserA: 1, UserB: 2, NameSimilarity: 0.6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.score([]model.DuplicatePair{tt.pair}, detectedAt)
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("score() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDefaultBatchSize(t *testing.T) {
+	if got := New(nil, nil, config.Duplicates{}).(service).cfg.BatchSize; got != 500 {
+		t.Errorf("New() batch size = %d, want 500", got)
+	}
+}
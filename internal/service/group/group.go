@@ -0,0 +1,242 @@
+package group
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/repo"
+	grouprepo "cmd/main.go/internal/repo/group"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+type service struct {
+	transactor        database.Transactor
+	groupRepository   grouprepo.Repo
+	requestRepository repo.UserRequestRepo
+}
+
+// ServiceInterface is a interface for Group service
+type ServiceInterface interface {
+	CreateGroup(ctx context.Context, group *model.Group) (uint64, error)
+	GetGroupById(ctx context.Context, IDs []uint64) ([]model.Group, error)
+	ListGroups(ctx context.Context, limit uint64, offset uint64) ([]model.Group, error)
+	UpdateGroup(ctx context.Context, groupID uint64, name, description string) (bool, error)
+	RemoveGroups(ctx context.Context, IDs []uint64) (bool, error)
+	AddMembers(ctx context.Context, groupID uint64, userIDs []uint64) ([]uint64, error)
+	RemoveMembers(ctx context.Context, groupID uint64, userIDs []uint64) (bool, error)
+	ListMembers(ctx context.Context, groupID uint64, limit uint64, offset uint64) ([]model.UserRequest, error)
+	ListUserGroups(ctx context.Context, userID uint64) ([]model.Group, error)
+}
+
+// New is a function to create a new service, members are checked and loaded with requestRepository,
+// so they may live on shards
+func New(transactor database.Transactor, groupRepository grouprepo.Repo, requestRepository repo.UserRequestRepo) ServiceInterface {
+	return service{
+		transactor:        transactor,
+		groupRepository:   groupRepository,
+		requestRepository: requestRepository,
+	}
+}
+
+// ErrNoCreatedGroup is a "unable to create group" error
+var ErrNoCreatedGroup = errors.New("unable to create group")
+
+// ErrNoGroup is a "group not found" error
+var ErrNoGroup = errors.New("group with this id does not exist")
+
+// ErrNoUpdatedGroup is a "unable to update group" error
+var ErrNoUpdatedGroup = errors.New("unable to update group")
+
+// ErrNoRemovedGroup is a "unable to remove group" error
+var ErrNoRemovedGroup = errors.New("unable to remove group")
+
+func (s service) CreateGroup(ctx context.Context, group *model.Group) (uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.CreateGroup")
+	defer span.Finish()
+
+	if group.CreatedAt.IsZero() {
+		group.CreatedAt = time.Now()
+	}
+
+	return database.WithTx(ctx, s.transactor, func(ctx context.Context) (uint64, error) {
+		id, err := s.groupRepository.CreateGroup(ctx, group)
+		if err != nil {
+			return 0, errors.Wrap(err, "groupRepository.CreateGroup")
+		}
+
+		if id == 0 {
+			return 0, ErrNoCreatedGroup
+		}
+		group.ID_group = id
+
+		return id, nil
+	}, database.RetryOnConflict())
+}
+
+func (s service) GetGroupById(ctx context.Context, IDs []uint64) ([]model.Group, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.GetGroupById")
+	defer span.Finish()
+	groups, err := s.groupRepository.GetGroupById(ctx, IDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "groupRepository.GetGroupById")
+	}
+
+	return groups, nil
+}
+
+func (s service) ListGroups(ctx context.Context, limit uint64, offset uint64) ([]model.Group, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.ListGroups")
+	defer span.Finish()
+	groups, err := s.groupRepository.ListGroups(ctx, limit, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "groupRepository.ListGroups")
+	}
+
+	return groups, nil
+}
+
+func (s service) UpdateGroup(ctx context.Context, groupID uint64, name, description string) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.UpdateGroup")
+	defer span.Finish()
+	return database.WithTx(ctx, s.transactor, func(ctx context.Context) (bool, error) {
+		result, err := s.groupRepository.UpdateGroup(ctx, groupID, name, description)
+		if err != nil {
+			return false, errors.Wrap(err, "groupRepository.UpdateGroup")
+		}
+
+		if !result {
+			return false, ErrNoUpdatedGroup
+		}
+
+		return result, nil
+	}, database.RetryOnConflict())
+}
+
+func (s service) RemoveGroups(ctx context.Context, IDs []uint64) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.RemoveGroups")
+	defer span.Finish()
+	return database.WithTx(ctx, s.transactor, func(ctx context.Context) (bool, error) {
+		result, err := s.groupRepository.RemoveGroups(ctx, IDs)
+		if err != nil {
+			return false, errors.Wrap(err, "groupRepository.RemoveGroups")
+		}
+
+		if !result {
+			return false, ErrNoRemovedGroup
+		}
+
+		return result, nil
+	}, database.RetryOnConflict())
+}
+
+func (s service) AddMembers(ctx context.Context, groupID uint64, userIDs []uint64) ([]uint64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.AddMembers")
+	defer span.Finish()
+
+	if err := s.checkGroupExists(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	var added []uint64
+	_, err := database.WithTx(ctx, s.transactor, func(ctx context.Context) (bool, error) {
+		users, err := s.requestRepository.GetUserByIdRequest(ctx, userIDs)
+		if err != nil {
+			return false, errors.Wrap(err, "requestRepository.GetUserByIdRequest")
+		}
+
+		// removed and missing users are skipped
+		existing := make([]uint64, 0, len(users))
+		for _, u := range users {
+			if !u.DeletedAt.Valid {
+				existing = append(existing, u.ID_user)
+			}
+		}
+
+		added, err = s.groupRepository.AddMembers(ctx, groupID, existing)
+		if err != nil {
+			return false, errors.Wrap(err, "groupRepository.AddMembers")
+		}
+
+		return true, nil
+	}, database.RetryOnConflict())
+	if err != nil {
+		return nil, err
+	}
+
+	return added, nil
+}
+
+func (s service) RemoveMembers(ctx context.Context, groupID uint64, userIDs []uint64) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.RemoveMembers")
+	defer span.Finish()
+	return database.WithTx(ctx, s.transactor, func(ctx context.Context) (bool, error) {
+		result, err := s.groupRepository.RemoveMembers(ctx, groupID, userIDs)
+		if err != nil {
+			return false, errors.Wrap(err, "groupRepository.RemoveMembers")
+		}
+
+		return result, nil
+	}, database.RetryOnConflict())
+}
+
+func (s service) ListMembers(ctx context.Context, groupID uint64, limit uint64, offset uint64) ([]model.UserRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.ListMembers")
+	defer span.Finish()
+
+	if err := s.checkGroupExists(ctx, groupID); err != nil {
+		return nil, err
+	}
+
+	userIDs, err := s.groupRepository.ListMembers(ctx, groupID, limit, offset)
+	if err != nil {
+		return nil, errors.Wrap(err, "groupRepository.ListMembers")
+	}
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	users, err := s.requestRepository.GetUserByIdRequest(ctx, userIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "requestRepository.GetUserByIdRequest")
+	}
+
+	// memberships are dropped when users are removed, a member removed meanwhile is skipped
+	members := make([]model.UserRequest, 0, len(users))
+	for _, u := range users {
+		if !u.DeletedAt.Valid {
+			members = append(members, u)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].ID_user < members[j].ID_user })
+
+	return members, nil
+}
+
+func (s service) ListUserGroups(ctx context.Context, userID uint64) ([]model.Group, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.ListUserGroups")
+	defer span.Finish()
+	groups, err := s.groupRepository.ListUserGroups(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "groupRepository.ListUserGroups")
+	}
+
+	return groups, nil
+}
+
+func (s service) checkGroupExists(ctx context.Context, groupID uint64) error {
+	groups, err := s.groupRepository.GetGroupById(ctx, []uint64{groupID})
+	if err != nil {
+		return errors.Wrap(err, "groupRepository.GetGroupById")
+	}
+
+	if len(groups) == 0 || groups[0].DeletedAt.Valid {
+		return ErrNoGroup
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package group
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/pkg/hashring"
+	"cmd/main.go/internal/repo"
+	"cmd/main.go/internal/repo/memory"
+	"cmd/main.go/internal/repo/sharded"
+)
+
+// TestMembersOnShards - groups live on primary while members are spread across shards
+func TestMembersOnShards(t *testing.T) {
+	ctx := context.Background()
+
+	primary := memory.NewStore()
+	shards := map[string]repo.UserRequestRepo{
+		"shard-a": memory.NewUserRequestRepo(memory.NewStore()),
+		"shard-b": memory.NewUserRequestRepo(memory.NewStore()),
+	}
+	users := sharded.NewUserRequestRepo(hashring.New([]string{"shard-a", "shard-b"}, 0), shards)
+	s := New(primary.Transactor(), memory.NewGroupRepo(primary), users)
+
+	var userIDs []uint64
+	for id := uint64(1); id <= 8; id++ {
+		if _, err := users.CreateUserRequest(ctx, &model.UserRequest{ID_user: id, Name: "member", CreatedAt: time.Now()}); err != nil {
+			t.Fatalf("CreateUserRequest() error = %v", err)
+		}
+		userIDs = append(userIDs, id)
+	}
+	if _, err := users.RemoveUserRequest(ctx, []uint64{8}); err != nil {
+		t.Fatalf("RemoveUserRequest() error = %v", err)
+	}
+
+	groupID, err := s.CreateGroup(ctx, &model.Group{Name: "sharded"})
+	if err != nil {
+		t.Fatalf("CreateGroup() error = %v", err)
+	}
+
+	// user 8 is removed and user 9 does not exist
+	added, err := s.AddMembers(ctx, groupID, append(userIDs, 9))
+	if err != nil {
+		t.Fatalf("AddMembers() error = %v", err)
+	}
+	if want := userIDs[:7]; !reflect.DeepEqual(added, want) {
+		t.Errorf("added = %v, want %v", added, want)
+	}
+
+	members, err := s.ListMembers(ctx, groupID, 3, 2)
+	if err != nil {
+		t.Fatalf("ListMembers() error = %v", err)
+	}
+	var got []uint64
+	for _, u := range members {
+		got = append(got, u.ID_user)
+	}
+	if want := []uint64{3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("members = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,98 @@
+package keyrotation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cmd/main.go/internal/config"
+	"cmd/main.go/internal/logger"
+	"cmd/main.go/internal/repo"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+const rotationRunLogTag = "KeyRotation.Run()"
+
+const defaultRotationInterval = time.Hour
+
+// ServiceInterface is a interface for rotation of encryption keys of user emails
+type ServiceInterface interface {
+	Rotate(ctx context.Context) error
+}
+
+type service struct {
+	rotators  []repo.EmailKeyRotator
+	batchSize uint64
+}
+
+// New is a function to create a new service, every rotator is walked by each rotation,
+// e.g. repositories of all shards
+func New(cfg config.Encryption, rotators ...repo.EmailKeyRotator) ServiceInterface {
+	if cfg.RotationBatchSize == 0 {
+		cfg.RotationBatchSize = 500
+	}
+
+	return service{
+		rotators:  rotators,
+		batchSize: cfg.RotationBatchSize,
+	}
+}
+
+// Rotate - walk over all users in batches and re-seal emails that are not sealed by the current key,
+// rows are rewritten one by one, so rotation can be interrupted and repeated at any moment
+func (s service) Rotate(ctx context.Context) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.RotateEmailKeys")
+	defer span.Finish()
+
+	startedAt := time.Now()
+
+	var rotated int64
+	for _, rotator := range s.rotators {
+		var afterID uint64
+		for {
+			lastID, n, err := rotator.RotateEmailKeys(ctx, afterID, s.batchSize)
+			if err != nil {
+				return errors.Wrap(err, "rotator.RotateEmailKeys")
+			}
+			rotated += n
+
+			if lastID == 0 {
+				break
+			}
+			afterID = lastID
+		}
+	}
+
+	logger.InfoKV(ctx, fmt.Sprintf("%s: rotation finished", rotationRunLogTag),
+		"rotated", rotated,
+		"duration", time.Since(startedAt).String(),
+	)
+
+	return nil
+}
+
+// RunRotation - run rotations periodically until context is done
+func RunRotation(ctx context.Context, s ServiceInterface, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultRotationInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Rotate(ctx); err != nil {
+			logger.ErrorKV(ctx, fmt.Sprintf("%s: rotation failed", rotationRunLogTag),
+				"err", err,
+			)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
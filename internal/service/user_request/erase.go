@@ -0,0 +1,123 @@
+package user_request
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+// tables whose rows are counted in erasure receipt
+const (
+	erasedUsersTable      = "users"
+	erasedMembersTable    = "group_members"
+	erasedEventsTable     = "users_events"
+	erasedCandidatesTable = "users_duplicate_candidates"
+)
+
+// ErrUserRequestAlreadyErased is a "personal data of user was already erased" error
+var ErrUserRequestAlreadyErased = errors.New("user request is already erased")
+
+// EraseUserRequest - irreversibly erase personal data of user in one transaction:
+// the user and users merged into it get tombstone values, their group memberships and duplicate candidates
+// are removed and personal keys are dropped from their event payloads.
+// Receipt and erasure event, which consumers use to scrub their copies, are written in the same transaction
+func (s service) EraseUserRequest(ctx context.Context, userRequestID uint64, reason string) (*model.ErasureReceipt, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.EraseUserRequest")
+	defer span.Finish()
+
+	var receipt *model.ErasureReceipt
+	_, txErr := database.WithTx(ctx, s.transactor, func(ctx context.Context) (bool, error) {
+		users, err := s.requestRepository.GetUserByIdForUpdateRequest(ctx, []uint64{userRequestID})
+		if err != nil {
+			return false, errors.Wrap(err, "repository.GetUserByIdForUpdateRequest")
+		}
+		if len(users) == 0 {
+			return false, errors.Wrap(ErrNoExistsUserRequest, fmt.Sprintf("user %d", userRequestID))
+		}
+		if users[0].ErasedAt.Valid {
+			return false, ErrUserRequestAlreadyErased
+		}
+
+		IDs, err := s.withMergedUsers(ctx, userRequestID)
+		if err != nil {
+			return false, err
+		}
+
+		rows := make(map[string]int64, 4)
+
+		if rows[erasedUsersTable], err = s.requestRepository.EraseUserRequest(ctx, IDs); err != nil {
+			return false, errors.Wrap(err, "repository.EraseUserRequest")
+		}
+		if rows[erasedMembersTable], err = s.groupRepository.RemoveUserMemberships(ctx, IDs); err != nil {
+			return false, errors.Wrap(err, "groupRepository.RemoveUserMemberships")
+		}
+		if rows[erasedEventsTable], err = s.eventRepository.ScrubPayloads(ctx, IDs, model.PersonalPayloadKeys); err != nil {
+			return false, errors.Wrap(err, "eventRepository.ScrubPayloads")
+		}
+		if rows[erasedCandidatesTable], err = s.erasureRepository.RemoveDuplicateCandidates(ctx, IDs); err != nil {
+			return false, errors.Wrap(err, "erasureRepository.RemoveDuplicateCandidates")
+		}
+
+		receipt = &model.ErasureReceipt{
+			UserID:   userRequestID,
+			Reason:   reason,
+			Summary:  model.ErasureSummary{Users: IDs, Rows: rows},
+			ErasedAt: time.Now(),
+		}
+		if receipt.ID, err = s.erasureRepository.AddReceipt(ctx, receipt); err != nil {
+			return false, errors.Wrap(err, "erasureRepository.AddReceipt")
+		}
+
+		event := model.UserEvent{
+			UserID: userRequestID,
+			Type:   model.UserErased,
+			Payload: model.EventPayload{
+				"erased":  IDs,
+				"receipt": receipt.ID,
+			},
+		}
+		if err = s.eventRepository.Add(ctx, []model.UserEvent{event}); err != nil {
+			return false, errors.Wrap(err, "eventRepository.Add")
+		}
+		database.AfterCommit(ctx, func() { usersErased.Add(float64(len(IDs))) })
+
+		return true, nil
+	}, database.WithRetries(mergeRetries, mergeRetryBackoff))
+
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return receipt, nil
+}
+
+// withMergedUsers returns id of user followed by ids of users merged into it directly or through other merged users
+func (s service) withMergedUsers(ctx context.Context, userRequestID uint64) ([]uint64, error) {
+	IDs := []uint64{userRequestID}
+	seen := map[uint64]struct{}{userRequestID: {}}
+
+	for survivors := IDs; len(survivors) > 0; {
+		merged, err := s.requestRepository.ListMergedIntoRequest(ctx, survivors)
+		if err != nil {
+			return nil, errors.Wrap(err, "repository.ListMergedIntoRequest")
+		}
+
+		survivors = nil
+		for _, id := range merged {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			IDs = append(IDs, id)
+			survivors = append(survivors, id)
+		}
+	}
+
+	return IDs, nil
+}
@@ -0,0 +1,107 @@
+package user_request
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/repo/memory"
+)
+
+// newMergedTestService returns test service where user 2 is merged into user 1,
+// user 2 and user 4 have events with personal data
+func newMergedTestService(t *testing.T) testService {
+	t.Helper()
+	ctx := context.Background()
+
+	s := newTestService(t, false)
+	if _, err := s.MergeUsersRequest(ctx, 1, []uint64{2}, model.MergePolicies{}); err != nil {
+		t.Fatalf("MergeUsersRequest() error = %v", err)
+	}
+
+	events := []model.UserEvent{
+		{UserID: 2, Type: "updated", Payload: model.EventPayload{"email": "two@example.com", "source": "import"}},
+		{UserID: 4, Type: "updated", Payload: model.EventPayload{"name": "four"}},
+	}
+	if err := s.events.Add(ctx, events); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	return s
+}
+
+func TestEraseUserRequest(t *testing.T) {
+	ctx := context.Background()
+	s := newMergedTestService(t)
+
+	receipt, err := s.EraseUserRequest(ctx, 1, "request of user")
+	if err != nil {
+		t.Fatalf("EraseUserRequest() error = %v", err)
+	}
+	if want := []uint64{1, 2}; !reflect.DeepEqual(receipt.Summary.Users, want) {
+		t.Errorf("EraseUserRequest() users = %v, want %v", receipt.Summary.Users, want)
+	}
+	wantRows := map[string]int64{
+		erasedUsersTable:      2,
+		erasedMembersTable:    1,
+		erasedEventsTable:     1,
+		erasedCandidatesTable: 0,
+	}
+	if !reflect.DeepEqual(receipt.Summary.Rows, wantRows) {
+		t.Errorf("EraseUserRequest() rows = %v, want %v", receipt.Summary.Rows, wantRows)
+	}
+
+	users, err := s.users.GetUserByIdRequest(ctx, []uint64{1, 2, 4})
+	if err != nil {
+		t.Fatalf("GetUserByIdRequest() error = %v", err)
+	}
+	for _, u := range users {
+		if erased := u.ID_user != 4; u.ErasedAt.Valid != erased || erased && (u.Name != "" || u.Email != "") {
+			t.Errorf("user %d erased at = %v, name = %q, email = %q", u.ID_user, u.ErasedAt, u.Name, u.Email)
+		}
+	}
+	if got := s.members(t); !reflect.DeepEqual(got, []uint64{3}) {
+		t.Errorf("members = %v after erasure, want [3]", got)
+	}
+
+	if _, err = s.EraseUserRequest(ctx, 1, "again"); !errors.Is(err, ErrUserRequestAlreadyErased) {
+		t.Errorf("EraseUserRequest() of erased user error = %v, want %v", err, ErrUserRequestAlreadyErased)
+	}
+	if _, err = s.EraseUserRequest(ctx, 42, "unknown"); !errors.Is(err, ErrNoExistsUserRequest) {
+		t.Errorf("EraseUserRequest() of unknown user error = %v, want %v", err, ErrNoExistsUserRequest)
+	}
+}
+
+func TestEraseUserRequestRollback(t *testing.T) {
+	ctx := context.Background()
+	s := newMergedTestService(t)
+
+	failing := New(s.store.Transactor(), s.users, s.groups, failingEvents{Repo: s.events}, memory.NewErasureRepo(s.store), nil)
+	if _, err := failing.EraseUserRequest(ctx, 1, "request of user"); !errors.Is(err, errEventsDown) {
+		t.Fatalf("EraseUserRequest() error = %v, want %v", err, errEventsDown)
+	}
+
+	users, err := s.users.GetUserByIdRequest(ctx, []uint64{1, 2})
+	if err != nil {
+		t.Fatalf("GetUserByIdRequest() error = %v", err)
+	}
+	for _, u := range users {
+		if u.ErasedAt.Valid || u.Name == "" {
+			t.Errorf("user %d is erased after rollback", u.ID_user)
+		}
+	}
+	if got := s.members(t); !reflect.DeepEqual(got, []uint64{1, 3}) {
+		t.Errorf("members = %v after rollback, want [1 3]", got)
+	}
+
+	// payloads scrubbed by the failed erasure are restored, so they are scrubbed again
+	receipt, err := s.EraseUserRequest(ctx, 1, "request of user")
+	if err != nil {
+		t.Fatalf("EraseUserRequest() error = %v", err)
+	}
+	if got := receipt.Summary.Rows[erasedEventsTable]; got != 1 {
+		t.Errorf("EraseUserRequest() scrubbed events = %d, want 1", got)
+	}
+}
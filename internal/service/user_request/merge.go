@@ -0,0 +1,185 @@
+package user_request
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/pkg/errors"
+)
+
+const (
+	// concurrent merges sharing users may deadlock on row locks, such transactions are rerun
+	mergeRetries      = 3
+	mergeRetryBackoff = 20 * time.Millisecond
+)
+
+// ErrMergeSurvivorIsLoser is a "survivor can not be merged into itself" error
+var ErrMergeSurvivorIsLoser = errors.New("survivor can not be in list of merged users")
+
+// ErrNoMergedUserRequest is a "unable to merge users" error
+var ErrNoMergedUserRequest = errors.New("unable to merge users")
+
+// MergeUsersRequest - merge losers into survivor in one transaction:
+// fields are resolved by policies, losers are soft deleted with pointer to survivor,
+// their group memberships are moved to survivor and merge event is written
+func (s service) MergeUsersRequest(ctx context.Context, survivorID uint64, loserIDs []uint64, policies model.MergePolicies) (*model.UserRequest, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.MergeUsersRequest")
+	defer span.Finish()
+
+	loserIDs, err := uniqueLosers(survivorID, loserIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged *model.UserRequest
+	_, txErr := database.WithTx(ctx, s.transactor, func(ctx context.Context) (bool, error) {
+		IDs := append([]uint64{survivorID}, loserIDs...)
+		users, err := s.requestRepository.GetUserByIdForUpdateRequest(ctx, IDs)
+		if err != nil {
+			return false, errors.Wrap(err, "repository.GetUserByIdForUpdateRequest")
+		}
+
+		byID := make(map[uint64]model.UserRequest, len(users))
+		for _, u := range users {
+			if !u.DeletedAt.Valid {
+				byID[u.ID_user] = u
+			}
+		}
+
+		survivor, ok := byID[survivorID]
+		if !ok {
+			return false, errors.Wrap(ErrNoExistsUserRequest, fmt.Sprintf("survivor %d", survivorID))
+		}
+
+		losers := make([]model.UserRequest, 0, len(loserIDs))
+		for _, id := range loserIDs {
+			loser, ok := byID[id]
+			if !ok {
+				return false, errors.Wrap(ErrNoExistsUserRequest, fmt.Sprintf("merged user %d", id))
+			}
+			losers = append(losers, loser)
+		}
+
+		merged = resolveMerge(survivor, losers, policies)
+
+		saved, err := s.requestRepository.SaveMergedUserRequest(ctx, merged)
+		if err != nil {
+			return false, errors.Wrap(err, "repository.SaveMergedUserRequest")
+		}
+		if !saved {
+			return false, ErrNoMergedUserRequest
+		}
+
+		affected, err := s.requestRepository.MarkMergedUserRequest(ctx, survivorID, loserIDs)
+		if err != nil {
+			return false, errors.Wrap(err, "repository.MarkMergedUserRequest")
+		}
+		if affected != int64(len(loserIDs)) {
+			return false, ErrNoMergedUserRequest
+		}
+
+		if err = s.groupRepository.MoveUserMemberships(ctx, loserIDs, survivorID); err != nil {
+			return false, errors.Wrap(err, "groupRepository.MoveUserMemberships")
+		}
+		database.AfterCommit(ctx, func() { usersMerged.Add(float64(len(loserIDs))) })
+
+		event := model.UserEvent{
+			UserID: survivorID,
+			Type:   model.UserMerged,
+			Payload: model.EventPayload{
+				"survivor": survivorID,
+				"merged":   loserIDs,
+			},
+		}
+		if err = s.eventRepository.Add(ctx, []model.UserEvent{event}); err != nil {
+			return false, errors.Wrap(err, "eventRepository.Add")
+		}
+
+		return true, nil
+	}, database.WithRetries(mergeRetries, mergeRetryBackoff))
+
+	if txErr != nil {
+		return nil, txErr
+	}
+
+	return merged, nil
+}
+
+// uniqueLosers - drop repeated loser ids, so every loser is counted once
+func uniqueLosers(survivorID uint64, loserIDs []uint64) ([]uint64, error) {
+	seen := make(map[uint64]struct{}, len(loserIDs))
+	unique := make([]uint64, 0, len(loserIDs))
+	for _, id := range loserIDs {
+		if id == survivorID {
+			return nil, ErrMergeSurvivorIsLoser
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+
+	return unique, nil
+}
+
+// resolveMerge - build survivor with fields chosen by policies
+func resolveMerge(survivor model.UserRequest, losers []model.UserRequest, policies model.MergePolicies) *model.UserRequest {
+	newestFirst := make([]model.UserRequest, 0, len(losers)+1)
+	newestFirst = append(newestFirst, survivor)
+	newestFirst = append(newestFirst, losers...)
+	// survivor goes first, so it wins when update times are equal
+	sort.SliceStable(newestFirst, func(i, j int) bool {
+		return lastModified(newestFirst[i]).After(lastModified(newestFirst[j]))
+	})
+
+	result := survivor
+	result.Name = pick(policies.Name, newestFirst, survivor.Name,
+		func(u model.UserRequest) string { return u.Name },
+		func(v string) bool { return v == "" })
+	result.Email = pick(policies.Email, newestFirst, survivor.Email,
+		func(u model.UserRequest) string { return u.Email },
+		func(v string) bool { return v == "" })
+	result.Labels = pick(policies.Labels, newestFirst, survivor.Labels,
+		func(u model.UserRequest) model.Labels { return u.Labels },
+		func(v model.Labels) bool { return len(v) == 0 })
+	result.Profile = pick(policies.Profile, newestFirst, survivor.Profile,
+		func(u model.UserRequest) model.Profile { return u.Profile },
+		func(v model.Profile) bool { return len(v) == 0 })
+
+	return &result
+}
+
+func pick[T any](policy model.MergePolicy, newestFirst []model.UserRequest, survivorValue T, get func(model.UserRequest) T, isEmpty func(T) bool) T {
+	switch policy {
+	case model.MergeKeepNewest:
+		return get(newestFirst[0])
+	case model.MergeKeepNonEmpty:
+		if !isEmpty(survivorValue) {
+			return survivorValue
+		}
+		for _, u := range newestFirst {
+			if v := get(u); !isEmpty(v) {
+				return v
+			}
+		}
+
+		return survivorValue
+	default:
+		return survivorValue
+	}
+}
+
+func lastModified(u model.UserRequest) time.Time {
+	if u.UpdatedAt.Valid {
+		return u.UpdatedAt.Time
+	}
+
+	return u.CreatedAt
+}
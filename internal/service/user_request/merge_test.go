@@ -0,0 +1,221 @@
+package user_request
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"cmd/main.go/internal/database"
+	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/repo"
+	eventrepo "cmd/main.go/internal/repo/event"
+	grouprepo "cmd/main.go/internal/repo/group"
+	"cmd/main.go/internal/repo/memory"
+)
+
+var errEventsDown = errors.New("events are down")
+
+// failingEvents fails every Add, so the transaction of service is rolled back at its last step
+type failingEvents struct {
+	eventrepo.Repo
+}
+
+func (failingEvents) Add(context.Context, []model.UserEvent) error {
+	return errEventsDown
+}
+
+// recordedEvents keeps events added by committed transactions
+type recordedEvents struct {
+	eventrepo.Repo
+	events []model.UserEvent
+}
+
+func (r *recordedEvents) Add(ctx context.Context, events []model.UserEvent) error {
+	if err := r.Repo.Add(ctx, events); err != nil {
+		return err
+	}
+	database.AfterCommit(ctx, func() { r.events = append(r.events, events...) })
+
+	return nil
+}
+
+type testService struct {
+	ServiceInterface
+	store  *memory.Store
+	users  repo.UserRequestRepo
+	groups grouprepo.Repo
+	events *recordedEvents
+	group  uint64
+}
+
+// newTestService returns service over in-memory store with users 1..4, users 2 and 3 are members of a group
+func newTestService(t *testing.T, failEvents bool) testService {
+	t.Helper()
+	ctx := context.Background()
+
+	store := memory.NewStore()
+	users := memory.NewUserRequestRepo(store)
+	groups := memory.NewGroupRepo(store)
+	recorded := &recordedEvents{Repo: memory.NewEventRepo(store)}
+	var events eventrepo.Repo = recorded
+	if failEvents {
+		events = failingEvents{Repo: events}
+	}
+
+	created := time.Now().Add(-time.Hour)
+	for id := uint64(1); id <= 4; id++ {
+		user := &model.UserRequest{ID_user: id, Name: "user", CreatedAt: created}
+		if id == 3 {
+			user.Email = "three@example.com"
+		}
+		if _, err := users.CreateUserRequest(ctx, user); err != nil {
+			t.Fatalf("CreateUserRequest() error = %v", err)
+		}
+	}
+	groupID, err := groups.CreateGroup(ctx, &model.Group{Name: "group"})
+	if err != nil {
+		t.Fatalf("CreateGroup() error = %v", err)
+	}
+	if _, err = groups.AddMembers(ctx, groupID, []uint64{2, 3}); err != nil {
+		t.Fatalf("AddMembers() error = %v", err)
+	}
+
+	return testService{
+		ServiceInterface: New(store.Transactor(), users, groups, events, memory.NewErasureRepo(store), nil),
+		store:            store,
+		users:            users,
+		groups:           groups,
+		events:           recorded,
+		group:            groupID,
+	}
+}
+
+func (s testService) members(t *testing.T) []uint64 {
+	t.Helper()
+
+	members, err := s.groups.ListMembers(context.Background(), s.group, 10, 0)
+	if err != nil {
+		t.Fatalf("ListMembers() error = %v", err)
+	}
+
+	return members
+}
+
+func TestMergeUsersRequest(t *testing.T) {
+	policies := model.MergePolicies{Email: model.MergeKeepNonEmpty}
+
+	tests := []struct {
+		name      string
+		survivor  uint64
+		losers    []uint64
+		wantErr   error
+		wantEmail string
+	}{
+		{
+			name:      "merged",
+			survivor:  1,
+			losers:    []uint64{2, 3},
+			wantEmail: "three@example.com",
+		},
+		{
+			name:      "repeated losers are merged once",
+			survivor:  1,
+			losers:    []uint64{3, 2, 3, 2},
+			wantEmail: "three@example.com",
+		},
+		{
+			name:     "survivor in losers",
+			survivor: 1,
+			losers:   []uint64{2, 1},
+			wantErr:  ErrMergeSurvivorIsLoser,
+		},
+		{
+			name:     "unknown loser",
+			survivor: 1,
+			losers:   []uint64{2, 42},
+			wantErr:  ErrNoExistsUserRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := newTestService(t, false)
+
+			merged, err := s.MergeUsersRequest(ctx, tt.survivor, tt.losers, policies)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MergeUsersRequest() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if merged.Email != tt.wantEmail {
+				t.Errorf("MergeUsersRequest() email = %q, want %q", merged.Email, tt.wantEmail)
+			}
+
+			losers, err := s.users.GetUserByIdRequest(ctx, []uint64{2, 3})
+			if err != nil {
+				t.Fatalf("GetUserByIdRequest() error = %v", err)
+			}
+			for _, loser := range losers {
+				if !loser.DeletedAt.Valid || loser.MergedInto.Int64 != int64(tt.survivor) {
+					t.Errorf("loser %d deleted = %v, merged into %v", loser.ID_user, loser.DeletedAt.Valid, loser.MergedInto)
+				}
+			}
+			if got := s.members(t); !reflect.DeepEqual(got, []uint64{tt.survivor}) {
+				t.Errorf("members = %v, want %v", got, []uint64{tt.survivor})
+			}
+
+			// merge event is kept after erasure of merged users, so it holds ids only
+			if len(s.events.events) != 1 {
+				t.Fatalf("events = %v, want merge event", s.events.events)
+			}
+			for _, key := range model.PersonalPayloadKeys {
+				if _, ok := s.events.events[0].Payload[key]; ok {
+					t.Errorf("merge event payload has personal key %q", key)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeUsersRequestRollback(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t, true)
+
+	if _, err := s.MergeUsersRequest(ctx, 1, []uint64{2, 3}, model.MergePolicies{Email: model.MergeKeepNonEmpty}); !errors.Is(err, errEventsDown) {
+		t.Fatalf("MergeUsersRequest() error = %v, want %v", err, errEventsDown)
+	}
+
+	users, err := s.users.GetUserByIdRequest(ctx, []uint64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("GetUserByIdRequest() error = %v", err)
+	}
+	for _, u := range users {
+		if u.DeletedAt.Valid || u.MergedInto.Valid {
+			t.Errorf("user %d is merged after rollback", u.ID_user)
+		}
+		if u.ID_user == 1 && u.Email != "" {
+			t.Errorf("survivor email = %q after rollback, want empty", u.Email)
+		}
+	}
+	if got := s.members(t); !reflect.DeepEqual(got, []uint64{2, 3}) {
+		t.Errorf("members = %v after rollback, want [2 3]", got)
+	}
+}
+
+func TestUniqueLosers(t *testing.T) {
+	got, err := uniqueLosers(1, []uint64{3, 2, 3, 2, 4})
+	if err != nil {
+		t.Fatalf("uniqueLosers() error = %v", err)
+	}
+	if want := []uint64{3, 2, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("uniqueLosers() = %v, want %v", got, want)
+	}
+
+	if _, err = uniqueLosers(1, []uint64{2, 1}); !errors.Is(err, ErrMergeSurvivorIsLoser) {
+		t.Errorf("uniqueLosers() error = %v, want %v", err, ErrMergeSurvivorIsLoser)
+	}
+}
@@ -0,0 +1,30 @@
+package user_request
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// business counters are incremented after commit, so rolled back changes are not counted
+var (
+	usersCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "users_created_total",
+		Help: "The total number of created users",
+	})
+	usersRemoved = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "users_removed_total",
+		Help: "The total number of users in committed remove requests, already removed ones included",
+	})
+	usersRestored = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "users_restored_total",
+		Help: "The total number of removed users restored",
+	})
+	usersMerged = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "users_merged_total",
+		Help: "The total number of users merged into survivors",
+	})
+	usersErased = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "users_erased_total",
+		Help: "The total number of users whose personal data was erased, merged users included",
+	})
+)
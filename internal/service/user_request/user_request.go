@@ -5,33 +5,50 @@ import (
 
 	"cmd/main.go/internal/database"
 	"cmd/main.go/internal/model"
+	"cmd/main.go/internal/pkg/labels"
+	"cmd/main.go/internal/pkg/profile"
 	"cmd/main.go/internal/repo"
+	erasurerepo "cmd/main.go/internal/repo/erasure"
+	eventrepo "cmd/main.go/internal/repo/event"
+	grouprepo "cmd/main.go/internal/repo/group"
 
-	"github.com/jmoiron/sqlx"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 )
 
 type service struct {
-	db                *sqlx.DB
+	transactor        database.Transactor
 	requestRepository repo.UserRequestRepo
+	groupRepository   grouprepo.Repo
+	eventRepository   eventrepo.Repo
+	erasureRepository erasurerepo.Repo
+	profileValidator  *profile.Validator
 }
 
 // ServiceInterface is a interface for User request service
 type ServiceInterface interface {
 	CreateUserRequest(ctx context.Context, userRequest *model.UserRequest) (uint64, error)
 	GetUserByIdRequest(ctx context.Context, IDs []uint64) ([]model.UserRequest, error)
-	ListUserRequest(ctx context.Context, limit uint64, offset uint64) ([]model.UserRequest, error)
+	ListUserRequest(ctx context.Context, limit uint64, offset uint64, selector labels.Selector) ([]model.UserRequest, error)
 	RemoveUserRequest(ctx context.Context, IDs []uint64) (bool, error)
+	RestoreUserRequest(ctx context.Context, IDs []uint64) (int64, error)
 	CheckExistsUserRequest(ctx context.Context, ID uint64) (bool, error)
 	UpdateUserByIdRequest(ctx context.Context, userRequestID uint64, name, email string) (bool, error)
+	UpdateUserLabelsRequest(ctx context.Context, userRequestID uint64, set model.Labels, removeKeys []string) (bool, error)
+	UpdateUserProfileRequest(ctx context.Context, userRequestID uint64, userProfile model.Profile) (bool, error)
+	MergeUsersRequest(ctx context.Context, survivorID uint64, loserIDs []uint64, policies model.MergePolicies) (*model.UserRequest, error)
+	EraseUserRequest(ctx context.Context, userRequestID uint64, reason string) (*model.ErasureReceipt, error)
 }
 
 // New is a function to create a new service
-func New(db *sqlx.DB, requestRepository repo.UserRequestRepo) ServiceInterface {
+func New(transactor database.Transactor, requestRepository repo.UserRequestRepo, groupRepository grouprepo.Repo, eventRepository eventrepo.Repo, erasureRepository erasurerepo.Repo, profileValidator *profile.Validator) ServiceInterface {
 	return service{
-		db:                db,
+		transactor:        transactor,
 		requestRepository: requestRepository,
+		groupRepository:   groupRepository,
+		eventRepository:   eventRepository,
+		erasureRepository: erasureRepository,
+		profileValidator:  profileValidator,
 	}
 }
 
@@ -50,13 +67,22 @@ var ErrNoRemovedUserRequest = errors.New("unable to remove user request")
 // ErrNoUpdatedUserIDUserRequest is a "unable to update User id of User request" error
 var ErrNoUpdatedUserIDUserRequest = errors.New("unable to update user of user request")
 
+// ErrNoUpdatedLabelsUserRequest is a "unable to update labels of User request" error
+var ErrNoUpdatedLabelsUserRequest = errors.New("unable to update labels of user request")
+
+// ErrNoUpdatedProfileUserRequest is a "unable to update profile of User request" error
+var ErrNoUpdatedProfileUserRequest = errors.New("unable to update profile of user request")
+
 func (s service) CreateUserRequest(ctx context.Context, userRequest *model.UserRequest) (uint64, error) {
+	if err := s.profileValidator.Validate(userRequest.Profile); err != nil {
+		return 0, err
+	}
 
-	createdRequestID, txErr := database.WithTxReturnUint64(ctx, s.db, func(ctx context.Context, tx *sqlx.Tx) (uint64, error) {
+	createdRequestID, txErr := database.WithTx(ctx, s.transactor, func(ctx context.Context) (uint64, error) {
 		span, ctx := opentracing.StartSpanFromContext(ctx, "service.CreateUserRequest")
 		defer span.Finish()
 
-		id, err := s.requestRepository.CreateUserRequest(ctx, userRequest, tx)
+		id, err := s.requestRepository.CreateUserRequest(ctx, userRequest)
 		if err != nil {
 			return 0, errors.Wrap(err, "requestRepository.CreateUserRequest")
 		}
@@ -65,9 +91,10 @@ func (s service) CreateUserRequest(ctx context.Context, userRequest *model.UserR
 			return 0, ErrNoCreatedUserRequest
 		}
 		userRequest.ID_user = id
+		database.AfterCommit(ctx, usersCreated.Inc)
 
 		return id, nil
-	})
+	}, database.RetryOnConflict())
 
 	if txErr != nil {
 		return createdRequestID, txErr
@@ -106,10 +133,10 @@ func (s service) CheckExistsUserRequest(ctx context.Context, ID uint64) (bool, e
 	return exists, nil
 }
 
-func (s service) ListUserRequest(ctx context.Context, limit uint64, offset uint64) ([]model.UserRequest, error) {
+func (s service) ListUserRequest(ctx context.Context, limit uint64, offset uint64, selector labels.Selector) ([]model.UserRequest, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "service.ListUserRequest")
 	defer span.Finish()
-	userRequests, err := s.requestRepository.ListUserRequest(ctx, limit, offset)
+	userRequests, err := s.requestRepository.ListUserRequest(ctx, limit, offset, selector)
 	if err != nil {
 		return nil, errors.Wrap(err, "repository.ListUserRequest")
 	}
@@ -124,8 +151,8 @@ func (s service) ListUserRequest(ctx context.Context, limit uint64, offset uint6
 func (s service) RemoveUserRequest(ctx context.Context, IDs []uint64) (bool, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "service.RemoveUserRequest")
 	defer span.Finish()
-	deleted, txErr := database.WithTxReturnBool(ctx, s.db, func(ctx context.Context, tx *sqlx.Tx) (bool, error) {
-		result, err := s.requestRepository.RemoveUserRequest(ctx, IDs, tx)
+	deleted, txErr := database.WithTx(ctx, s.transactor, func(ctx context.Context) (bool, error) {
+		result, err := s.requestRepository.RemoveUserRequest(ctx, IDs)
 		if err != nil {
 			return false, errors.Wrap(err, "repository.RemoveUserRequest")
 		}
@@ -134,8 +161,13 @@ func (s service) RemoveUserRequest(ctx context.Context, IDs []uint64) (bool, err
 			return false, ErrNoRemovedUserRequest
 		}
 
+		if _, err = s.groupRepository.RemoveUserMemberships(ctx, IDs); err != nil {
+			return false, errors.Wrap(err, "groupRepository.RemoveUserMemberships")
+		}
+		database.AfterCommit(ctx, func() { usersRemoved.Add(float64(len(IDs))) })
+
 		return result, nil
-	})
+	}, database.RetryOnConflict())
 
 	if txErr != nil {
 		return deleted, txErr
@@ -144,11 +176,28 @@ func (s service) RemoveUserRequest(ctx context.Context, IDs []uint64) (bool, err
 	return deleted, nil
 }
 
+// RestoreUserRequest - restore removed users and return number of restored ones, merged and erased users
+// stay removed. Memberships dropped by removal are not restored
+func (s service) RestoreUserRequest(ctx context.Context, IDs []uint64) (int64, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.RestoreUserRequest")
+	defer span.Finish()
+
+	return database.WithTx(ctx, s.transactor, func(ctx context.Context) (int64, error) {
+		restored, err := s.requestRepository.RestoreUserRequest(ctx, IDs)
+		if err != nil {
+			return 0, errors.Wrap(err, "repository.RestoreUserRequest")
+		}
+		database.AfterCommit(ctx, func() { usersRestored.Add(float64(restored)) })
+
+		return restored, nil
+	}, database.RetryOnConflict())
+}
+
 func (s service) UpdateUserByIdRequest(ctx context.Context, userRequestID uint64, name, email string) (bool, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "service.UpdateUserByIdRequest")
 	defer span.Finish()
-	updated, txErr := database.WithTxReturnBool(ctx, s.db, func(ctx context.Context, tx *sqlx.Tx) (bool, error) {
-		result, err := s.requestRepository.UpdateUserByIdRequest(ctx, userRequestID, name, email, tx)
+	updated, txErr := database.WithTx(ctx, s.transactor, func(ctx context.Context) (bool, error) {
+		result, err := s.requestRepository.UpdateUserByIdRequest(ctx, userRequestID, name, email)
 		if err != nil {
 			return false, errors.Wrap(err, "repository.UpdateUserByIdRequest")
 		}
@@ -158,7 +207,57 @@ func (s service) UpdateUserByIdRequest(ctx context.Context, userRequestID uint64
 		}
 
 		return result, nil
-	})
+	}, database.RetryOnConflict())
+
+	if txErr != nil {
+		return updated, txErr
+	}
+
+	return updated, nil
+}
+
+func (s service) UpdateUserLabelsRequest(ctx context.Context, userRequestID uint64, set model.Labels, removeKeys []string) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.UpdateUserLabelsRequest")
+	defer span.Finish()
+	updated, txErr := database.WithTx(ctx, s.transactor, func(ctx context.Context) (bool, error) {
+		result, err := s.requestRepository.UpdateUserLabelsRequest(ctx, userRequestID, set, removeKeys)
+		if err != nil {
+			return false, errors.Wrap(err, "repository.UpdateUserLabelsRequest")
+		}
+
+		if !result {
+			return false, ErrNoUpdatedLabelsUserRequest
+		}
+
+		return result, nil
+	}, database.RetryOnConflict())
+
+	if txErr != nil {
+		return updated, txErr
+	}
+
+	return updated, nil
+}
+
+func (s service) UpdateUserProfileRequest(ctx context.Context, userRequestID uint64, userProfile model.Profile) (bool, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "service.UpdateUserProfileRequest")
+	defer span.Finish()
+	if err := s.profileValidator.Validate(userProfile); err != nil {
+		return false, err
+	}
+
+	updated, txErr := database.WithTx(ctx, s.transactor, func(ctx context.Context) (bool, error) {
+		result, err := s.requestRepository.UpdateUserProfileRequest(ctx, userRequestID, userProfile)
+		if err != nil {
+			return false, errors.Wrap(err, "repository.UpdateUserProfileRequest")
+		}
+
+		if !result {
+			return false, ErrNoUpdatedProfileUserRequest
+		}
+
+		return result, nil
+	}, database.RetryOnConflict())
 
 	if txErr != nil {
 		return updated, txErr
@@ -0,0 +1,59 @@
+package user_request
+
+import (
+	"context"
+	"testing"
+
+	"cmd/main.go/internal/model"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func counterValue(t *testing.T, c prometheus.Counter) float64 {
+	t.Helper()
+
+	var m dto.Metric
+	if err := c.Write(&m); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	return m.GetCounter().GetValue()
+}
+
+func TestRestoreUserRequest(t *testing.T) {
+	ctx := context.Background()
+	s := newTestService(t, false)
+
+	if _, err := s.RemoveUserRequest(ctx, []uint64{2, 3}); err != nil {
+		t.Fatalf("RemoveUserRequest() error = %v", err)
+	}
+	if _, err := s.MergeUsersRequest(ctx, 1, []uint64{4}, model.MergePolicies{}); err != nil {
+		t.Fatalf("MergeUsersRequest() error = %v", err)
+	}
+
+	before := counterValue(t, usersRestored)
+	restored, err := s.RestoreUserRequest(ctx, []uint64{2, 3, 4, 42})
+	if err != nil {
+		t.Fatalf("RestoreUserRequest() error = %v", err)
+	}
+	if restored != 2 {
+		t.Errorf("RestoreUserRequest() = %d, want 2", restored)
+	}
+	if got := counterValue(t, usersRestored) - before; got != 2 {
+		t.Errorf("users_restored_total grew by %v, want 2", got)
+	}
+
+	for id, want := range map[uint64]bool{2: true, 3: true, 4: false} {
+		if exists, err := s.users.Exists(ctx, id); err != nil || exists != want {
+			t.Errorf("Exists(%d) = %v, %v, want %v", id, exists, err, want)
+		}
+	}
+	if got := s.members(t); len(got) != 0 {
+		t.Errorf("members = %v after restore, memberships dropped by removal stay dropped", got)
+	}
+
+	if restored, err = s.RestoreUserRequest(ctx, []uint64{2, 3}); err != nil || restored != 0 {
+		t.Errorf("RestoreUserRequest() of active users = %d, %v, want 0", restored, err)
+	}
+}
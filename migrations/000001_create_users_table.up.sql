@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS users
+(
+    id_user    BIGINT PRIMARY KEY,
+    name       TEXT        NOT NULL DEFAULT '',
+    email      TEXT        NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NULL,
+    deleted_at TIMESTAMPTZ NULL,
+    done_at    TIMESTAMPTZ NULL
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS users_labels_idx;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS labels;
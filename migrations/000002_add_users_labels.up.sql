@@ -0,0 +1,5 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS labels JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- jsonb_ops supports both containment (@>) and key existence (?) used by label selectors
+CREATE INDEX IF NOT EXISTS users_labels_idx ON users USING GIN (labels jsonb_ops);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS groups
+(
+    id_group    BIGSERIAL PRIMARY KEY,
+    name        TEXT        NOT NULL,
+    description TEXT        NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at  TIMESTAMPTZ NULL,
+    deleted_at  TIMESTAMPTZ NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS groups_name_uidx ON groups (name) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS group_members
+(
+    id_group   BIGINT      NOT NULL REFERENCES groups (id_group) ON DELETE CASCADE,
+    id_user    BIGINT      NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (id_group, id_user)
+);
+
+CREATE INDEX IF NOT EXISTS group_members_id_user_idx ON group_members (id_user);
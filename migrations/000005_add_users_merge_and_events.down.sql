@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS users_events;
+
+DROP INDEX IF EXISTS users_merged_into_idx;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS merged_into;
@@ -0,0 +1,15 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS merged_into BIGINT NULL;
+
+CREATE INDEX IF NOT EXISTS users_merged_into_idx ON users (merged_into) WHERE merged_into IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS users_events
+(
+    id         BIGSERIAL PRIMARY KEY,
+    id_user    BIGINT      NOT NULL,
+    type       TEXT        NOT NULL,
+    payload    JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS users_events_id_user_idx ON users_events (id_user);
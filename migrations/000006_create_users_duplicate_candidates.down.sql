@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS users_duplicate_candidates;
+
+DROP INDEX IF EXISTS users_name_trgm_idx;
+
+DROP INDEX IF EXISTS users_email_local_trgm_idx;
+
+DROP INDEX IF EXISTS users_email_normalized_idx;
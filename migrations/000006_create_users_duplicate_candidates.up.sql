@@ -0,0 +1,24 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- expressions below must match the ones used by duplicate analyzer queries
+CREATE INDEX IF NOT EXISTS users_email_normalized_idx
+    ON users ((regexp_replace(lower(btrim(email)), '\+[^@]*@', '@')));
+
+CREATE INDEX IF NOT EXISTS users_email_local_trgm_idx
+    ON users USING GIN ((split_part(lower(btrim(email)), '@', 1)) gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS users_name_trgm_idx
+    ON users USING GIN (name gin_trgm_ops);
+
+CREATE TABLE IF NOT EXISTS users_duplicate_candidates
+(
+    id_user_a   BIGINT           NOT NULL REFERENCES users (id_user) ON DELETE CASCADE,
+    id_user_b   BIGINT           NOT NULL REFERENCES users (id_user) ON DELETE CASCADE,
+    score       DOUBLE PRECISION NOT NULL,
+    signals     TEXT[]           NOT NULL DEFAULT '{}',
+    detected_at TIMESTAMPTZ      NOT NULL DEFAULT now(),
+    PRIMARY KEY (id_user_a, id_user_b),
+    CHECK (id_user_a < id_user_b)
+);
+
+CREATE INDEX IF NOT EXISTS users_duplicate_candidates_score_idx ON users_duplicate_candidates (score DESC);
@@ -0,0 +1,5 @@
+-- keyed hash of normalized email, written when emails are encrypted
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS email_bidx TEXT NULL;
+
+CREATE INDEX IF NOT EXISTS users_email_bidx_idx ON users (email_bidx) WHERE email_bidx IS NOT NULL;
@@ -0,0 +1,15 @@
+-- erased users keep their rows with tombstone values, so references to them stay valid
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS erased_at TIMESTAMPTZ NULL;
+
+-- users of sharded deployments are not stored in primary database, so id_user is not a foreign key
+CREATE TABLE IF NOT EXISTS users_erasure_receipts
+(
+    id        BIGSERIAL PRIMARY KEY,
+    id_user   BIGINT      NOT NULL,
+    reason    TEXT        NOT NULL DEFAULT '',
+    summary   JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    erased_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS users_erasure_receipts_id_user_idx ON users_erasure_receipts (id_user);
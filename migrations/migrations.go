@@ -0,0 +1,31 @@
+// Package migrations contains versioned SQL migrations embedded into binaries
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+
+	"cmd/main.go/internal/database"
+)
+
+// FS - Postgres migration files named as NNNNNN_name.up.sql and NNNNNN_name.down.sql
+//
+//go:embed *.sql
+var FS embed.FS
+
+// sqliteFS - SQLite migrations of the same versions, named after dialect
+//
+//go:embed sqlite/*.sql
+var sqliteFS embed.FS
+
+// For returns migrations of dialect
+func For(dialect *database.Dialect) fs.FS {
+	if dialect == database.SQLite {
+		// name of embedded directory is a valid path, so fs.Sub does not fail
+		sub, _ := fs.Sub(sqliteFS, dialect.Name())
+
+		return sub
+	}
+
+	return FS
+}
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS users
+(
+    id_user    BIGINT PRIMARY KEY,
+    name       TEXT      NOT NULL DEFAULT '',
+    email      TEXT      NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NULL,
+    deleted_at TIMESTAMP NULL,
+    done_at    TIMESTAMP NULL
+);
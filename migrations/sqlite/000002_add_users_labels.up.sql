@@ -0,0 +1,3 @@
+-- labels are JSON text, selectors scan them with json1 functions
+ALTER TABLE users
+    ADD COLUMN labels TEXT NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+ALTER TABLE users
+    ADD COLUMN profile TEXT NOT NULL DEFAULT '{}';
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS groups
+(
+    id_group    INTEGER PRIMARY KEY AUTOINCREMENT,
+    name        TEXT      NOT NULL,
+    description TEXT      NOT NULL DEFAULT '',
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  TIMESTAMP NULL,
+    deleted_at  TIMESTAMP NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS groups_name_uidx ON groups (name) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS group_members
+(
+    id_group   BIGINT    NOT NULL REFERENCES groups (id_group) ON DELETE CASCADE,
+    id_user    BIGINT    NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id_group, id_user)
+);
+
+CREATE INDEX IF NOT EXISTS group_members_id_user_idx ON group_members (id_user);
@@ -0,0 +1,15 @@
+ALTER TABLE users
+    ADD COLUMN merged_into BIGINT NULL;
+
+CREATE INDEX IF NOT EXISTS users_merged_into_idx ON users (merged_into) WHERE merged_into IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS users_events
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    id_user    BIGINT    NOT NULL,
+    type       TEXT      NOT NULL,
+    payload    TEXT      NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS users_events_id_user_idx ON users_events (id_user);
@@ -0,0 +1,13 @@
+-- duplicate analyzer needs pg_trgm and does not run on SQLite, the table keeps schemas of dialects alike
+CREATE TABLE IF NOT EXISTS users_duplicate_candidates
+(
+    id_user_a   BIGINT    NOT NULL REFERENCES users (id_user) ON DELETE CASCADE,
+    id_user_b   BIGINT    NOT NULL REFERENCES users (id_user) ON DELETE CASCADE,
+    score       REAL      NOT NULL,
+    signals     TEXT      NOT NULL DEFAULT '{}',
+    detected_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id_user_a, id_user_b),
+    CHECK (id_user_a < id_user_b)
+);
+
+CREATE INDEX IF NOT EXISTS users_duplicate_candidates_score_idx ON users_duplicate_candidates (score DESC);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS users_email_bidx_idx;
+
+ALTER TABLE users
+    DROP COLUMN email_bidx;
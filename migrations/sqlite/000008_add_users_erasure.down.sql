@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS users_erasure_receipts;
+
+ALTER TABLE users
+    DROP COLUMN erased_at;
@@ -0,0 +1,14 @@
+-- erased users keep their rows with tombstone values, so references to them stay valid
+ALTER TABLE users
+    ADD COLUMN erased_at TIMESTAMP NULL;
+
+CREATE TABLE IF NOT EXISTS users_erasure_receipts
+(
+    id        INTEGER PRIMARY KEY AUTOINCREMENT,
+    id_user   BIGINT    NOT NULL,
+    reason    TEXT      NOT NULL DEFAULT '',
+    summary   TEXT      NOT NULL DEFAULT '{}',
+    erased_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS users_erasure_receipts_id_user_idx ON users_erasure_receipts (id_user);
@@ -178,6 +178,68 @@ func (m *User) validate(all bool) error {
 		}
 	}
 
+	// no validation rules for Labels
+
+	if all {
+		switch v := interface{}(m.GetProfile()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserValidationError{
+					field:  "Profile",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserValidationError{
+					field:  "Profile",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetProfile()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserValidationError{
+				field:  "Profile",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for MergedInto
+
+	if all {
+		switch v := interface{}(m.GetErasedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UserValidationError{
+					field:  "ErasedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UserValidationError{
+					field:  "ErasedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetErasedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UserValidationError{
+				field:  "ErasedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return UserMultiError(errors)
 	}
@@ -390,6 +452,94 @@ func (m *CreateUserRequest) validate(all bool) error {
 		}
 	}
 
+	if len(m.GetLabels()) > 64 {
+		err := CreateUserRequestValidationError{
+			field:  "Labels",
+			reason: "value must contain no more than 64 pair(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	{
+		sorted_keys := make([]string, len(m.GetLabels()))
+		i := 0
+		for key := range m.GetLabels() {
+			sorted_keys[i] = key
+			i++
+		}
+		sort.Slice(sorted_keys, func(i, j int) bool { return sorted_keys[i] < sorted_keys[j] })
+		for _, key := range sorted_keys {
+			val := m.GetLabels()[key]
+			_ = val
+
+			if l := utf8.RuneCountInString(key); l < 1 || l > 63 {
+				err := CreateUserRequestValidationError{
+					field:  fmt.Sprintf("Labels[%v]", key),
+					reason: "value length must be between 1 and 63 runes, inclusive",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+			if !_CreateUserRequest_Labels_Pattern.MatchString(key) {
+				err := CreateUserRequestValidationError{
+					field:  fmt.Sprintf("Labels[%v]", key),
+					reason: "value does not match regex pattern \"^[A-Za-z0-9]([-A-Za-z0-9_./]*[A-Za-z0-9])?$\"",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+			if utf8.RuneCountInString(val) > 63 {
+				err := CreateUserRequestValidationError{
+					field:  fmt.Sprintf("Labels[%v]", key),
+					reason: "value length must be at most 63 runes",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetProfile()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CreateUserRequestValidationError{
+					field:  "Profile",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CreateUserRequestValidationError{
+					field:  "Profile",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetProfile()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CreateUserRequestValidationError{
+				field:  "Profile",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return CreateUserRequestMultiError(errors)
 	}
@@ -470,6 +620,8 @@ var _ interface {
 	ErrorName() string
 } = CreateUserRequestValidationError{}
 
+var _CreateUserRequest_Labels_Pattern = regexp.MustCompile("^[A-Za-z0-9]([-A-Za-z0-9_./]*[A-Za-z0-9])?$")
+
 // Validate checks the field values on CreateUserResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
@@ -872,6 +1024,17 @@ func (m *ListUserRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	if utf8.RuneCountInString(m.GetLabelSelector()) > 1024 {
+		err := ListUserRequestValidationError{
+			field:  "LabelSelector",
+			reason: "value length must be at most 1024 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
 	if len(errors) > 0 {
 		return ListUserRequestMultiError(errors)
 	}
@@ -1314,32 +1477,32 @@ var _ interface {
 	ErrorName() string
 } = RemoveUserResponseValidationError{}
 
-// Validate checks the field values on UpdateUserByIdRequest with the rules
+// Validate checks the field values on RestoreUsersRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *UpdateUserByIdRequest) Validate() error {
+func (m *RestoreUsersRequest) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UpdateUserByIdRequest with the rules
+// ValidateAll checks the field values on RestoreUsersRequest with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// UpdateUserByIdRequestMultiError, or nil if none found.
-func (m *UpdateUserByIdRequest) ValidateAll() error {
+// RestoreUsersRequestMultiError, or nil if none found.
+func (m *RestoreUsersRequest) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UpdateUserByIdRequest) validate(all bool) error {
+func (m *RestoreUsersRequest) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if m.GetIdUser() <= 0 {
-		err := UpdateUserByIdRequestValidationError{
-			field:  "IdUser",
-			reason: "value must be greater than 0",
+	if l := len(m.GetIdsUser()); l < 1 || l > 100 {
+		err := RestoreUsersRequestValidationError{
+			field:  "IdsUser",
+			reason: "value must contain between 1 and 100 items, inclusive",
 		}
 		if !all {
 			return err
@@ -1347,24 +1510,51 @@ func (m *UpdateUserByIdRequest) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	// no validation rules for Name
+	_RestoreUsersRequest_IdsUser_Unique := make(map[uint64]struct{}, len(m.GetIdsUser()))
 
-	// no validation rules for Email
+	for idx, item := range m.GetIdsUser() {
+		_, _ = idx, item
+
+		if _, exists := _RestoreUsersRequest_IdsUser_Unique[item]; exists {
+			err := RestoreUsersRequestValidationError{
+				field:  fmt.Sprintf("IdsUser[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_RestoreUsersRequest_IdsUser_Unique[item] = struct{}{}
+		}
+
+		if item <= 0 {
+			err := RestoreUsersRequestValidationError{
+				field:  fmt.Sprintf("IdsUser[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
 
 	if len(errors) > 0 {
-		return UpdateUserByIdRequestMultiError(errors)
+		return RestoreUsersRequestMultiError(errors)
 	}
 
 	return nil
 }
 
-// UpdateUserByIdRequestMultiError is an error wrapping multiple validation
-// errors returned by UpdateUserByIdRequest.ValidateAll() if the designated
+// RestoreUsersRequestMultiError is an error wrapping multiple validation
+// errors returned by RestoreUsersRequest.ValidateAll() if the designated
 // constraints aren't met.
-type UpdateUserByIdRequestMultiError []error
+type RestoreUsersRequestMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UpdateUserByIdRequestMultiError) Error() string {
+func (m RestoreUsersRequestMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1373,11 +1563,11 @@ func (m UpdateUserByIdRequestMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UpdateUserByIdRequestMultiError) AllErrors() []error { return m }
+func (m RestoreUsersRequestMultiError) AllErrors() []error { return m }
 
-// UpdateUserByIdRequestValidationError is the validation error returned by
-// UpdateUserByIdRequest.Validate if the designated constraints aren't met.
-type UpdateUserByIdRequestValidationError struct {
+// RestoreUsersRequestValidationError is the validation error returned by
+// RestoreUsersRequest.Validate if the designated constraints aren't met.
+type RestoreUsersRequestValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1385,24 +1575,24 @@ type UpdateUserByIdRequestValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdateUserByIdRequestValidationError) Field() string { return e.field }
+func (e RestoreUsersRequestValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdateUserByIdRequestValidationError) Reason() string { return e.reason }
+func (e RestoreUsersRequestValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdateUserByIdRequestValidationError) Cause() error { return e.cause }
+func (e RestoreUsersRequestValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdateUserByIdRequestValidationError) Key() bool { return e.key }
+func (e RestoreUsersRequestValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdateUserByIdRequestValidationError) ErrorName() string {
-	return "UpdateUserByIdRequestValidationError"
+func (e RestoreUsersRequestValidationError) ErrorName() string {
+	return "RestoreUsersRequestValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e UpdateUserByIdRequestValidationError) Error() string {
+func (e RestoreUsersRequestValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1414,14 +1604,14 @@ func (e UpdateUserByIdRequestValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdateUserByIdRequest.%s: %s%s",
+		"invalid %sRestoreUsersRequest.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdateUserByIdRequestValidationError{}
+var _ error = RestoreUsersRequestValidationError{}
 
 var _ interface {
 	Field() string
@@ -1429,46 +1619,46 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdateUserByIdRequestValidationError{}
+} = RestoreUsersRequestValidationError{}
 
-// Validate checks the field values on UpdateUserByIdResponse with the rules
+// Validate checks the field values on RestoreUsersResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
-func (m *UpdateUserByIdResponse) Validate() error {
+func (m *RestoreUsersResponse) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on UpdateUserByIdResponse with the rules
+// ValidateAll checks the field values on RestoreUsersResponse with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// UpdateUserByIdResponseMultiError, or nil if none found.
-func (m *UpdateUserByIdResponse) ValidateAll() error {
+// RestoreUsersResponseMultiError, or nil if none found.
+func (m *RestoreUsersResponse) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *UpdateUserByIdResponse) validate(all bool) error {
+func (m *RestoreUsersResponse) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	// no validation rules for Updated
+	// no validation rules for Restored
 
 	if len(errors) > 0 {
-		return UpdateUserByIdResponseMultiError(errors)
+		return RestoreUsersResponseMultiError(errors)
 	}
 
 	return nil
 }
 
-// UpdateUserByIdResponseMultiError is an error wrapping multiple validation
-// errors returned by UpdateUserByIdResponse.ValidateAll() if the designated
+// RestoreUsersResponseMultiError is an error wrapping multiple validation
+// errors returned by RestoreUsersResponse.ValidateAll() if the designated
 // constraints aren't met.
-type UpdateUserByIdResponseMultiError []error
+type RestoreUsersResponseMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m UpdateUserByIdResponseMultiError) Error() string {
+func (m RestoreUsersResponseMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -1477,11 +1667,11 @@ func (m UpdateUserByIdResponseMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m UpdateUserByIdResponseMultiError) AllErrors() []error { return m }
+func (m RestoreUsersResponseMultiError) AllErrors() []error { return m }
 
-// UpdateUserByIdResponseValidationError is the validation error returned by
-// UpdateUserByIdResponse.Validate if the designated constraints aren't met.
-type UpdateUserByIdResponseValidationError struct {
+// RestoreUsersResponseValidationError is the validation error returned by
+// RestoreUsersResponse.Validate if the designated constraints aren't met.
+type RestoreUsersResponseValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -1489,24 +1679,24 @@ type UpdateUserByIdResponseValidationError struct {
 }
 
 // Field function returns field value.
-func (e UpdateUserByIdResponseValidationError) Field() string { return e.field }
+func (e RestoreUsersResponseValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e UpdateUserByIdResponseValidationError) Reason() string { return e.reason }
+func (e RestoreUsersResponseValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e UpdateUserByIdResponseValidationError) Cause() error { return e.cause }
+func (e RestoreUsersResponseValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e UpdateUserByIdResponseValidationError) Key() bool { return e.key }
+func (e RestoreUsersResponseValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e UpdateUserByIdResponseValidationError) ErrorName() string {
-	return "UpdateUserByIdResponseValidationError"
+func (e RestoreUsersResponseValidationError) ErrorName() string {
+	return "RestoreUsersResponseValidationError"
 }
 
 // Error satisfies the builtin error interface
-func (e UpdateUserByIdResponseValidationError) Error() string {
+func (e RestoreUsersResponseValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -1518,14 +1708,14 @@ func (e UpdateUserByIdResponseValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sUpdateUserByIdResponse.%s: %s%s",
+		"invalid %sRestoreUsersResponse.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = UpdateUserByIdResponseValidationError{}
+var _ error = RestoreUsersResponseValidationError{}
 
 var _ interface {
 	Field() string
@@ -1533,7 +1723,4844 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = UpdateUserByIdResponseValidationError{}
+} = RestoreUsersResponseValidationError{}
+
+// Validate checks the field values on UpdateUserByIdRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateUserByIdRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateUserByIdRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateUserByIdRequestMultiError, or nil if none found.
+func (m *UpdateUserByIdRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateUserByIdRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetIdUser() <= 0 {
+		err := UpdateUserByIdRequestValidationError{
+			field:  "IdUser",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Name
+
+	// no validation rules for Email
+
+	if len(errors) > 0 {
+		return UpdateUserByIdRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateUserByIdRequestMultiError is an error wrapping multiple validation
+// errors returned by UpdateUserByIdRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UpdateUserByIdRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateUserByIdRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateUserByIdRequestMultiError) AllErrors() []error { return m }
+
+// UpdateUserByIdRequestValidationError is the validation error returned by
+// UpdateUserByIdRequest.Validate if the designated constraints aren't met.
+type UpdateUserByIdRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateUserByIdRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateUserByIdRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateUserByIdRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateUserByIdRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateUserByIdRequestValidationError) ErrorName() string {
+	return "UpdateUserByIdRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateUserByIdRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateUserByIdRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateUserByIdRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateUserByIdRequestValidationError{}
+
+// Validate checks the field values on UpdateUserByIdResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateUserByIdResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateUserByIdResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateUserByIdResponseMultiError, or nil if none found.
+func (m *UpdateUserByIdResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateUserByIdResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Updated
+
+	if len(errors) > 0 {
+		return UpdateUserByIdResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateUserByIdResponseMultiError is an error wrapping multiple validation
+// errors returned by UpdateUserByIdResponse.ValidateAll() if the designated
+// constraints aren't met.
+type UpdateUserByIdResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateUserByIdResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateUserByIdResponseMultiError) AllErrors() []error { return m }
+
+// UpdateUserByIdResponseValidationError is the validation error returned by
+// UpdateUserByIdResponse.Validate if the designated constraints aren't met.
+type UpdateUserByIdResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateUserByIdResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateUserByIdResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateUserByIdResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateUserByIdResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateUserByIdResponseValidationError) ErrorName() string {
+	return "UpdateUserByIdResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateUserByIdResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateUserByIdResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateUserByIdResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateUserByIdResponseValidationError{}
+
+// Validate checks the field values on UpdateUserLabelsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateUserLabelsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateUserLabelsRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateUserLabelsRequestMultiError, or nil if none found.
+func (m *UpdateUserLabelsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateUserLabelsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetIdUser() <= 0 {
+		err := UpdateUserLabelsRequestValidationError{
+			field:  "IdUser",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetSetLabels()) > 64 {
+		err := UpdateUserLabelsRequestValidationError{
+			field:  "SetLabels",
+			reason: "value must contain no more than 64 pair(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	{
+		sorted_keys := make([]string, len(m.GetSetLabels()))
+		i := 0
+		for key := range m.GetSetLabels() {
+			sorted_keys[i] = key
+			i++
+		}
+		sort.Slice(sorted_keys, func(i, j int) bool { return sorted_keys[i] < sorted_keys[j] })
+		for _, key := range sorted_keys {
+			val := m.GetSetLabels()[key]
+			_ = val
+
+			if l := utf8.RuneCountInString(key); l < 1 || l > 63 {
+				err := UpdateUserLabelsRequestValidationError{
+					field:  fmt.Sprintf("SetLabels[%v]", key),
+					reason: "value length must be between 1 and 63 runes, inclusive",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+			if !_UpdateUserLabelsRequest_SetLabels_Pattern.MatchString(key) {
+				err := UpdateUserLabelsRequestValidationError{
+					field:  fmt.Sprintf("SetLabels[%v]", key),
+					reason: "value does not match regex pattern \"^[A-Za-z0-9]([-A-Za-z0-9_./]*[A-Za-z0-9])?$\"",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+			if utf8.RuneCountInString(val) > 63 {
+				err := UpdateUserLabelsRequestValidationError{
+					field:  fmt.Sprintf("SetLabels[%v]", key),
+					reason: "value length must be at most 63 runes",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+	}
+
+	if len(m.GetRemoveKeys()) > 64 {
+		err := UpdateUserLabelsRequestValidationError{
+			field:  "RemoveKeys",
+			reason: "value must contain no more than 64 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetRemoveKeys() {
+		_, _ = idx, item
+
+		if l := utf8.RuneCountInString(item); l < 1 || l > 63 {
+			err := UpdateUserLabelsRequestValidationError{
+				field:  fmt.Sprintf("RemoveKeys[%v]", idx),
+				reason: "value length must be between 1 and 63 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return UpdateUserLabelsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateUserLabelsRequestMultiError is an error wrapping multiple validation
+// errors returned by UpdateUserLabelsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UpdateUserLabelsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateUserLabelsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateUserLabelsRequestMultiError) AllErrors() []error { return m }
+
+// UpdateUserLabelsRequestValidationError is the validation error returned by
+// UpdateUserLabelsRequest.Validate if the designated constraints aren't met.
+type UpdateUserLabelsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateUserLabelsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateUserLabelsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateUserLabelsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateUserLabelsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateUserLabelsRequestValidationError) ErrorName() string {
+	return "UpdateUserLabelsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateUserLabelsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateUserLabelsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateUserLabelsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateUserLabelsRequestValidationError{}
+
+var _UpdateUserLabelsRequest_SetLabels_Pattern = regexp.MustCompile("^[A-Za-z0-9]([-A-Za-z0-9_./]*[A-Za-z0-9])?$")
+
+// Validate checks the field values on UpdateUserLabelsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateUserLabelsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateUserLabelsResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateUserLabelsResponseMultiError, or nil if none found.
+func (m *UpdateUserLabelsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateUserLabelsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Updated
+
+	if len(errors) > 0 {
+		return UpdateUserLabelsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateUserLabelsResponseMultiError is an error wrapping multiple validation
+// errors returned by UpdateUserLabelsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type UpdateUserLabelsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateUserLabelsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateUserLabelsResponseMultiError) AllErrors() []error { return m }
+
+// UpdateUserLabelsResponseValidationError is the validation error returned by
+// UpdateUserLabelsResponse.Validate if the designated constraints aren't met.
+type UpdateUserLabelsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateUserLabelsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateUserLabelsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateUserLabelsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateUserLabelsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateUserLabelsResponseValidationError) ErrorName() string {
+	return "UpdateUserLabelsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateUserLabelsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateUserLabelsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateUserLabelsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateUserLabelsResponseValidationError{}
+
+// Validate checks the field values on UpdateUserProfileRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateUserProfileRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateUserProfileRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateUserProfileRequestMultiError, or nil if none found.
+func (m *UpdateUserProfileRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateUserProfileRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetIdUser() <= 0 {
+		err := UpdateUserProfileRequestValidationError{
+			field:  "IdUser",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetProfile() == nil {
+		err := UpdateUserProfileRequestValidationError{
+			field:  "Profile",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetProfile()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, UpdateUserProfileRequestValidationError{
+					field:  "Profile",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, UpdateUserProfileRequestValidationError{
+					field:  "Profile",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetProfile()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return UpdateUserProfileRequestValidationError{
+				field:  "Profile",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return UpdateUserProfileRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateUserProfileRequestMultiError is an error wrapping multiple validation
+// errors returned by UpdateUserProfileRequest.ValidateAll() if the designated
+// constraints aren't met.
+type UpdateUserProfileRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateUserProfileRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateUserProfileRequestMultiError) AllErrors() []error { return m }
+
+// UpdateUserProfileRequestValidationError is the validation error returned by
+// UpdateUserProfileRequest.Validate if the designated constraints aren't met.
+type UpdateUserProfileRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateUserProfileRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateUserProfileRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateUserProfileRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateUserProfileRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateUserProfileRequestValidationError) ErrorName() string {
+	return "UpdateUserProfileRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateUserProfileRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateUserProfileRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateUserProfileRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateUserProfileRequestValidationError{}
+
+// Validate checks the field values on UpdateUserProfileResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateUserProfileResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateUserProfileResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateUserProfileResponseMultiError, or nil if none found.
+func (m *UpdateUserProfileResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateUserProfileResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Updated
+
+	if len(errors) > 0 {
+		return UpdateUserProfileResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateUserProfileResponseMultiError is an error wrapping multiple validation
+// errors returned by UpdateUserProfileResponse.ValidateAll() if the
+// designated constraints aren't met.
+type UpdateUserProfileResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateUserProfileResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateUserProfileResponseMultiError) AllErrors() []error { return m }
+
+// UpdateUserProfileResponseValidationError is the validation error returned by
+// UpdateUserProfileResponse.Validate if the designated constraints aren't met.
+type UpdateUserProfileResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateUserProfileResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateUserProfileResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateUserProfileResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateUserProfileResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateUserProfileResponseValidationError) ErrorName() string {
+	return "UpdateUserProfileResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateUserProfileResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateUserProfileResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateUserProfileResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateUserProfileResponseValidationError{}
+
+// Validate checks the field values on MergePolicies with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *MergePolicies) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on MergePolicies with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in MergePoliciesMultiError, or
+// nil if none found.
+func (m *MergePolicies) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *MergePolicies) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if _, ok := MergePolicy_name[int32(m.GetName())]; !ok {
+		err := MergePoliciesValidationError{
+			field:  "Name",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := MergePolicy_name[int32(m.GetEmail())]; !ok {
+		err := MergePoliciesValidationError{
+			field:  "Email",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := MergePolicy_name[int32(m.GetLabels())]; !ok {
+		err := MergePoliciesValidationError{
+			field:  "Labels",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := MergePolicy_name[int32(m.GetProfile())]; !ok {
+		err := MergePoliciesValidationError{
+			field:  "Profile",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return MergePoliciesMultiError(errors)
+	}
+
+	return nil
+}
+
+// MergePoliciesMultiError is an error wrapping multiple validation errors
+// returned by MergePolicies.ValidateAll() if the designated constraints
+// aren't met.
+type MergePoliciesMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MergePoliciesMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MergePoliciesMultiError) AllErrors() []error { return m }
+
+// MergePoliciesValidationError is the validation error returned by
+// MergePolicies.Validate if the designated constraints aren't met.
+type MergePoliciesValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MergePoliciesValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MergePoliciesValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MergePoliciesValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MergePoliciesValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MergePoliciesValidationError) ErrorName() string { return "MergePoliciesValidationError" }
+
+// Error satisfies the builtin error interface
+func (e MergePoliciesValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMergePolicies.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MergePoliciesValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MergePoliciesValidationError{}
+
+// Validate checks the field values on MergeUsersRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *MergeUsersRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on MergeUsersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// MergeUsersRequestMultiError, or nil if none found.
+func (m *MergeUsersRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *MergeUsersRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetIdSurvivor() <= 0 {
+		err := MergeUsersRequestValidationError{
+			field:  "IdSurvivor",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := len(m.GetIdsLoser()); l < 1 || l > 100 {
+		err := MergeUsersRequestValidationError{
+			field:  "IdsLoser",
+			reason: "value must contain between 1 and 100 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_MergeUsersRequest_IdsLoser_Unique := make(map[uint64]struct{}, len(m.GetIdsLoser()))
+
+	for idx, item := range m.GetIdsLoser() {
+		_, _ = idx, item
+
+		if _, exists := _MergeUsersRequest_IdsLoser_Unique[item]; exists {
+			err := MergeUsersRequestValidationError{
+				field:  fmt.Sprintf("IdsLoser[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_MergeUsersRequest_IdsLoser_Unique[item] = struct{}{}
+		}
+
+		if item <= 0 {
+			err := MergeUsersRequestValidationError{
+				field:  fmt.Sprintf("IdsLoser[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetPolicies()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, MergeUsersRequestValidationError{
+					field:  "Policies",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, MergeUsersRequestValidationError{
+					field:  "Policies",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPolicies()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return MergeUsersRequestValidationError{
+				field:  "Policies",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return MergeUsersRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// MergeUsersRequestMultiError is an error wrapping multiple validation errors
+// returned by MergeUsersRequest.ValidateAll() if the designated constraints
+// aren't met.
+type MergeUsersRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MergeUsersRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MergeUsersRequestMultiError) AllErrors() []error { return m }
+
+// MergeUsersRequestValidationError is the validation error returned by
+// MergeUsersRequest.Validate if the designated constraints aren't met.
+type MergeUsersRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MergeUsersRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MergeUsersRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MergeUsersRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MergeUsersRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MergeUsersRequestValidationError) ErrorName() string {
+	return "MergeUsersRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e MergeUsersRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMergeUsersRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MergeUsersRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MergeUsersRequestValidationError{}
+
+// Validate checks the field values on MergeUsersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *MergeUsersResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on MergeUsersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// MergeUsersResponseMultiError, or nil if none found.
+func (m *MergeUsersResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *MergeUsersResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetSurvivor()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, MergeUsersResponseValidationError{
+					field:  "Survivor",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, MergeUsersResponseValidationError{
+					field:  "Survivor",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSurvivor()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return MergeUsersResponseValidationError{
+				field:  "Survivor",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return MergeUsersResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// MergeUsersResponseMultiError is an error wrapping multiple validation errors
+// returned by MergeUsersResponse.ValidateAll() if the designated constraints
+// aren't met.
+type MergeUsersResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m MergeUsersResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m MergeUsersResponseMultiError) AllErrors() []error { return m }
+
+// MergeUsersResponseValidationError is the validation error returned by
+// MergeUsersResponse.Validate if the designated constraints aren't met.
+type MergeUsersResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e MergeUsersResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e MergeUsersResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e MergeUsersResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e MergeUsersResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e MergeUsersResponseValidationError) ErrorName() string {
+	return "MergeUsersResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e MergeUsersResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sMergeUsersResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = MergeUsersResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = MergeUsersResponseValidationError{}
+
+// Validate checks the field values on DuplicateCandidate with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DuplicateCandidate) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DuplicateCandidate with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DuplicateCandidateMultiError, or nil if none found.
+func (m *DuplicateCandidate) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DuplicateCandidate) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for IdUserA
+
+	// no validation rules for IdUserB
+
+	// no validation rules for Score
+
+	if all {
+		switch v := interface{}(m.GetDetectedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DuplicateCandidateValidationError{
+					field:  "DetectedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DuplicateCandidateValidationError{
+					field:  "DetectedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDetectedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DuplicateCandidateValidationError{
+				field:  "DetectedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return DuplicateCandidateMultiError(errors)
+	}
+
+	return nil
+}
+
+// DuplicateCandidateMultiError is an error wrapping multiple validation errors
+// returned by DuplicateCandidate.ValidateAll() if the designated constraints
+// aren't met.
+type DuplicateCandidateMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DuplicateCandidateMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DuplicateCandidateMultiError) AllErrors() []error { return m }
+
+// DuplicateCandidateValidationError is the validation error returned by
+// DuplicateCandidate.Validate if the designated constraints aren't met.
+type DuplicateCandidateValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DuplicateCandidateValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DuplicateCandidateValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DuplicateCandidateValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DuplicateCandidateValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DuplicateCandidateValidationError) ErrorName() string {
+	return "DuplicateCandidateValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DuplicateCandidateValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDuplicateCandidate.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DuplicateCandidateValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DuplicateCandidateValidationError{}
+
+// Validate checks the field values on ListDuplicateCandidatesRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListDuplicateCandidatesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListDuplicateCandidatesRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListDuplicateCandidatesRequestMultiError, or nil if none found.
+func (m *ListDuplicateCandidatesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListDuplicateCandidatesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if val := m.GetMinScore(); val < 0 || val > 1 {
+		err := ListDuplicateCandidatesRequestValidationError{
+			field:  "MinScore",
+			reason: "value must be inside range [0, 1]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetLimit(); val <= 0 || val > 1000 {
+		err := ListDuplicateCandidatesRequestValidationError{
+			field:  "Limit",
+			reason: "value must be inside range (0, 1000]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Offset
+
+	if len(errors) > 0 {
+		return ListDuplicateCandidatesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListDuplicateCandidatesRequestMultiError is an error wrapping multiple
+// validation errors returned by ListDuplicateCandidatesRequest.ValidateAll()
+// if the designated constraints aren't met.
+type ListDuplicateCandidatesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListDuplicateCandidatesRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListDuplicateCandidatesRequestMultiError) AllErrors() []error { return m }
+
+// ListDuplicateCandidatesRequestValidationError is the validation error
+// returned by ListDuplicateCandidatesRequest.Validate if the designated
+// constraints aren't met.
+type ListDuplicateCandidatesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListDuplicateCandidatesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListDuplicateCandidatesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListDuplicateCandidatesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListDuplicateCandidatesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListDuplicateCandidatesRequestValidationError) ErrorName() string {
+	return "ListDuplicateCandidatesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListDuplicateCandidatesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListDuplicateCandidatesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListDuplicateCandidatesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListDuplicateCandidatesRequestValidationError{}
+
+// Validate checks the field values on ListDuplicateCandidatesResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListDuplicateCandidatesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListDuplicateCandidatesResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ListDuplicateCandidatesResponseMultiError, or nil if none found.
+func (m *ListDuplicateCandidatesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListDuplicateCandidatesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetItems() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListDuplicateCandidatesResponseValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListDuplicateCandidatesResponseValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListDuplicateCandidatesResponseValidationError{
+					field:  fmt.Sprintf("Items[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListDuplicateCandidatesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListDuplicateCandidatesResponseMultiError is an error wrapping multiple
+// validation errors returned by ListDuplicateCandidatesResponse.ValidateAll()
+// if the designated constraints aren't met.
+type ListDuplicateCandidatesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListDuplicateCandidatesResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListDuplicateCandidatesResponseMultiError) AllErrors() []error { return m }
+
+// ListDuplicateCandidatesResponseValidationError is the validation error
+// returned by ListDuplicateCandidatesResponse.Validate if the designated
+// constraints aren't met.
+type ListDuplicateCandidatesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListDuplicateCandidatesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListDuplicateCandidatesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListDuplicateCandidatesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListDuplicateCandidatesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListDuplicateCandidatesResponseValidationError) ErrorName() string {
+	return "ListDuplicateCandidatesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListDuplicateCandidatesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListDuplicateCandidatesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListDuplicateCandidatesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListDuplicateCandidatesResponseValidationError{}
+
+// Validate checks the field values on EraseUserRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *EraseUserRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EraseUserRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// EraseUserRequestMultiError, or nil if none found.
+func (m *EraseUserRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EraseUserRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetIdUser() <= 0 {
+		err := EraseUserRequestValidationError{
+			field:  "IdUser",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetReason()) > 1024 {
+		err := EraseUserRequestValidationError{
+			field:  "Reason",
+			reason: "value length must be at most 1024 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return EraseUserRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// EraseUserRequestMultiError is an error wrapping multiple validation errors
+// returned by EraseUserRequest.ValidateAll() if the designated constraints
+// aren't met.
+type EraseUserRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EraseUserRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EraseUserRequestMultiError) AllErrors() []error { return m }
+
+// EraseUserRequestValidationError is the validation error returned by
+// EraseUserRequest.Validate if the designated constraints aren't met.
+type EraseUserRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EraseUserRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EraseUserRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EraseUserRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EraseUserRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EraseUserRequestValidationError) ErrorName() string { return "EraseUserRequestValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EraseUserRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEraseUserRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EraseUserRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EraseUserRequestValidationError{}
+
+// Validate checks the field values on ErasureReceipt with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ErasureReceipt) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ErasureReceipt with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ErasureReceiptMultiError,
+// or nil if none found.
+func (m *ErasureReceipt) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ErasureReceipt) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for IdUser
+
+	// no validation rules for Rows
+
+	// no validation rules for Reason
+
+	if all {
+		switch v := interface{}(m.GetErasedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ErasureReceiptValidationError{
+					field:  "ErasedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ErasureReceiptValidationError{
+					field:  "ErasedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetErasedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ErasureReceiptValidationError{
+				field:  "ErasedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ErasureReceiptMultiError(errors)
+	}
+
+	return nil
+}
+
+// ErasureReceiptMultiError is an error wrapping multiple validation errors
+// returned by ErasureReceipt.ValidateAll() if the designated constraints
+// aren't met.
+type ErasureReceiptMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ErasureReceiptMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ErasureReceiptMultiError) AllErrors() []error { return m }
+
+// ErasureReceiptValidationError is the validation error returned by
+// ErasureReceipt.Validate if the designated constraints aren't met.
+type ErasureReceiptValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ErasureReceiptValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ErasureReceiptValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ErasureReceiptValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ErasureReceiptValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ErasureReceiptValidationError) ErrorName() string { return "ErasureReceiptValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ErasureReceiptValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sErasureReceipt.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ErasureReceiptValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ErasureReceiptValidationError{}
+
+// Validate checks the field values on EraseUserResponse with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *EraseUserResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EraseUserResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// EraseUserResponseMultiError, or nil if none found.
+func (m *EraseUserResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EraseUserResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetReceipt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EraseUserResponseValidationError{
+					field:  "Receipt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EraseUserResponseValidationError{
+					field:  "Receipt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetReceipt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EraseUserResponseValidationError{
+				field:  "Receipt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return EraseUserResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// EraseUserResponseMultiError is an error wrapping multiple validation errors
+// returned by EraseUserResponse.ValidateAll() if the designated constraints
+// aren't met.
+type EraseUserResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EraseUserResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EraseUserResponseMultiError) AllErrors() []error { return m }
+
+// EraseUserResponseValidationError is the validation error returned by
+// EraseUserResponse.Validate if the designated constraints aren't met.
+type EraseUserResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EraseUserResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EraseUserResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EraseUserResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EraseUserResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EraseUserResponseValidationError) ErrorName() string {
+	return "EraseUserResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e EraseUserResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEraseUserResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EraseUserResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EraseUserResponseValidationError{}
+
+// Validate checks the field values on Group with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Group) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Group with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in GroupMultiError, or nil if none found.
+func (m *Group) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Group) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Name
+
+	// no validation rules for Description
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GroupValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GroupValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GroupValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetUpdatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GroupValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GroupValidationError{
+					field:  "UpdatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetUpdatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GroupValidationError{
+				field:  "UpdatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetDeletedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, GroupValidationError{
+					field:  "DeletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, GroupValidationError{
+					field:  "DeletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDeletedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return GroupValidationError{
+				field:  "DeletedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return GroupMultiError(errors)
+	}
+
+	return nil
+}
+
+// GroupMultiError is an error wrapping multiple validation errors returned by
+// Group.ValidateAll() if the designated constraints aren't met.
+type GroupMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GroupMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GroupMultiError) AllErrors() []error { return m }
+
+// GroupValidationError is the validation error returned by Group.Validate if
+// the designated constraints aren't met.
+type GroupValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GroupValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GroupValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GroupValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GroupValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GroupValidationError) ErrorName() string { return "GroupValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GroupValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGroup.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GroupValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GroupValidationError{}
+
+// Validate checks the field values on CreateGroupRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateGroupRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateGroupRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateGroupRequestMultiError, or nil if none found.
+func (m *CreateGroupRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateGroupRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := utf8.RuneCountInString(m.GetName()); l < 1 || l > 255 {
+		err := CreateGroupRequestValidationError{
+			field:  "Name",
+			reason: "value length must be between 1 and 255 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetDescription()) > 1024 {
+		err := CreateGroupRequestValidationError{
+			field:  "Description",
+			reason: "value length must be at most 1024 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return CreateGroupRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateGroupRequestMultiError is an error wrapping multiple validation errors
+// returned by CreateGroupRequest.ValidateAll() if the designated constraints
+// aren't met.
+type CreateGroupRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateGroupRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateGroupRequestMultiError) AllErrors() []error { return m }
+
+// CreateGroupRequestValidationError is the validation error returned by
+// CreateGroupRequest.Validate if the designated constraints aren't met.
+type CreateGroupRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateGroupRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateGroupRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateGroupRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateGroupRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateGroupRequestValidationError) ErrorName() string {
+	return "CreateGroupRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateGroupRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateGroupRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateGroupRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateGroupRequestValidationError{}
+
+// Validate checks the field values on CreateGroupResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CreateGroupResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateGroupResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateGroupResponseMultiError, or nil if none found.
+func (m *CreateGroupResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateGroupResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for IdGroup
+
+	if len(errors) > 0 {
+		return CreateGroupResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateGroupResponseMultiError is an error wrapping multiple validation
+// errors returned by CreateGroupResponse.ValidateAll() if the designated
+// constraints aren't met.
+type CreateGroupResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateGroupResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateGroupResponseMultiError) AllErrors() []error { return m }
+
+// CreateGroupResponseValidationError is the validation error returned by
+// CreateGroupResponse.Validate if the designated constraints aren't met.
+type CreateGroupResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateGroupResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateGroupResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateGroupResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateGroupResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateGroupResponseValidationError) ErrorName() string {
+	return "CreateGroupResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateGroupResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateGroupResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateGroupResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateGroupResponseValidationError{}
+
+// Validate checks the field values on GetGroupByIdRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetGroupByIdRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetGroupByIdRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetGroupByIdRequestMultiError, or nil if none found.
+func (m *GetGroupByIdRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetGroupByIdRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetIdsGroup()) < 1 {
+		err := GetGroupByIdRequestValidationError{
+			field:  "IdsGroup",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetIdsGroup() {
+		_, _ = idx, item
+
+		if item <= 0 {
+			err := GetGroupByIdRequestValidationError{
+				field:  fmt.Sprintf("IdsGroup[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetGroupByIdRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetGroupByIdRequestMultiError is an error wrapping multiple validation
+// errors returned by GetGroupByIdRequest.ValidateAll() if the designated
+// constraints aren't met.
+type GetGroupByIdRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetGroupByIdRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetGroupByIdRequestMultiError) AllErrors() []error { return m }
+
+// GetGroupByIdRequestValidationError is the validation error returned by
+// GetGroupByIdRequest.Validate if the designated constraints aren't met.
+type GetGroupByIdRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetGroupByIdRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetGroupByIdRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetGroupByIdRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetGroupByIdRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetGroupByIdRequestValidationError) ErrorName() string {
+	return "GetGroupByIdRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetGroupByIdRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetGroupByIdRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetGroupByIdRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetGroupByIdRequestValidationError{}
+
+// Validate checks the field values on GetGroupByIdResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *GetGroupByIdResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetGroupByIdResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetGroupByIdResponseMultiError, or nil if none found.
+func (m *GetGroupByIdResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetGroupByIdResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetGroups() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetGroupByIdResponseValidationError{
+						field:  fmt.Sprintf("Groups[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetGroupByIdResponseValidationError{
+						field:  fmt.Sprintf("Groups[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetGroupByIdResponseValidationError{
+					field:  fmt.Sprintf("Groups[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetGroupByIdResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetGroupByIdResponseMultiError is an error wrapping multiple validation
+// errors returned by GetGroupByIdResponse.ValidateAll() if the designated
+// constraints aren't met.
+type GetGroupByIdResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetGroupByIdResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetGroupByIdResponseMultiError) AllErrors() []error { return m }
+
+// GetGroupByIdResponseValidationError is the validation error returned by
+// GetGroupByIdResponse.Validate if the designated constraints aren't met.
+type GetGroupByIdResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetGroupByIdResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetGroupByIdResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetGroupByIdResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetGroupByIdResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetGroupByIdResponseValidationError) ErrorName() string {
+	return "GetGroupByIdResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e GetGroupByIdResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetGroupByIdResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetGroupByIdResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetGroupByIdResponseValidationError{}
+
+// Validate checks the field values on ListGroupsRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListGroupsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListGroupsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListGroupsRequestMultiError, or nil if none found.
+func (m *ListGroupsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListGroupsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if val := m.GetLimit(); val <= 0 || val > 1000 {
+		err := ListGroupsRequestValidationError{
+			field:  "Limit",
+			reason: "value must be inside range (0, 1000]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Offset
+
+	if len(errors) > 0 {
+		return ListGroupsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListGroupsRequestMultiError is an error wrapping multiple validation errors
+// returned by ListGroupsRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListGroupsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListGroupsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListGroupsRequestMultiError) AllErrors() []error { return m }
+
+// ListGroupsRequestValidationError is the validation error returned by
+// ListGroupsRequest.Validate if the designated constraints aren't met.
+type ListGroupsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListGroupsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListGroupsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListGroupsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListGroupsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListGroupsRequestValidationError) ErrorName() string {
+	return "ListGroupsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListGroupsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListGroupsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListGroupsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListGroupsRequestValidationError{}
+
+// Validate checks the field values on ListGroupsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListGroupsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListGroupsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListGroupsResponseMultiError, or nil if none found.
+func (m *ListGroupsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListGroupsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetItems() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListGroupsResponseValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListGroupsResponseValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListGroupsResponseValidationError{
+					field:  fmt.Sprintf("Items[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListGroupsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListGroupsResponseMultiError is an error wrapping multiple validation errors
+// returned by ListGroupsResponse.ValidateAll() if the designated constraints
+// aren't met.
+type ListGroupsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListGroupsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListGroupsResponseMultiError) AllErrors() []error { return m }
+
+// ListGroupsResponseValidationError is the validation error returned by
+// ListGroupsResponse.Validate if the designated constraints aren't met.
+type ListGroupsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListGroupsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListGroupsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListGroupsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListGroupsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListGroupsResponseValidationError) ErrorName() string {
+	return "ListGroupsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListGroupsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListGroupsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListGroupsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListGroupsResponseValidationError{}
+
+// Validate checks the field values on UpdateGroupRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateGroupRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateGroupRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateGroupRequestMultiError, or nil if none found.
+func (m *UpdateGroupRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateGroupRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetIdGroup() <= 0 {
+		err := UpdateGroupRequestValidationError{
+			field:  "IdGroup",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetName()); l < 1 || l > 255 {
+		err := UpdateGroupRequestValidationError{
+			field:  "Name",
+			reason: "value length must be between 1 and 255 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetDescription()) > 1024 {
+		err := UpdateGroupRequestValidationError{
+			field:  "Description",
+			reason: "value length must be at most 1024 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UpdateGroupRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateGroupRequestMultiError is an error wrapping multiple validation errors
+// returned by UpdateGroupRequest.ValidateAll() if the designated constraints
+// aren't met.
+type UpdateGroupRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateGroupRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateGroupRequestMultiError) AllErrors() []error { return m }
+
+// UpdateGroupRequestValidationError is the validation error returned by
+// UpdateGroupRequest.Validate if the designated constraints aren't met.
+type UpdateGroupRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateGroupRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateGroupRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateGroupRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateGroupRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateGroupRequestValidationError) ErrorName() string {
+	return "UpdateGroupRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateGroupRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateGroupRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateGroupRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateGroupRequestValidationError{}
+
+// Validate checks the field values on UpdateGroupResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UpdateGroupResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UpdateGroupResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UpdateGroupResponseMultiError, or nil if none found.
+func (m *UpdateGroupResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UpdateGroupResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Updated
+
+	if len(errors) > 0 {
+		return UpdateGroupResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// UpdateGroupResponseMultiError is an error wrapping multiple validation
+// errors returned by UpdateGroupResponse.ValidateAll() if the designated
+// constraints aren't met.
+type UpdateGroupResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UpdateGroupResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UpdateGroupResponseMultiError) AllErrors() []error { return m }
+
+// UpdateGroupResponseValidationError is the validation error returned by
+// UpdateGroupResponse.Validate if the designated constraints aren't met.
+type UpdateGroupResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UpdateGroupResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UpdateGroupResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UpdateGroupResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UpdateGroupResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UpdateGroupResponseValidationError) ErrorName() string {
+	return "UpdateGroupResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UpdateGroupResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUpdateGroupResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UpdateGroupResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UpdateGroupResponseValidationError{}
+
+// Validate checks the field values on RemoveGroupRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RemoveGroupRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RemoveGroupRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RemoveGroupRequestMultiError, or nil if none found.
+func (m *RemoveGroupRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RemoveGroupRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetIdsGroup()) < 1 {
+		err := RemoveGroupRequestValidationError{
+			field:  "IdsGroup",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetIdsGroup() {
+		_, _ = idx, item
+
+		if item <= 0 {
+			err := RemoveGroupRequestValidationError{
+				field:  fmt.Sprintf("IdsGroup[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return RemoveGroupRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RemoveGroupRequestMultiError is an error wrapping multiple validation errors
+// returned by RemoveGroupRequest.ValidateAll() if the designated constraints
+// aren't met.
+type RemoveGroupRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RemoveGroupRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RemoveGroupRequestMultiError) AllErrors() []error { return m }
+
+// RemoveGroupRequestValidationError is the validation error returned by
+// RemoveGroupRequest.Validate if the designated constraints aren't met.
+type RemoveGroupRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RemoveGroupRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RemoveGroupRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RemoveGroupRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RemoveGroupRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RemoveGroupRequestValidationError) ErrorName() string {
+	return "RemoveGroupRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RemoveGroupRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRemoveGroupRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RemoveGroupRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RemoveGroupRequestValidationError{}
+
+// Validate checks the field values on RemoveGroupResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RemoveGroupResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RemoveGroupResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RemoveGroupResponseMultiError, or nil if none found.
+func (m *RemoveGroupResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RemoveGroupResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Removed
+
+	if len(errors) > 0 {
+		return RemoveGroupResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RemoveGroupResponseMultiError is an error wrapping multiple validation
+// errors returned by RemoveGroupResponse.ValidateAll() if the designated
+// constraints aren't met.
+type RemoveGroupResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RemoveGroupResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RemoveGroupResponseMultiError) AllErrors() []error { return m }
+
+// RemoveGroupResponseValidationError is the validation error returned by
+// RemoveGroupResponse.Validate if the designated constraints aren't met.
+type RemoveGroupResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RemoveGroupResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RemoveGroupResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RemoveGroupResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RemoveGroupResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RemoveGroupResponseValidationError) ErrorName() string {
+	return "RemoveGroupResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RemoveGroupResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRemoveGroupResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RemoveGroupResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RemoveGroupResponseValidationError{}
+
+// Validate checks the field values on AddMembersRequest with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *AddMembersRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AddMembersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AddMembersRequestMultiError, or nil if none found.
+func (m *AddMembersRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AddMembersRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetIdGroup() <= 0 {
+		err := AddMembersRequestValidationError{
+			field:  "IdGroup",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := len(m.GetIdsUser()); l < 1 || l > 1000 {
+		err := AddMembersRequestValidationError{
+			field:  "IdsUser",
+			reason: "value must contain between 1 and 1000 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetIdsUser() {
+		_, _ = idx, item
+
+		if item <= 0 {
+			err := AddMembersRequestValidationError{
+				field:  fmt.Sprintf("IdsUser[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return AddMembersRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// AddMembersRequestMultiError is an error wrapping multiple validation errors
+// returned by AddMembersRequest.ValidateAll() if the designated constraints
+// aren't met.
+type AddMembersRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AddMembersRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AddMembersRequestMultiError) AllErrors() []error { return m }
+
+// AddMembersRequestValidationError is the validation error returned by
+// AddMembersRequest.Validate if the designated constraints aren't met.
+type AddMembersRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AddMembersRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AddMembersRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AddMembersRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AddMembersRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AddMembersRequestValidationError) ErrorName() string {
+	return "AddMembersRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e AddMembersRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAddMembersRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AddMembersRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AddMembersRequestValidationError{}
+
+// Validate checks the field values on AddMembersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *AddMembersResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AddMembersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AddMembersResponseMultiError, or nil if none found.
+func (m *AddMembersResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AddMembersResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return AddMembersResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// AddMembersResponseMultiError is an error wrapping multiple validation errors
+// returned by AddMembersResponse.ValidateAll() if the designated constraints
+// aren't met.
+type AddMembersResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AddMembersResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AddMembersResponseMultiError) AllErrors() []error { return m }
+
+// AddMembersResponseValidationError is the validation error returned by
+// AddMembersResponse.Validate if the designated constraints aren't met.
+type AddMembersResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AddMembersResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AddMembersResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AddMembersResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AddMembersResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AddMembersResponseValidationError) ErrorName() string {
+	return "AddMembersResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e AddMembersResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAddMembersResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AddMembersResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AddMembersResponseValidationError{}
+
+// Validate checks the field values on RemoveMembersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RemoveMembersRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RemoveMembersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RemoveMembersRequestMultiError, or nil if none found.
+func (m *RemoveMembersRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RemoveMembersRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetIdGroup() <= 0 {
+		err := RemoveMembersRequestValidationError{
+			field:  "IdGroup",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := len(m.GetIdsUser()); l < 1 || l > 1000 {
+		err := RemoveMembersRequestValidationError{
+			field:  "IdsUser",
+			reason: "value must contain between 1 and 1000 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetIdsUser() {
+		_, _ = idx, item
+
+		if item <= 0 {
+			err := RemoveMembersRequestValidationError{
+				field:  fmt.Sprintf("IdsUser[%v]", idx),
+				reason: "value must be greater than 0",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return RemoveMembersRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RemoveMembersRequestMultiError is an error wrapping multiple validation
+// errors returned by RemoveMembersRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RemoveMembersRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RemoveMembersRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RemoveMembersRequestMultiError) AllErrors() []error { return m }
+
+// RemoveMembersRequestValidationError is the validation error returned by
+// RemoveMembersRequest.Validate if the designated constraints aren't met.
+type RemoveMembersRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RemoveMembersRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RemoveMembersRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RemoveMembersRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RemoveMembersRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RemoveMembersRequestValidationError) ErrorName() string {
+	return "RemoveMembersRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RemoveMembersRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRemoveMembersRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RemoveMembersRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RemoveMembersRequestValidationError{}
+
+// Validate checks the field values on RemoveMembersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *RemoveMembersResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RemoveMembersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RemoveMembersResponseMultiError, or nil if none found.
+func (m *RemoveMembersResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RemoveMembersResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Removed
+
+	if len(errors) > 0 {
+		return RemoveMembersResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RemoveMembersResponseMultiError is an error wrapping multiple validation
+// errors returned by RemoveMembersResponse.ValidateAll() if the designated
+// constraints aren't met.
+type RemoveMembersResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RemoveMembersResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RemoveMembersResponseMultiError) AllErrors() []error { return m }
+
+// RemoveMembersResponseValidationError is the validation error returned by
+// RemoveMembersResponse.Validate if the designated constraints aren't met.
+type RemoveMembersResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RemoveMembersResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RemoveMembersResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RemoveMembersResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RemoveMembersResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RemoveMembersResponseValidationError) ErrorName() string {
+	return "RemoveMembersResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RemoveMembersResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRemoveMembersResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RemoveMembersResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RemoveMembersResponseValidationError{}
+
+// Validate checks the field values on ListMembersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListMembersRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListMembersRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListMembersRequestMultiError, or nil if none found.
+func (m *ListMembersRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListMembersRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetIdGroup() <= 0 {
+		err := ListMembersRequestValidationError{
+			field:  "IdGroup",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetLimit(); val <= 0 || val > 1000 {
+		err := ListMembersRequestValidationError{
+			field:  "Limit",
+			reason: "value must be inside range (0, 1000]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for Offset
+
+	if len(errors) > 0 {
+		return ListMembersRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListMembersRequestMultiError is an error wrapping multiple validation errors
+// returned by ListMembersRequest.ValidateAll() if the designated constraints
+// aren't met.
+type ListMembersRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListMembersRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListMembersRequestMultiError) AllErrors() []error { return m }
+
+// ListMembersRequestValidationError is the validation error returned by
+// ListMembersRequest.Validate if the designated constraints aren't met.
+type ListMembersRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListMembersRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListMembersRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListMembersRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListMembersRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListMembersRequestValidationError) ErrorName() string {
+	return "ListMembersRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListMembersRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListMembersRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListMembersRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListMembersRequestValidationError{}
+
+// Validate checks the field values on ListMembersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListMembersResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListMembersResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListMembersResponseMultiError, or nil if none found.
+func (m *ListMembersResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListMembersResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetItems() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListMembersResponseValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListMembersResponseValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListMembersResponseValidationError{
+					field:  fmt.Sprintf("Items[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListMembersResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListMembersResponseMultiError is an error wrapping multiple validation
+// errors returned by ListMembersResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListMembersResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListMembersResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListMembersResponseMultiError) AllErrors() []error { return m }
+
+// ListMembersResponseValidationError is the validation error returned by
+// ListMembersResponse.Validate if the designated constraints aren't met.
+type ListMembersResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListMembersResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListMembersResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListMembersResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListMembersResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListMembersResponseValidationError) ErrorName() string {
+	return "ListMembersResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListMembersResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListMembersResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListMembersResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListMembersResponseValidationError{}
+
+// Validate checks the field values on ListUserGroupsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListUserGroupsRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListUserGroupsRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListUserGroupsRequestMultiError, or nil if none found.
+func (m *ListUserGroupsRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListUserGroupsRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetIdUser() <= 0 {
+		err := ListUserGroupsRequestValidationError{
+			field:  "IdUser",
+			reason: "value must be greater than 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListUserGroupsRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListUserGroupsRequestMultiError is an error wrapping multiple validation
+// errors returned by ListUserGroupsRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ListUserGroupsRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListUserGroupsRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListUserGroupsRequestMultiError) AllErrors() []error { return m }
+
+// ListUserGroupsRequestValidationError is the validation error returned by
+// ListUserGroupsRequest.Validate if the designated constraints aren't met.
+type ListUserGroupsRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUserGroupsRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUserGroupsRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUserGroupsRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUserGroupsRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUserGroupsRequestValidationError) ErrorName() string {
+	return "ListUserGroupsRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListUserGroupsRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUserGroupsRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUserGroupsRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUserGroupsRequestValidationError{}
+
+// Validate checks the field values on ListUserGroupsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListUserGroupsResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListUserGroupsResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListUserGroupsResponseMultiError, or nil if none found.
+func (m *ListUserGroupsResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListUserGroupsResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetItems() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListUserGroupsResponseValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListUserGroupsResponseValidationError{
+						field:  fmt.Sprintf("Items[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListUserGroupsResponseValidationError{
+					field:  fmt.Sprintf("Items[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListUserGroupsResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListUserGroupsResponseMultiError is an error wrapping multiple validation
+// errors returned by ListUserGroupsResponse.ValidateAll() if the designated
+// constraints aren't met.
+type ListUserGroupsResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListUserGroupsResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListUserGroupsResponseMultiError) AllErrors() []error { return m }
+
+// ListUserGroupsResponseValidationError is the validation error returned by
+// ListUserGroupsResponse.Validate if the designated constraints aren't met.
+type ListUserGroupsResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListUserGroupsResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListUserGroupsResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListUserGroupsResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListUserGroupsResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListUserGroupsResponseValidationError) ErrorName() string {
+	return "ListUserGroupsResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListUserGroupsResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListUserGroupsResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListUserGroupsResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListUserGroupsResponseValidationError{}
+
+// Validate checks the field values on Identity with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Identity) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Identity with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in IdentityMultiError, or nil
+// if none found.
+func (m *Identity) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Identity) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetSubject()) > 256 {
+		err := IdentityValidationError{
+			field:  "Subject",
+			reason: "value length must be at most 256 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return IdentityMultiError(errors)
+	}
+
+	return nil
+}
+
+// IdentityMultiError is an error wrapping multiple validation errors returned
+// by Identity.ValidateAll() if the designated constraints aren't met.
+type IdentityMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m IdentityMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m IdentityMultiError) AllErrors() []error { return m }
+
+// IdentityValidationError is the validation error returned by
+// Identity.Validate if the designated constraints aren't met.
+type IdentityValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e IdentityValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e IdentityValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e IdentityValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e IdentityValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e IdentityValidationError) ErrorName() string { return "IdentityValidationError" }
+
+// Error satisfies the builtin error interface
+func (e IdentityValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sIdentity.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = IdentityValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = IdentityValidationError{}
+
+// Validate checks the field values on CheckAccessRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CheckAccessRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CheckAccessRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CheckAccessRequestMultiError, or nil if none found.
+func (m *CheckAccessRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CheckAccessRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetMethod()) > 256 {
+		err := CheckAccessRequestValidationError{
+			field:  "Method",
+			reason: "value length must be at most 256 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_CheckAccessRequest_Method_Pattern.MatchString(m.GetMethod()) {
+		err := CheckAccessRequestValidationError{
+			field:  "Method",
+			reason: "value does not match regex pattern \"^/[^/]+/[^/]+$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetIdentity()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CheckAccessRequestValidationError{
+					field:  "Identity",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CheckAccessRequestValidationError{
+					field:  "Identity",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetIdentity()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CheckAccessRequestValidationError{
+				field:  "Identity",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CheckAccessRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// CheckAccessRequestMultiError is an error wrapping multiple validation errors
+// returned by CheckAccessRequest.ValidateAll() if the designated constraints
+// aren't met.
+type CheckAccessRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CheckAccessRequestMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CheckAccessRequestMultiError) AllErrors() []error { return m }
+
+// CheckAccessRequestValidationError is the validation error returned by
+// CheckAccessRequest.Validate if the designated constraints aren't met.
+type CheckAccessRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CheckAccessRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CheckAccessRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CheckAccessRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CheckAccessRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CheckAccessRequestValidationError) ErrorName() string {
+	return "CheckAccessRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CheckAccessRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCheckAccessRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CheckAccessRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CheckAccessRequestValidationError{}
+
+var _CheckAccessRequest_Method_Pattern = regexp.MustCompile("^/[^/]+/[^/]+$")
+
+// Validate checks the field values on CheckAccessResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *CheckAccessResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CheckAccessResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CheckAccessResponseMultiError, or nil if none found.
+func (m *CheckAccessResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CheckAccessResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Allowed
+
+	// no validation rules for Reason
+
+	if all {
+		switch v := interface{}(m.GetIdentity()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, CheckAccessResponseValidationError{
+					field:  "Identity",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, CheckAccessResponseValidationError{
+					field:  "Identity",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetIdentity()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return CheckAccessResponseValidationError{
+				field:  "Identity",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return CheckAccessResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// CheckAccessResponseMultiError is an error wrapping multiple validation
+// errors returned by CheckAccessResponse.ValidateAll() if the designated
+// constraints aren't met.
+type CheckAccessResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CheckAccessResponseMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CheckAccessResponseMultiError) AllErrors() []error { return m }
+
+// CheckAccessResponseValidationError is the validation error returned by
+// CheckAccessResponse.Validate if the designated constraints aren't met.
+type CheckAccessResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CheckAccessResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CheckAccessResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CheckAccessResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CheckAccessResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CheckAccessResponseValidationError) ErrorName() string {
+	return "CheckAccessResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CheckAccessResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCheckAccessResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CheckAccessResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CheckAccessResponseValidationError{}
 
 // Validate checks the field values on UserRequestPayload with the rules
 // defined in the proto definition for this message. If any rules are